@@ -1,45 +1,234 @@
 package main
 
 import (
+	"bytes"
 	_ "embed"
 	"fmt"
+	"go/format"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
+	"unicode"
 )
 
 //go:embed templates/typescript.tmpl
 var typescriptTemplate string
 
+//go:embed templates/params.tmpl
+var paramsTemplate string
+
+//go:embed templates/client.tmpl
+var clientTemplate string
+
 // Generator generates TypeScript code
 type Generator struct {
 	outputDir string
 	tmpl      *template.Template
 }
 
-// toTSType converts Go type names to TypeScript types
-func toTSType(goType string) string {
-	switch {
-	case goType == "string":
+// toTSType converts a resolved Go type to the TypeScript type of its JSON
+// encoding, qualifying declared types by their module
+func toTSType(t *GoType) string {
+	return tsTypeIn(t, "")
+}
+
+// tsTypeIn converts t for use in module, where its own types are unqualified
+func tsTypeIn(t *GoType, module string) string {
+	if t == nil {
+		return "any"
+	}
+	switch t.Kind {
+	case KindNamed:
+		if t.Module == module {
+			return t.Name
+		}
+		return t.Module + "." + t.Name
+	case KindPointer:
+		return tsTypeIn(t.Elem, module) + " | null"
+	case KindSlice:
+		elem := tsTypeIn(t.Elem, module)
+		if strings.Contains(elem, " | ") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	case KindMap:
+		return "Record<string, " + tsTypeIn(t.Elem, module) + ">"
+	case KindStruct:
+		if len(t.Fields) == 0 {
+			return "Record<string, never>"
+		}
+		fields := make([]string, 0, len(t.Fields))
+		for _, f := range t.Fields {
+			fields = append(fields, tsField(f, module)+";")
+		}
+		return "{ " + strings.Join(fields, " ") + " }"
+	}
+
+	switch goType := t.Name; {
+	case goType == "string", goType == "[]byte", goType == "time.Time":
 		return "string"
 	case goType == "bool":
 		return "boolean"
-	case goType == "int" || goType == "int64" || goType == "float64" || goType == "float32":
+	case goType == "int" || goType == "int8" || goType == "int16" || goType == "int32" || goType == "int64",
+		goType == "uint" || goType == "uint8" || goType == "uint16" || goType == "uint32" || goType == "uint64",
+		goType == "float64" || goType == "float32" || goType == "byte" || goType == "rune",
+		goType == "time.Duration":
 		return "number"
-	case strings.HasPrefix(goType, "map["):
-		return "Record<string, any>"
-	case strings.HasPrefix(goType, "[]"):
-		return "any[]"
-	case goType == "interface{}" || goType == "interface {}" || goType == "":
-		return "any"
 	case goType == "error":
 		return "string"
+	case isStreamType(goType):
+		return "Blob"
 	default:
 		return "any"
 	}
 }
 
+// tsField writes a struct field as a TypeScript property. An optional
+// pointer is left out rather than null.
+func tsField(f Field, module string) string {
+	name := f.Name
+	if !isTSIdentifier(name) {
+		name = "'" + strings.ReplaceAll(name, "'", `\'`) + "'"
+	}
+	// The string option quotes numbers and booleans
+	if hasOption(f.Options, "string") && f.Type.Kind == KindBasic {
+		f.Type = &GoType{Kind: KindBasic, Name: "string"}
+	}
+	if f.Optional {
+		typ := f.Type
+		if typ.Kind == KindPointer {
+			typ = typ.Elem
+		}
+		return name + "?: " + tsTypeIn(typ, module)
+	}
+	return name + ": " + tsTypeIn(f.Type, module)
+}
+
+// tsValues writes the constants of decl as TypeScript literals
+func tsValues(decl *TypeDecl) []string {
+	var values []string
+	for _, c := range decl.Consts {
+		value := c.Value
+		if s, err := strconv.Unquote(value); err == nil {
+			if value[0] == '\'' {
+				// A rune is encoded as its number
+				value = strconv.Itoa(int([]rune(s)[0]))
+			} else {
+				value = "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`, "\n", `\n`).Replace(s) + "'"
+			}
+		}
+		if !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func isTSIdentifier(name string) bool {
+	for i, r := range name {
+		if r != '_' && r != '$' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return name != ""
+}
+
+// tsModules lists the modules of the declared types that types refer to,
+// other than module itself
+func tsModules(module string, types ...*GoType) []string {
+	seen := map[string]bool{}
+	var walk func(t *GoType)
+	walk = func(t *GoType) {
+		if t == nil {
+			return
+		}
+		if t.Kind == KindNamed && t.Module != module {
+			seen[t.Module] = true
+		}
+		walk(t.Elem)
+		for _, f := range t.Fields {
+			walk(f.Type)
+		}
+	}
+	for _, t := range types {
+		walk(t)
+	}
+
+	modules := make([]string, 0, len(seen))
+	for m := range seen {
+		modules = append(modules, m)
+	}
+	sort.Strings(modules)
+	return modules
+}
+
+// usedTypes returns the declarations in types that the methods of services
+// use, directly or through other declarations, in their original order
+func usedTypes(types []*TypeDecl, services []Service) []*TypeDecl {
+	decls := map[string]*TypeDecl{}
+	for _, decl := range types {
+		decls[decl.Module+"."+decl.Name] = decl
+	}
+
+	used := map[*TypeDecl]bool{}
+	var walk func(t *GoType)
+	walk = func(t *GoType) {
+		if t == nil {
+			return
+		}
+		if t.Kind == KindNamed {
+			decl := decls[t.Module+"."+t.Name]
+			if decl == nil || used[decl] {
+				return
+			}
+			used[decl] = true
+			walk(decl.Type)
+			return
+		}
+		walk(t.Elem)
+		for _, f := range t.Fields {
+			walk(f.Type)
+		}
+	}
+	for _, service := range services {
+		for _, method := range service.Methods {
+			for _, param := range method.Parameters {
+				walk(param.Resolved)
+			}
+			for _, r := range method.Returns {
+				walk(r.Resolved)
+			}
+		}
+	}
+
+	var out []*TypeDecl
+	for _, decl := range types {
+		if used[decl] {
+			out = append(out, decl)
+		}
+	}
+	return out
+}
+
+// isStreamType reports whether a Go type is sent or received as raw bytes
+func isStreamType(goType string) bool {
+	return goType == "io.Reader"
+}
+
+// uploadParam returns the name of the first parameter sent as a file upload, or ""
+func uploadParam(params []Parameter) string {
+	for _, p := range params {
+		if isStreamType(p.Type) {
+			return p.Name
+		}
+	}
+	return ""
+}
+
 // isPrimitiveType returns true for primitive Go types that should be wrapped in { value: ... }
 func isPrimitiveType(goType string) bool {
 	switch {
@@ -55,14 +244,27 @@ func isPrimitiveType(goType string) bool {
 // NewGenerator creates a new generator
 func NewGenerator(outputDir string) (*Generator, error) {
 	funcMap := template.FuncMap{
-		"tsType":       toTSType,
-		"toKebabCase":  toKebabCase,
-		"isPrimitive":  isPrimitiveType,
+		"tsType":      toTSType,
+		"tsTypeIn":    tsTypeIn,
+		"tsField":     tsField,
+		"toKebabCase": toKebabCase,
+		"isPrimitive": isPrimitiveType,
+		"isStream":    isStreamType,
+		"uploadParam": uploadParam,
+		"isStruct":    func(t *GoType) bool { return t != nil && t.Kind == KindStruct },
+		"join":        strings.Join,
+		"tsValues":    tsValues,
 	}
 	tmpl, err := template.New("typescript").Funcs(funcMap).Parse(typescriptTemplate)
 	if err != nil {
 		return nil, err
 	}
+	if _, err := tmpl.Parse(paramsTemplate); err != nil {
+		return nil, err
+	}
+	if _, err := tmpl.Parse(clientTemplate); err != nil {
+		return nil, err
+	}
 
 	return &Generator{
 		outputDir: outputDir,
@@ -70,8 +272,8 @@ func NewGenerator(outputDir string) (*Generator, error) {
 	}, nil
 }
 
-// Generate creates TypeScript files for all services
-func (g *Generator) Generate(services []Service) error {
+// Generate creates TypeScript files for all services and the types they use
+func (g *Generator) Generate(services []Service, types []*TypeDecl) error {
 	// Create output directories
 	wailsDir := filepath.Join(g.outputDir, "wails")
 	httpDir := filepath.Join(g.outputDir, "http")
@@ -79,15 +281,35 @@ func (g *Generator) Generate(services []Service) error {
 	os.MkdirAll(wailsDir, 0755)
 	os.MkdirAll(httpDir, 0755)
 
-	// Generate individual service files (HTTP only — Wails bindings require `wails generate`)
+	// The HTTP clients only call methods with a result; the others, and
+	// the types only they use, are left out
+	var emitted []Service
 	for _, service := range services {
+		var methods []ServiceMethod
+		for _, method := range service.Methods {
+			if len(method.Returns) > 0 {
+				methods = append(methods, method)
+			}
+		}
+		if len(methods) > 0 {
+			emitted = append(emitted, Service{Name: service.Name, Methods: methods})
+		}
+	}
+
+	// Generate individual service files (HTTP only — Wails bindings require `wails generate`)
+	for _, service := range emitted {
 		if err := g.generateHTTPService(httpDir, service); err != nil {
 			return err
 		}
 	}
 
+	modules, err := g.generateTypes(filepath.Join(httpDir, "types"), usedTypes(types, emitted))
+	if err != nil {
+		return err
+	}
+
 	// Generate index files
-	if err := g.generateHTTPIndex(httpDir, services); err != nil {
+	if err := g.generateHTTPIndex(httpDir, emitted, modules); err != nil {
 		return err
 	}
 
@@ -97,11 +319,23 @@ func (g *Generator) Generate(services []Service) error {
 func (g *Generator) generateHTTPService(dir string, service Service) error {
 	filename := filepath.Join(dir, toCamelCase(service.Name)+".ts")
 
+	var used []*GoType
+	for _, method := range service.Methods {
+		for _, param := range method.Parameters {
+			used = append(used, param.Resolved)
+		}
+		if len(method.Returns) > 0 {
+			used = append(used, method.Returns[0].Resolved)
+		}
+	}
+
 	data := struct {
 		ServiceName string
+		Imports     []string
 		Methods     []ServiceMethod
 	}{
 		ServiceName: service.Name,
+		Imports:     tsModules("", used...),
 		Methods:     service.Methods,
 	}
 
@@ -114,7 +348,53 @@ func (g *Generator) generateHTTPService(dir string, service Service) error {
 	return g.tmpl.ExecuteTemplate(file, "http", data)
 }
 
-func (g *Generator) generateHTTPIndex(dir string, services []Service) error {
+// generateTypes writes a file of interfaces and unions for each module,
+// returning the modules written
+func (g *Generator) generateTypes(dir string, types []*TypeDecl) ([]string, error) {
+	byModule := map[string][]*TypeDecl{}
+	var modules []string
+	for _, decl := range types {
+		if _, ok := byModule[decl.Module]; !ok {
+			modules = append(modules, decl.Module)
+		}
+		byModule[decl.Module] = append(byModule[decl.Module], decl)
+	}
+	if len(modules) == 0 {
+		return nil, nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	for _, module := range modules {
+		decls := byModule[module]
+		var used []*GoType
+		for _, decl := range decls {
+			used = append(used, decl.Type)
+		}
+
+		data := struct {
+			Module  string
+			Imports []string
+			Types   []*TypeDecl
+		}{
+			Module:  module,
+			Imports: tsModules(module, used...),
+			Types:   decls,
+		}
+
+		var buf bytes.Buffer
+		if err := g.tmpl.ExecuteTemplate(&buf, "types", data); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(filepath.Join(dir, module+".ts"), buf.Bytes(), 0644); err != nil {
+			return nil, err
+		}
+	}
+	return modules, nil
+}
+
+func (g *Generator) generateHTTPIndex(dir string, services []Service, modules []string) error {
 	filename := filepath.Join(dir, "index.ts")
 
 	var exports []string
@@ -122,12 +402,36 @@ func (g *Generator) generateHTTPIndex(dir string, services []Service) error {
 		exports = append(exports, fmt.Sprintf("export * as %s from './%s';",
 			toCamelCase(svc.Name), toCamelCase(svc.Name)))
 	}
+	for _, module := range modules {
+		exports = append(exports, fmt.Sprintf("export type * as %s from './types/%s';", module, module))
+	}
 
 	content := strings.Join(exports, "\n")
 	return os.WriteFile(filename, []byte(content), 0644)
 }
 
+// GenerateParams writes the Go registry of method parameter names used by the HTTP router
+func (g *Generator) GenerateParams(filename, pkgName string, services []Service) error {
+	data := struct {
+		Package  string
+		Services []Service
+	}{
+		Package:  pkgName,
+		Services: services,
+	}
 
+	var buf bytes.Buffer
+	if err := g.tmpl.ExecuteTemplate(&buf, "params", data); err != nil {
+		return err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("format %s: %w", filename, err)
+	}
+
+	return os.WriteFile(filename, src, 0644)
+}
 
 // toCamelCase converts PascalCase to camelCase
 func toCamelCase(s string) string {
@@ -0,0 +1,362 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// goImports collects the imports a generated Go file needs
+type goImports map[string]bool
+
+// list returns the imports sorted, the standard library's before the
+// subpackages of client
+func (imports goImports) list(client string) []string {
+	var std, local []string
+	for importPath := range imports {
+		if strings.HasPrefix(importPath, client+"/") {
+			local = append(local, importPath)
+		} else {
+			std = append(std, importPath)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(local)
+	if len(std) > 0 && len(local) > 0 {
+		std = append(std, "")
+	}
+	return append(std, local...)
+}
+
+// goTypeIn converts t to a Go type expression for use in module, recording
+// the imports it needs. Declared types live in one subpackage of client per
+// module.
+func goTypeIn(t *GoType, module, client string, imports goImports) string {
+	if t == nil {
+		return "interface{}"
+	}
+	switch t.Kind {
+	case KindNamed:
+		if t.Module == module {
+			return t.Name
+		}
+		imports[path.Join(client, t.Module)] = true
+		return t.Module + "." + t.Name
+	case KindPointer:
+		return "*" + goTypeIn(t.Elem, module, client, imports)
+	case KindSlice:
+		return "[]" + goTypeIn(t.Elem, module, client, imports)
+	case KindMap:
+		return "map[string]" + goTypeIn(t.Elem, module, client, imports)
+	case KindStruct:
+		var b strings.Builder
+		b.WriteString("struct {\n")
+		for _, f := range t.Fields {
+			fmt.Fprintf(&b, "%s %s %s\n", f.GoName, goTypeIn(f.Type, module, client, imports), goTag(f))
+		}
+		b.WriteString("}")
+		return b.String()
+	}
+
+	switch t.Name {
+	case "time.Time", "time.Duration":
+		imports["time"] = true
+		return t.Name
+	case "json.RawMessage":
+		imports["encoding/json"] = true
+		return t.Name
+	case "io.Reader":
+		imports["io"] = true
+		return t.Name
+	case "error":
+		return "string"
+	}
+	if strings.Contains(t.Name, ".") {
+		// Other types from outside the module are decoded generically
+		return "interface{}"
+	}
+	return t.Name
+}
+
+// goTag writes the json tag of a generated struct field
+func goTag(f Field) string {
+	tag := f.Name
+	if f.Options != "" {
+		tag += "," + f.Options
+	}
+	return "`json:\"" + tag + "\"`"
+}
+
+// goField is a field of a generated struct
+type goField struct {
+	Name string
+	Type string
+	Tag  string
+}
+
+// goDecl is a generated type declaration
+type goDecl struct {
+	Name   string
+	Fields []goField
+	// Struct types have Fields; the others are Type
+	Struct bool
+	Type   string
+	Consts []Constant
+}
+
+// goParam is a parameter of a generated client method
+type goParam struct {
+	// Name is the Go parameter name and Key its name in the request
+	Name string
+	Key  string
+	Type string
+	// String parameters are sent as form fields as they are, the others as
+	// JSON
+	String bool
+}
+
+// goMethod is a generated client method
+type goMethod struct {
+	Name   string
+	Path   string
+	Params []goParam
+	// Result is the type of the method's result, or "" when it has none
+	Result string
+	// Stream results are the raw response body
+	Stream bool
+	// Body is how the parameters are sent: "none", "struct" for a struct
+	// sent as the body, "value" for one value, "fields" for named fields or
+	// "upload" for a multipart form
+	Body   string
+	Upload string
+}
+
+// GenerateClient writes a Go package to dir that calls every service the
+// HTTP server routes. importPath is the package's import path; the types the
+// services use are written to one subpackage per module beneath it.
+func (g *Generator) GenerateClient(dir, importPath string, services []Service, types []*TypeDecl) error {
+	services = clientServices(services)
+	types = usedTypes(types, services)
+	byModule := map[string][]*TypeDecl{}
+	for _, decl := range types {
+		byModule[decl.Module] = append(byModule[decl.Module], decl)
+	}
+	structs := map[string]bool{}
+	for _, decl := range types {
+		structs[decl.Module+"."+decl.Name] = decl.Type != nil && decl.Type.Kind == KindStruct
+	}
+
+	for module, decls := range byModule {
+		if err := g.generateClientTypes(filepath.Join(dir, module), module, importPath, decls); err != nil {
+			return err
+		}
+	}
+
+	if err := g.writeGo(filepath.Join(dir, "client.go"), "goClient", struct {
+		Package  string
+		Services []Service
+	}{
+		Package:  path.Base(importPath),
+		Services: services,
+	}); err != nil {
+		return err
+	}
+
+	for _, service := range services {
+		imports := goImports{"context": true}
+		var methods []goMethod
+		for _, method := range service.Methods {
+			methods = append(methods, clientMethod(service, method, importPath, structs, imports))
+		}
+		filename := strings.ReplaceAll(toKebabCase(service.Name), "-", "_") + ".go"
+		if err := g.writeGo(filepath.Join(dir, filename), "goService", struct {
+			Package     string
+			Imports     []string
+			ServiceName string
+			Methods     []goMethod
+		}{
+			Package:     path.Base(importPath),
+			Imports:     imports.list(importPath),
+			ServiceName: service.Name,
+			Methods:     methods,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clientServices returns the services the client calls: those the HTTP
+// server routes, without the methods whose parameters cannot be sent
+func clientServices(services []Service) []Service {
+	var out []Service
+	for _, service := range services {
+		if service.Desktop {
+			continue
+		}
+		var methods []ServiceMethod
+		for _, method := range service.Methods {
+			if sendable(method.Parameters) {
+				methods = append(methods, method)
+			}
+		}
+		if len(methods) > 0 {
+			out = append(out, Service{Name: service.Name, Methods: methods})
+		}
+	}
+	return out
+}
+
+// sendable reports whether every parameter can be sent in a request: none
+// is of a type from outside the module that the client has no encoding for,
+// such as *application.App
+func sendable(params []Parameter) bool {
+	var ok func(t *GoType) bool
+	ok = func(t *GoType) bool {
+		switch {
+		case t == nil:
+			return true
+		case t.Kind == KindBasic:
+			switch t.Name {
+			case "time.Time", "time.Duration", "json.RawMessage", "io.Reader":
+				return true
+			}
+			return !strings.Contains(t.Name, ".")
+		case t.Kind == KindStruct:
+			for _, f := range t.Fields {
+				if !ok(f.Type) {
+					return false
+				}
+			}
+			return true
+		}
+		return ok(t.Elem)
+	}
+	for _, p := range params {
+		if !ok(p.Resolved) {
+			return false
+		}
+	}
+	return true
+}
+
+// clientMethod describes how the client calls method
+func clientMethod(service Service, method ServiceMethod, client string, structs map[string]bool, imports goImports) goMethod {
+	m := goMethod{
+		Name: method.Name,
+		Path: "/api/" + toKebabCase(service.Name) + "/" + toKebabCase(method.Name),
+		Body: "fields",
+	}
+
+	for _, p := range method.Parameters {
+		param := goParam{
+			Name: goParamName(p.Name),
+			Key:  p.Name,
+			Type: goTypeIn(p.Resolved, "", client, imports),
+		}
+		param.String = p.Resolved != nil && p.Resolved.Kind == KindBasic && p.Resolved.Name == "string"
+		if isStreamType(p.Type) && m.Upload == "" {
+			m.Upload = param.Name
+		}
+		m.Params = append(m.Params, param)
+	}
+
+	switch {
+	case m.Upload != "":
+		m.Body = "upload"
+		imports["io"] = true
+	case len(m.Params) == 0:
+		m.Body = "none"
+	case len(m.Params) == 1:
+		m.Body = "value"
+		t := method.Parameters[0].Resolved
+		if t != nil && (t.Kind == KindStruct || t.Kind == KindNamed && structs[t.Module+"."+t.Name]) {
+			m.Body = "struct"
+		}
+	}
+
+	for _, r := range method.Returns {
+		if r.Type == "error" {
+			continue
+		}
+		if isStreamType(r.Type) {
+			m.Stream = true
+			m.Result = "io.ReadCloser"
+			imports["io"] = true
+		} else {
+			m.Result = goTypeIn(r.Resolved, "", client, imports)
+		}
+		break
+	}
+	return m
+}
+
+// goParamName keeps parameter names clear of the generated code's own
+// identifiers
+func goParamName(name string) string {
+	switch name {
+	case "svc", "ctx", "out", "body", "contentType", "err":
+		return name + "Arg"
+	}
+	if token.IsKeyword(name) {
+		return name + "Arg"
+	}
+	return name
+}
+
+// generateClientTypes writes the types of module to its subpackage
+func (g *Generator) generateClientTypes(dir, module, client string, decls []*TypeDecl) error {
+	imports := goImports{}
+	var out []goDecl
+	for _, decl := range decls {
+		d := goDecl{Name: decl.Name, Consts: decl.Consts}
+		if decl.Type != nil && decl.Type.Kind == KindStruct {
+			d.Struct = true
+			for _, f := range decl.Type.Fields {
+				d.Fields = append(d.Fields, goField{
+					Name: f.GoName,
+					Type: goTypeIn(f.Type, module, client, imports),
+					Tag:  goTag(f),
+				})
+			}
+		} else {
+			d.Type = goTypeIn(decl.Type, module, client, imports)
+		}
+		out = append(out, d)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return g.writeGo(filepath.Join(dir, "types.go"), "goTypes", struct {
+		Package string
+		Imports []string
+		Types   []goDecl
+	}{
+		Package: module,
+		Imports: imports.list(client),
+		Types:   out,
+	})
+}
+
+// writeGo executes the named template and writes the result, formatted
+func (g *Generator) writeGo(filename, name string, data interface{}) error {
+	var buf bytes.Buffer
+	if err := g.tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		return err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("format %s: %w", filename, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filename, src, 0644)
+}
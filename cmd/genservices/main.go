@@ -4,13 +4,17 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"path"
 	"path/filepath"
+	"strings"
 )
 
 func main() {
 	var (
 		serviceDir = flag.String("services", "service", "Directory containing Go service files")
 		outputDir  = flag.String("output", "frontend/src/generated", "Output directory for generated TypeScript")
+		paramsFile = flag.String("params", "", "Output file for the Go parameter-name registry (default: <services>/params_gen.go)")
+		clientDir  = flag.String("client", "pkg/client", "Output directory for the Go HTTP client package; empty to skip it")
 	)
 	flag.Parse()
 
@@ -46,9 +50,36 @@ func main() {
 		log.Fatal("Failed to create generator:", err)
 	}
 
-	if err := generator.Generate(services); err != nil {
+	if err := generator.Generate(services, parser.Types()); err != nil {
 		log.Fatal("Failed to generate TypeScript:", err)
 	}
 
+	// Generate Go parameter-name registry for the HTTP router
+	if *paramsFile == "" {
+		*paramsFile = filepath.Join(absServiceDir, "params_gen.go")
+	}
+	if err := generator.GenerateParams(*paramsFile, filepath.Base(absServiceDir), services); err != nil {
+		log.Fatal("Failed to generate parameter registry:", err)
+	}
+	fmt.Printf("Generating parameter registry to: %s\n", *paramsFile)
+
+	// Generate the Go HTTP client, importable from within the module
+	if *clientDir != "" {
+		absClientDir, err := filepath.Abs(*clientDir)
+		if err != nil {
+			log.Fatal(err)
+		}
+		modulePath, moduleDir := parser.Module()
+		rel, err := filepath.Rel(moduleDir, absClientDir)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			log.Fatalf("Go client directory %s is outside the module", absClientDir)
+		}
+		importPath := path.Join(modulePath, filepath.ToSlash(rel))
+		if err := generator.GenerateClient(absClientDir, importPath, services, parser.Types()); err != nil {
+			log.Fatal("Failed to generate Go client:", err)
+		}
+		fmt.Printf("Generating Go client to: %s\n", absClientDir)
+	}
+
 	fmt.Println("✓ Generation complete!")
 }
@@ -1,15 +1,28 @@
 package main
 
 import (
+	"bufio"
+	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // ServiceMethod represents a method in a service
 type ServiceMethod struct {
-	Name       string
+	Name string
+	// Doc is the first sentence of the method's doc comment, without the
+	// method's name
+	Doc        string
 	Parameters []Parameter
 	Returns    []Parameter
 }
@@ -18,54 +31,304 @@ type ServiceMethod struct {
 type Parameter struct {
 	Name string
 	Type string
+	// Resolved is Type followed to the types it is encoded as
+	Resolved *GoType
 }
 
 // Service represents a parsed service
 type Service struct {
 	Name    string
 	Methods []ServiceMethod
+	// Desktop services, marked //genservices:desktop, are bound to the
+	// desktop app only and not routed by the HTTP server
+	Desktop bool
+}
+
+// desktopDirective marks a service the HTTP server does not route
+const desktopDirective = "//genservices:desktop"
+
+// TypeKind classifies a resolved type by how encoding/json writes it
+type TypeKind int
+
+const (
+	// KindBasic is a predeclared type, or a type from outside the module
+	// named by its qualified name, such as time.Time
+	KindBasic TypeKind = iota
+	// KindNamed is a type declared in the module, described by a TypeDecl
+	KindNamed
+	KindSlice
+	KindMap
+	KindPointer
+	// KindStruct is an anonymous struct
+	KindStruct
+)
+
+// GoType is a Go type resolved as far as its JSON encoding needs
+type GoType struct {
+	Kind TypeKind
+	// Name is the basic type's name or the declared type's name
+	Name string
+	// Module is the TypeScript module a declared type is emitted in
+	Module string
+	// Elem is the element type of slices and pointers and the value type of
+	// maps
+	Elem *GoType
+	// Fields are the fields of an anonymous struct
+	Fields []Field
+}
+
+// Field is a struct field as it appears in JSON
+type Field struct {
+	// Name is the JSON name and GoName the Go one
+	Name   string
+	GoName string
+	Type   *GoType
+	// Options are the json tag options, such as omitempty
+	Options string
+	// Optional fields are left out when empty (omitempty or omitzero)
+	Optional bool
+}
+
+// Constant is a typed constant declared with a literal value
+type Constant struct {
+	Name string
+	// Value is the literal as written in Go
+	Value string
+}
+
+// TypeDecl is a declared type reachable from a service method
+type TypeDecl struct {
+	Module string
+	Name   string
+	// Type is the underlying type, a KindStruct for structs
+	Type *GoType
+	// Consts are the typed constants declared for a string or number type
+	Consts []Constant
+}
+
+// goPackage is a parsed package of the module
+type goPackage struct {
+	path    string
+	name    string
+	module  string
+	files   map[string]*ast.File
+	specs   map[string]*ast.TypeSpec
+	specIn  map[string]*ast.File
+	consts  map[string][]Constant
+	methods map[string]bool
+	imports map[*ast.File]map[string]string
 }
 
 // Parser parses Go service files
 type Parser struct {
 	serviceDir string
+	modulePath string
+	moduleDir  string
+	packages   map[string]*goPackage
+	modules    map[string]string
+	types      map[string]*TypeDecl
 }
 
 // NewParser creates a new parser
 func NewParser(serviceDir string) *Parser {
-	return &Parser{serviceDir: serviceDir}
+	return &Parser{
+		serviceDir: serviceDir,
+		packages:   map[string]*goPackage{},
+		modules:    map[string]string{},
+		types:      map[string]*TypeDecl{},
+	}
 }
 
 // ParseServices parses all service files in the directory
 func (p *Parser) ParseServices() ([]Service, error) {
-	fset := token.NewFileSet()
-
-	// Parse all Go files in service directory
-	pkgs, err := parser.ParseDir(fset, p.serviceDir, nil, 0)
+	if err := p.findModule(); err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(p.moduleDir, p.serviceDir)
+	if err != nil {
+		return nil, err
+	}
+	pkg, err := p.loadPackage(path.Join(p.modulePath, filepath.ToSlash(rel)))
 	if err != nil {
 		return nil, err
 	}
 
+	// Files are visited in order so that resolved types are too
+	filenames := make([]string, 0, len(pkg.files))
+	for filename := range pkg.files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
 	var services []Service
+	for _, filename := range filenames {
+		service := p.parseFile(pkg, pkg.files[filename])
+		if service != nil {
+			services = append(services, *service)
+		}
+	}
 
-	for _, pkg := range pkgs {
-		for filename, file := range pkg.Files {
-			if strings.HasSuffix(filename, "_test.go") {
-				continue
-			}
+	// Map iteration order is random; keep generated output stable
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].Name < services[j].Name
+	})
+
+	return services, nil
+}
+
+// Types returns the declared types the parsed services use, ordered by
+// module and name
+func (p *Parser) Types() []*TypeDecl {
+	decls := make([]*TypeDecl, 0, len(p.types))
+	for _, decl := range p.types {
+		decls = append(decls, decl)
+	}
+	sort.Slice(decls, func(i, j int) bool {
+		if decls[i].Module != decls[j].Module {
+			return decls[i].Module < decls[j].Module
+		}
+		return decls[i].Name < decls[j].Name
+	})
+	return decls
+}
 
-			service := p.parseFile(file)
-			if service != nil {
-				services = append(services, *service)
+// Module returns the path and directory of the module the services are in
+func (p *Parser) Module() (path, dir string) {
+	return p.modulePath, p.moduleDir
+}
+
+// findModule finds the go.mod above the service directory
+func (p *Parser) findModule() error {
+	for dir := p.serviceDir; ; dir = filepath.Dir(dir) {
+		f, err := os.Open(filepath.Join(dir, "go.mod"))
+		if err == nil {
+			defer f.Close()
+			scanner := bufio.NewScanner(f)
+			for scanner.Scan() {
+				if mod, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+					p.modulePath = strings.Trim(strings.TrimSpace(mod), `"`)
+					p.moduleDir = dir
+					return nil
+				}
 			}
+			return fmt.Errorf("%s: no module directive", f.Name())
+		}
+		if filepath.Dir(dir) == dir {
+			return fmt.Errorf("no go.mod found above %s", p.serviceDir)
 		}
 	}
+}
 
-	return services, nil
+// loadPackage parses the package of the module at importPath
+func (p *Parser) loadPackage(importPath string) (*goPackage, error) {
+	if pkg, ok := p.packages[importPath]; ok {
+		return pkg, nil
+	}
+
+	dir := filepath.Join(p.moduleDir, filepath.FromSlash(strings.TrimPrefix(importPath, p.modulePath)))
+	fset := token.NewFileSet()
+	notTest := func(fi os.FileInfo) bool { return !strings.HasSuffix(fi.Name(), "_test.go") }
+	pkgs, err := parser.ParseDir(fset, dir, notTest, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	var astPkg *ast.Package
+	for _, candidate := range pkgs {
+		astPkg = candidate
+	}
+	if astPkg == nil {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+
+	pkg := &goPackage{
+		path:    importPath,
+		name:    astPkg.Name,
+		module:  p.moduleName(importPath, astPkg.Name),
+		files:   astPkg.Files,
+		specs:   map[string]*ast.TypeSpec{},
+		specIn:  map[string]*ast.File{},
+		consts:  map[string][]Constant{},
+		methods: map[string]bool{},
+		imports: map[*ast.File]map[string]string{},
+	}
+	p.packages[importPath] = pkg
+
+	filenames := make([]string, 0, len(pkg.files))
+	for filename := range pkg.files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+	for _, filename := range filenames {
+		p.indexFile(pkg, pkg.files[filename])
+	}
+	return pkg, nil
+}
+
+// moduleName picks the TypeScript module name of a package: its Go name,
+// or its import path when two packages share a name
+func (p *Parser) moduleName(importPath, name string) string {
+	if _, taken := p.modules[name]; taken {
+		rel := strings.TrimPrefix(strings.TrimPrefix(importPath, p.modulePath), "/")
+		name = strings.NewReplacer("/", "_", "-", "_", ".", "_").Replace(rel)
+	}
+	p.modules[name] = importPath
+	return name
+}
+
+// indexFile records the types, typed constants, methods and imports of file
+func (p *Parser) indexFile(pkg *goPackage, file *ast.File) {
+	// Packages are assumed to be named after their directory unless
+	// imported under another name; importPath checks the rest
+	imports := map[string]string{}
+	for _, imp := range file.Imports {
+		importPath, _ := strconv.Unquote(imp.Path.Value)
+		name := path.Base(importPath)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		imports[name] = importPath
+	}
+	pkg.imports[file] = imports
+
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv != nil && len(decl.Recv.List) > 0 {
+				recv := strings.TrimPrefix(p.getTypeString(decl.Recv.List[0].Type), "*")
+				pkg.methods[recv+"."+decl.Name.Name] = true
+			}
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					if _, seen := pkg.specs[spec.Name.Name]; !seen {
+						pkg.specs[spec.Name.Name] = spec
+						pkg.specIn[spec.Name.Name] = file
+					}
+				case *ast.ValueSpec:
+					if decl.Tok != token.CONST {
+						continue
+					}
+					typeName, ok := spec.Type.(*ast.Ident)
+					if !ok || len(spec.Values) != len(spec.Names) {
+						continue
+					}
+					for i, value := range spec.Values {
+						if lit, ok := value.(*ast.BasicLit); ok {
+							pkg.consts[typeName.Name] = append(pkg.consts[typeName.Name], Constant{
+								Name:  spec.Names[i].Name,
+								Value: lit.Value,
+							})
+						}
+					}
+				}
+			}
+		}
+	}
 }
 
 // parseFile parses a single Go file and extracts services
-func (p *Parser) parseFile(file *ast.File) *Service {
+func (p *Parser) parseFile(pkg *goPackage, file *ast.File) *Service {
 	for _, decl := range file.Decls {
 		genDecl, ok := decl.(*ast.GenDecl)
 		if !ok || genDecl.Tok != token.TYPE {
@@ -86,11 +349,14 @@ func (p *Parser) parseFile(file *ast.File) *Service {
 			// Check if it's a service (has Service suffix or contains service methods)
 			if strings.HasSuffix(typeSpec.Name.Name, "Service") {
 				service := &Service{
-					Name: typeSpec.Name.Name,
+					Name:    typeSpec.Name.Name,
+					Desktop: hasDirective(desktopDirective, genDecl.Doc, typeSpec.Doc),
 				}
 
-				// Find methods for this type
-				service.Methods = p.findMethods(file, typeSpec.Name.Name)
+				// Find methods for this type, then the stream operations
+				// the HTTP server routes as its own
+				service.Methods = p.findMethods(pkg, file, typeSpec.Name.Name)
+				service.Methods = append(service.Methods, p.findMethods(pkg, file, streamsType(typeSpec.Name.Name))...)
 
 				return service
 			}
@@ -100,8 +366,34 @@ func (p *Parser) parseFile(file *ast.File) *Service {
 	return nil
 }
 
+// hasDirective reports whether one of the comment groups has directive on a
+// line of its own
+func hasDirective(directive string, groups ...*ast.CommentGroup) bool {
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+		for _, c := range group.List {
+			if strings.TrimSpace(c.Text) == directive {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// streamsType names the type holding a service's stream operations:
+// encoderStreams for EncoderService
+func streamsType(service string) string {
+	name := strings.TrimSuffix(service, "Service")
+	if name == "" {
+		return ""
+	}
+	return strings.ToLower(name[:1]) + name[1:] + "Streams"
+}
+
 // findMethods finds all methods for a given type
-func (p *Parser) findMethods(file *ast.File, typeName string) []ServiceMethod {
+func (p *Parser) findMethods(pkg *goPackage, file *ast.File, typeName string) []ServiceMethod {
 	var methods []ServiceMethod
 
 	for _, decl := range file.Decls {
@@ -110,8 +402,8 @@ func (p *Parser) findMethods(file *ast.File, typeName string) []ServiceMethod {
 			continue
 		}
 
-		// Skip lifecycle methods
-		if isLifecycleMethod(funcDecl.Name.Name) {
+		// Skip unexported and lifecycle methods
+		if !funcDecl.Name.IsExported() || isLifecycleMethod(funcDecl.Name.Name) {
 			continue
 		}
 
@@ -121,16 +413,22 @@ func (p *Parser) findMethods(file *ast.File, typeName string) []ServiceMethod {
 			if recvType == "*"+typeName || recvType == typeName {
 				method := ServiceMethod{
 					Name: funcDecl.Name.Name,
+					Doc:  summary(funcDecl.Name.Name, funcDecl.Doc.Text()),
 				}
 
-				// Parse parameters (skip receiver)
+				// Parse parameters (skip receiver). A context.Context is
+				// filled in by the server, not sent by clients.
 				if funcDecl.Type.Params != nil {
 					for _, param := range funcDecl.Type.Params.List {
 						paramType := p.getTypeString(param.Type)
+						if paramType == "context.Context" {
+							continue
+						}
 						for _, name := range param.Names {
 							method.Parameters = append(method.Parameters, Parameter{
-								Name: name.Name,
-								Type: paramType,
+								Name:     name.Name,
+								Type:     paramType,
+								Resolved: p.resolveType(pkg, file, param.Type),
 							})
 						}
 					}
@@ -141,7 +439,8 @@ func (p *Parser) findMethods(file *ast.File, typeName string) []ServiceMethod {
 					for _, result := range funcDecl.Type.Results.List {
 						resultType := p.getTypeString(result.Type)
 						method.Returns = append(method.Returns, Parameter{
-							Type: resultType,
+							Type:     resultType,
+							Resolved: p.resolveType(pkg, file, result.Type),
 						})
 					}
 				}
@@ -154,6 +453,46 @@ func (p *Parser) findMethods(file *ast.File, typeName string) []ServiceMethod {
 	return methods
 }
 
+// summary returns the first sentence of a method's doc comment, dropping
+// the method's name it starts with: "Decodes a JWT token" for "Decode
+// decodes a JWT token". Comments here often leave out the period, so a line
+// followed by one starting with a capitalized word ends the sentence too.
+func summary(name, doc string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(doc, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		if b.Len() > 0 {
+			if startsSentence(line) {
+				break
+			}
+			b.WriteByte(' ')
+		}
+		b.WriteString(line)
+	}
+
+	text := b.String()
+	if i := strings.Index(text, ". "); i >= 0 {
+		text = text[:i]
+	}
+	text = strings.TrimSuffix(text, ".")
+	if rest, ok := strings.CutPrefix(text, name+" "); ok && rest != "" {
+		r, size := utf8.DecodeRuneInString(rest)
+		text = string(unicode.ToUpper(r)) + rest[size:]
+	}
+	return text
+}
+
+// startsSentence reports whether line starts with a capitalized word, as
+// opposed to an acronym such as IV or JSON
+func startsSentence(line string) bool {
+	first, size := utf8.DecodeRuneInString(line)
+	second, _ := utf8.DecodeRuneInString(line[size:])
+	return unicode.IsUpper(first) && unicode.IsLower(second)
+}
+
 // isLifecycleMethod checks if method is a Wails lifecycle method
 func isLifecycleMethod(name string) bool {
 	return name == "ServiceStartup" || name == "ServiceShutdown"
@@ -178,3 +517,209 @@ func (p *Parser) getTypeString(expr ast.Expr) string {
 		return ""
 	}
 }
+
+// basicTypes are the predeclared types, which need no resolving
+var basicTypes = map[string]bool{
+	"bool": true, "string": true, "error": true, "any": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true, "uintptr": true,
+	"float32": true, "float64": true, "byte": true, "rune": true,
+}
+
+// resolveType resolves expr, written in file of pkg, following declared
+// types of the module into the types they are encoded as
+func (p *Parser) resolveType(pkg *goPackage, file *ast.File, expr ast.Expr) *GoType {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if t.Name == "any" {
+			return &GoType{Kind: KindBasic, Name: "interface{}"}
+		}
+		if basicTypes[t.Name] {
+			return &GoType{Kind: KindBasic, Name: t.Name}
+		}
+		return p.resolveNamed(pkg, t.Name)
+	case *ast.StarExpr:
+		return &GoType{Kind: KindPointer, Elem: p.resolveType(pkg, file, t.X)}
+	case *ast.ArrayType:
+		// []byte is encoded as a base64 string
+		if elt, ok := t.Elt.(*ast.Ident); ok && (elt.Name == "byte" || elt.Name == "uint8") {
+			return &GoType{Kind: KindBasic, Name: "[]byte"}
+		}
+		return &GoType{Kind: KindSlice, Elem: p.resolveType(pkg, file, t.Elt)}
+	case *ast.MapType:
+		return &GoType{Kind: KindMap, Elem: p.resolveType(pkg, file, t.Value)}
+	case *ast.StructType:
+		return &GoType{Kind: KindStruct, Fields: p.structFields(pkg, file, t)}
+	case *ast.SelectorExpr:
+		x, ok := t.X.(*ast.Ident)
+		if !ok {
+			break
+		}
+		importPath := p.importPath(pkg, file, x.Name)
+		if importPath == "" || !strings.HasPrefix(importPath, p.modulePath+"/") {
+			// Types from outside the module are known by name, if at all
+			return &GoType{Kind: KindBasic, Name: x.Name + "." + t.Sel.Name}
+		}
+		dep, err := p.loadPackage(importPath)
+		if err != nil {
+			break
+		}
+		return p.resolveNamed(dep, t.Sel.Name)
+	}
+	// Interfaces, generics, funcs and channels have no fixed JSON shape
+	return &GoType{Kind: KindBasic, Name: "interface{}"}
+}
+
+// importPath returns the import path file refers to as name
+func (p *Parser) importPath(pkg *goPackage, file *ast.File, name string) string {
+	imports := pkg.imports[file]
+	if importPath, ok := imports[name]; ok {
+		return importPath
+	}
+	// A package not named after its directory
+	for _, importPath := range imports {
+		if !strings.HasPrefix(importPath, p.modulePath+"/") {
+			continue
+		}
+		if dep, err := p.loadPackage(importPath); err == nil && dep.name == name {
+			return importPath
+		}
+	}
+	return ""
+}
+
+// resolveNamed resolves the type name declared in pkg, recording a TypeDecl
+// for it
+func (p *Parser) resolveNamed(pkg *goPackage, name string) *GoType {
+	spec, ok := pkg.specs[name]
+	if !ok || spec.TypeParams != nil {
+		return &GoType{Kind: KindBasic, Name: "interface{}"}
+	}
+	file := pkg.specIn[name]
+
+	// An alias is the type it stands for
+	if spec.Assign.IsValid() {
+		return p.resolveType(pkg, file, spec.Type)
+	}
+
+	ref := &GoType{Kind: KindNamed, Name: name, Module: pkg.module}
+	key := pkg.module + "." + name
+	if _, ok := p.types[key]; ok {
+		return ref
+	}
+
+	// Recorded before resolving so recursive types refer back to it
+	decl := &TypeDecl{Module: pkg.module, Name: name}
+	p.types[key] = decl
+
+	switch {
+	case pkg.methods[name+".MarshalJSON"]:
+		decl.Type = &GoType{Kind: KindBasic, Name: "interface{}"}
+	case pkg.methods[name+".MarshalText"]:
+		decl.Type = &GoType{Kind: KindBasic, Name: "string"}
+	default:
+		decl.Type = p.resolveType(pkg, file, spec.Type)
+		if decl.Type.Kind == KindBasic {
+			decl.Consts = pkg.consts[name]
+		}
+	}
+	return ref
+}
+
+// structFields lists the fields of st as encoding/json writes them
+func (p *Parser) structFields(pkg *goPackage, file *ast.File, st *ast.StructType) []Field {
+	var fields, promoted []Field
+	for _, f := range st.Fields.List {
+		var tag reflect.StructTag
+		if f.Tag != nil {
+			if unquoted, err := strconv.Unquote(f.Tag.Value); err == nil {
+				tag = reflect.StructTag(unquoted)
+			}
+		}
+		jsonName, opts, _ := strings.Cut(tag.Get("json"), ",")
+		if jsonName == "-" && opts == "" {
+			continue
+		}
+		optional := hasOption(opts, "omitempty") || hasOption(opts, "omitzero")
+
+		names := make([]string, 0, len(f.Names))
+		for _, name := range f.Names {
+			if name.IsExported() {
+				names = append(names, name.Name)
+			}
+		}
+		if len(f.Names) > 0 && len(names) == 0 {
+			continue
+		}
+
+		typ := p.resolveType(pkg, file, f.Type)
+
+		if len(f.Names) == 0 {
+			// Untagged embedded structs have their fields promoted
+			if embedded := p.structOf(typ); embedded != nil && jsonName == "" {
+				promoted = append(promoted, embedded...)
+				continue
+			}
+			names = append(names, strings.TrimPrefix(p.getTypeString(f.Type), "*"))
+			if i := strings.LastIndex(names[0], "."); i >= 0 {
+				names[0] = names[0][i+1:]
+			}
+		}
+
+		for _, name := range names {
+			if !ast.IsExported(name) {
+				continue
+			}
+			field := Field{Name: name, GoName: name, Type: typ, Options: opts, Optional: optional}
+			if jsonName != "" {
+				field.Name = jsonName
+			}
+			fields = append(fields, field)
+		}
+	}
+
+	// Fields declared directly win over promoted ones
+	for _, f := range promoted {
+		if !hasField(fields, f) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// structOf returns the fields of t when it is, or points to, a struct
+func (p *Parser) structOf(t *GoType) []Field {
+	if t.Kind == KindPointer {
+		t = t.Elem
+	}
+	switch t.Kind {
+	case KindStruct:
+		return t.Fields
+	case KindNamed:
+		if decl := p.types[t.Module+"."+t.Name]; decl != nil && decl.Type != nil && decl.Type.Kind == KindStruct {
+			return decl.Type.Fields
+		}
+	}
+	return nil
+}
+
+func hasOption(opts, option string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+// hasField reports whether fields has field's JSON or Go name
+func hasField(fields []Field, field Field) bool {
+	for _, f := range fields {
+		if f.Name == field.Name || f.GoName == field.GoName {
+			return true
+		}
+	}
+	return false
+}
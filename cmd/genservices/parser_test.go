@@ -0,0 +1,327 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeModule lays out files, keyed by slash-separated path, as a module
+func writeModule(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	files["go.mod"] = "module example.com/app\n\ngo 1.22\n"
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+func parseFixture(t *testing.T) (*Parser, []Service) {
+	dir := writeModule(t, map[string]string{
+		"service/clock.go": `package service
+
+import (
+	"context"
+
+	"example.com/app/internal/clock"
+	other "example.com/app/internal/other/clock"
+)
+
+type ClockService struct{}
+
+func (s *ClockService) Convert(ctx context.Context, req clock.Request) (clock.Response, error) {
+	return clock.Response{}, nil
+}
+
+func (s *ClockService) Zones() ([]*clock.Zone, error) { return nil, nil }
+
+func (s *ClockService) Other() other.Thing { return other.Thing{} }
+`,
+		"internal/clock/dto.go": `package clock
+
+import "time"
+
+type Unit string
+
+const (
+	Seconds Unit = "seconds"
+	Millis  Unit = "millis"
+)
+
+type Base struct {
+	ID      string ` + "`json:\"id\"`" + `
+	Comment string ` + "`json:\"comment\"`" + `
+}
+
+type Request struct {
+	Base
+	Input   string            ` + "`json:\"input\"`" + `
+	Unit    Unit              ` + "`json:\"unit,omitempty\"`" + `
+	Comment int               ` + "`json:\"comment\"`" + `
+	At      time.Time         ` + "`json:\"at\"`" + `
+	Count   int64             ` + "`json:\"count,string\"`" + `
+	Labels  map[string]string ` + "`json:\"labels\"`" + `
+	Secret  string            ` + "`json:\"-\"`" + `
+	hidden  string
+	Raw     []byte
+}
+
+type Response struct {
+	Zone   *Zone ` + "`json:\"zone\"`" + `
+	Next   *Zone ` + "`json:\"next,omitempty\"`" + `
+	Window struct {
+		From int ` + "`json:\"from\"`" + `
+		To   int ` + "`json:\"to\"`" + `
+	} ` + "`json:\"window\"`" + `
+	Span  Span    ` + "`json:\"span\"`" + `
+	Alias Aliased ` + "`json:\"alias\"`" + `
+}
+
+type Zone struct {
+	Name   string  ` + "`json:\"name\"`" + `
+	Parent *Zone   ` + "`json:\"parent,omitempty\"`" + `
+	Units  []*Unit ` + "`json:\"units\"`" + `
+}
+
+type Span struct{ d time.Duration }
+
+func (s Span) MarshalText() ([]byte, error) { return nil, nil }
+
+type Aliased = Zone
+`,
+		"internal/other/clock/thing.go": `package clock
+
+type Thing struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+`,
+	})
+
+	p := NewParser(filepath.Join(dir, "service"))
+	services, err := p.ParseServices()
+	require.NoError(t, err)
+	return p, services
+}
+
+func findType(t *testing.T, p *Parser, module, name string) *TypeDecl {
+	t.Helper()
+	for _, decl := range p.Types() {
+		if decl.Module == module && decl.Name == name {
+			return decl
+		}
+	}
+	t.Fatalf("type %s.%s not resolved", module, name)
+	return nil
+}
+
+func TestParser_ResolvesMethodTypes(t *testing.T) {
+	_, services := parseFixture(t)
+	require.Len(t, services, 1)
+
+	methods := services[0].Methods
+	require.Len(t, methods, 3)
+	assert.Equal(t, "clock.Request", toTSType(methods[0].Parameters[0].Resolved))
+	assert.Equal(t, "clock.Response", toTSType(methods[0].Returns[0].Resolved))
+	assert.Equal(t, "(clock.Zone | null)[]", toTSType(methods[1].Returns[0].Resolved))
+	assert.Equal(t, "internal_other_clock.Thing", toTSType(methods[2].Returns[0].Resolved))
+}
+
+func TestSummary(t *testing.T) {
+	tests := []struct {
+		name, doc, want string
+	}{
+		{"Decode", "Decode decodes a JWT token\n", "Decodes a JWT token"},
+		{"Hash", "Hash hashes input. config carries options\n", "Hashes input"},
+		{"ListMethods", "ListMethods describes the ciphers, with the key and\nIV each requires\n", "Describes the ciphers, with the key and IV each requires"},
+		{"Run", "Run runs the recipe\nErrors stop the run\n", "Runs the recipe"},
+		{"Run", "Run runs it\n\nMore detail.\n", "Runs it"},
+		{"Now", "Returns the current time.\n", "Returns the current time"},
+		{"Now", "", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, summary(tt.name, tt.doc), tt.doc)
+	}
+}
+
+func TestParser_StructFieldsFollowJSONTags(t *testing.T) {
+	p, _ := parseFixture(t)
+
+	var fields []string
+	for _, f := range findType(t, p, "clock", "Request").Type.Fields {
+		fields = append(fields, tsField(f, "clock"))
+	}
+	assert.Equal(t, []string{
+		"input: string",
+		"unit?: Unit",
+		"comment: number",
+		"at: string",
+		"count: string",
+		"labels: Record<string, string>",
+		"Raw: string",
+		"id: string",
+	}, fields)
+}
+
+func TestParser_NestedAndNamedTypes(t *testing.T) {
+	p, _ := parseFixture(t)
+
+	response := findType(t, p, "clock", "Response").Type
+	require.Len(t, response.Fields, 5)
+	assert.Equal(t, "zone: Zone | null", tsField(response.Fields[0], "clock"))
+	assert.Equal(t, "next?: Zone", tsField(response.Fields[1], "clock"))
+	assert.Equal(t, "window: { from: number; to: number; }", tsField(response.Fields[2], "clock"))
+	assert.Equal(t, "span: Span", tsField(response.Fields[3], "clock"))
+	assert.Equal(t, "alias: Zone", tsField(response.Fields[4], "clock"))
+
+	assert.Equal(t, []string{"'seconds'", "'millis'"}, tsValues(findType(t, p, "clock", "Unit")))
+	assert.Equal(t, "string", toTSType(findType(t, p, "clock", "Span").Type))
+
+	zone := findType(t, p, "clock", "Zone").Type
+	assert.Equal(t, "parent?: Zone", tsField(zone.Fields[1], "clock"))
+	assert.Equal(t, "units: (Unit | null)[]", tsField(zone.Fields[2], "clock"))
+}
+
+func TestGenerator_WritesTypeModules(t *testing.T) {
+	p, services := parseFixture(t)
+	out := t.TempDir()
+
+	g, err := NewGenerator(out)
+	require.NoError(t, err)
+	require.NoError(t, g.Generate(services, p.Types()))
+
+	types, err := os.ReadFile(filepath.Join(out, "http", "types", "clock.ts"))
+	require.NoError(t, err)
+	assert.Contains(t, string(types), "export type Unit = 'seconds' | 'millis';")
+	assert.Contains(t, string(types), "export interface Zone {\n  name: string;\n  parent?: Zone;\n  units: (Unit | null)[];\n}")
+
+	client, err := os.ReadFile(filepath.Join(out, "http", "clockService.ts"))
+	require.NoError(t, err)
+	assert.Contains(t, string(client), "import type * as clock from './types/clock';")
+	assert.Contains(t, string(client), "export async function Convert(req: clock.Request): Promise<clock.Response>")
+
+	index, err := os.ReadFile(filepath.Join(out, "http", "index.ts"))
+	require.NoError(t, err)
+	assert.Contains(t, string(index), "export type * as clock from './types/clock';")
+}
+
+func TestGenerator_SkipsTypesOfMethodsWithoutResult(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"service/bus.go": `package service
+
+import "example.com/app/internal/events"
+
+type BusService struct{}
+
+func (s *BusService) Attach(bus *events.Bus) {}
+
+func (s *BusService) Ready() bool { return true }
+`,
+		"internal/events/bus.go": `package events
+
+type Bus struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+`,
+	})
+	p := NewParser(filepath.Join(dir, "service"))
+	services, err := p.ParseServices()
+	require.NoError(t, err)
+
+	out := t.TempDir()
+	g, err := NewGenerator(out)
+	require.NoError(t, err)
+	require.NoError(t, g.Generate(services, p.Types()))
+
+	client, err := os.ReadFile(filepath.Join(out, "http", "busService.ts"))
+	require.NoError(t, err)
+	assert.Contains(t, string(client), "export async function Ready(): Promise<boolean>")
+	assert.NotContains(t, string(client), "Attach")
+	assert.NotContains(t, string(client), "import type * as events")
+
+	_, err = os.Stat(filepath.Join(out, "http", "types", "events.ts"))
+	assert.True(t, os.IsNotExist(err), "no module is written for types only skipped methods use")
+	index, err := os.ReadFile(filepath.Join(out, "http", "index.ts"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(index), "events")
+}
+
+func TestGenerator_WritesGoClient(t *testing.T) {
+	p, services := parseFixture(t)
+	out := t.TempDir()
+
+	g, err := NewGenerator(out)
+	require.NoError(t, err)
+	require.NoError(t, g.GenerateClient(out, "example.com/app/client", services, p.Types()))
+
+	svc, err := os.ReadFile(filepath.Join(out, "clock_service.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(svc), `"example.com/app/client/clock"`)
+	assert.Contains(t, string(svc), "func (svc *ClockService) Convert(ctx context.Context, req clock.Request) (clock.Response, error) {")
+	assert.Contains(t, string(svc), "body, err := jsonBody(req)")
+	assert.Contains(t, string(svc), "func (svc *ClockService) Zones(ctx context.Context) ([]*clock.Zone, error) {")
+	assert.Contains(t, string(svc), `svc.c.call(ctx, "/api/clock-service/zones", contentType, body, &out)`)
+
+	types, err := os.ReadFile(filepath.Join(out, "clock", "types.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(types), "\tSeconds Unit = \"seconds\"")
+	assert.Contains(t, string(types), "Count   int64             `json:\"count,string\"`")
+	assert.Contains(t, string(types), "ID      string            `json:\"id\"`")
+	assert.NotContains(t, string(types), "Secret")
+
+	_, err = os.Stat(filepath.Join(out, "client.go"))
+	assert.NoError(t, err)
+}
+
+func TestGenerator_GoClientSkipsUnroutedMethods(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"service/window.go": `package service
+
+import "github.com/wailsapp/wails/v3/pkg/application"
+
+// WindowService drives the app window
+//
+//genservices:desktop
+type WindowService struct{}
+
+func (s *WindowService) IsVisible() bool { return true }
+`,
+		"service/tool.go": `package service
+
+import "github.com/wailsapp/wails/v3/pkg/application"
+
+type ToolService struct{}
+
+func (s *ToolService) SetApp(app *application.App) {}
+
+func (s *ToolService) Run(input string) (string, error) { return input, nil }
+`,
+	})
+	p := NewParser(filepath.Join(dir, "service"))
+	services, err := p.ParseServices()
+	require.NoError(t, err)
+	require.Len(t, services, 2)
+	assert.Equal(t, "ToolService", services[0].Name)
+	assert.False(t, services[0].Desktop)
+	assert.True(t, services[1].Desktop)
+
+	out := t.TempDir()
+	g, err := NewGenerator(out)
+	require.NoError(t, err)
+	require.NoError(t, g.GenerateClient(out, "example.com/app/client", services, p.Types()))
+
+	_, err = os.Stat(filepath.Join(out, "window_service.go"))
+	assert.True(t, os.IsNotExist(err), "desktop services get no client")
+	tool, err := os.ReadFile(filepath.Join(out, "tool_service.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(tool), "func (svc *ToolService) Run(")
+	assert.NotContains(t, string(tool), "SetApp")
+	client, err := os.ReadFile(filepath.Join(out, "client.go"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(client), "WindowService")
+}
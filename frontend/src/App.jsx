@@ -21,18 +21,24 @@ function App() {
     try {
       unsubscribe = Events.On('navigate:to', (event) => {
         let path = '';
+        let input;
         const data = event;
         if (typeof data === 'string') {
           path = data;
         } else if (data && typeof data === 'object') {
-          if (data.data) {
+          if (data.data && typeof data.data === 'object' && !Array.isArray(data.data)) {
+            // Another launch handed over a tool and its input
+            ({ path, input } = data.data);
+          } else if (data.data) {
             path = typeof data.data === 'string' ? data.data : data.data[0];
           } else if (data.path) {
             path = data.path;
           }
         }
-        if (path) navigate(path);
+        if (path) navigate(path, input ? { state: { input } } : undefined);
       });
+      // Tell the backend the listener is ready for the tool this launch opens
+      Events.Emit('app:ready');
     } catch (err) {
       console.error('[App] Failed to register navigation listener:', err);
     }
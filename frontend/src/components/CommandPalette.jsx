@@ -1,4 +1,4 @@
-import React, { useState, useEffect, useRef, useCallback } from 'react';
+import React, { useState, useEffect, useRef, useCallback, useMemo } from 'react';
 import { useNavigate } from 'react-router-dom';
 import {
   Search,
@@ -23,9 +23,12 @@ import {
   Code2,
   Timer,
   FileCode,
+  Link,
 } from 'lucide-react';
 import { Events } from '@wailsio/runtime';
 import { useTheme } from '../context/ThemeContext';
+import { useTools } from '../hooks/useTools';
+import { matchesTool } from '../services/tools';
 import './CommandPalette.css';
 
 // Icon mapping matching the sidebar
@@ -42,11 +45,17 @@ const TOOL_ICONS = {
   'data-generator': LayoutGrid,
   'code-formatter': Code2,
   'color-converter': Palette,
+  'url-inspector': Link,
   cron: Timer,
   regexp: Regex,
   diff: FileDiff,
 };
 
+// The spotlight window of the desktop app hands selections to the main window;
+// in a browser it navigates itself
+const isDesktop =
+  typeof window !== 'undefined' && !!window.go?.devtoolbox?.service?.SpotlightService;
+
 // Helper to get icon for a command
 const getIconForCommand = (command) => {
   // System commands have explicit icons
@@ -62,8 +71,9 @@ const getIconForCommand = (command) => {
   return Wrench;
 };
 
-// Command definitions with tool presets matching the main app
-const COMMANDS = [
+// Tool presets matching the main app; the tools themselves come from the
+// backend registry
+const PRESET_COMMANDS = [
   // Code Formatter presets
   {
     id: 'formatter-json',
@@ -152,25 +162,6 @@ const COMMANDS = [
     path: '/tool/code-converter',
   },
 
-  // Direct navigation
-  { id: 'jwt', label: 'JWT Debugger', path: '/tool/jwt' },
-  { id: 'barcode', label: 'Barcode Generator', path: '/tool/barcode' },
-  { id: 'regexp', label: 'RegExp Tester', path: '/tool/regexp' },
-  { id: 'cron', label: 'Cron Job Parser', path: '/tool/cron' },
-  { id: 'diff', label: 'Text Diff Checker', path: '/tool/diff' },
-  { id: 'number', label: 'Number Converter', path: '/tool/number-converter' },
-  { id: 'color', label: 'Color Converter', path: '/tool/color-converter' },
-  {
-    id: 'datetime',
-    label: 'DateTime Converter',
-    path: '/tool/datetime-converter',
-  },
-  { id: 'encoder', label: 'Code Encoder', path: '/tool/code-encoder' },
-  { id: 'encrypter', label: 'Code Encrypter', path: '/tool/code-encrypter' },
-  { id: 'hash', label: 'Hash Generator', path: '/tool/hash-generator' },
-  { id: 'converter', label: 'Code Converter', path: '/tool/code-converter' },
-  { id: 'textutils', label: 'Text Utilities', path: '/tool/text-utilities' },
-
   // Data Generator
   {
     id: 'data-user',
@@ -182,8 +173,9 @@ const COMMANDS = [
     label: 'Data Generator > Address',
     path: '/tool/data-generator?preset=Address',
   },
+];
 
-  // System commands
+const SYSTEM_COMMANDS = [
   {
     id: 'theme-toggle',
     label: 'Toggle Dark Mode',
@@ -202,7 +194,21 @@ export function CommandPalette() {
   const { themeMode, setThemeMode } = useTheme();
   const [searchQuery, setSearchQuery] = useState('');
   const [selectedIndex, setSelectedIndex] = useState(0);
-  const [commands, setCommands] = useState(COMMANDS);
+  const tools = useTools();
+  const allCommands = useMemo(
+    () => [
+      ...tools.map((tool) => ({
+        id: tool.id,
+        label: tool.name,
+        path: tool.path,
+        tool,
+      })),
+      ...PRESET_COMMANDS,
+      ...SYSTEM_COMMANDS,
+    ],
+    [tools]
+  );
+  const [commands, setCommands] = useState(allCommands);
   const [recentCommands, setRecentCommands] = useState(() => {
     try {
       return JSON.parse(localStorage.getItem('commandPaletteRecent')) || [];
@@ -231,23 +237,27 @@ export function CommandPalette() {
     if (!searchQuery.trim()) {
       // Show recent commands first, then all commands
       const recentIds = new Set(recentCommands);
-      const recent = COMMANDS.filter((c) => recentIds.has(c.id)).sort(
+      const recent = allCommands.filter((c) => recentIds.has(c.id)).sort(
         (a, b) => recentCommands.indexOf(a.id) - recentCommands.indexOf(b.id)
       );
-      const others = COMMANDS.filter((c) => !recentIds.has(c.id));
+      const others = allCommands.filter((c) => !recentIds.has(c.id));
       setCommands([...recent.slice(0, 5), ...others]);
       setSelectedIndex(0);
       return;
     }
 
     const query = searchQuery.toLowerCase();
-    const filtered = COMMANDS.filter((cmd) => cmd.label.toLowerCase().includes(query)).sort(
-      (a, b) => fuzzyScore(a.label, query) - fuzzyScore(b.label, query)
-    );
+    // Tools also match on their keywords and categories
+    const filtered = allCommands
+      .filter(
+        (cmd) =>
+          cmd.label.toLowerCase().includes(query) || (cmd.tool && matchesTool(cmd.tool, query))
+      )
+      .sort((a, b) => fuzzyScore(a.label, query) - fuzzyScore(b.label, query));
 
     setCommands(filtered);
     setSelectedIndex(0);
-  }, [searchQuery, recentCommands]);
+  }, [searchQuery, recentCommands, allCommands]);
 
   // Listen for command palette opened event
   useEffect(() => {
@@ -294,6 +304,11 @@ export function CommandPalette() {
       } else if (command.path) {
         console.log('[CommandPalette] Selected path command:', command.id, command.path);
         const path = command.path;
+        // Served by the HTTP server in a browser tab: open the tool directly
+        if (!isDesktop) {
+          window.location.assign(path);
+          return;
+        }
         try {
           Events.Emit('spotlight:command-selected', path);
           console.log('[CommandPalette] Emitted spotlight:command-selected with path:', path);
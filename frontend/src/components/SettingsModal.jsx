@@ -5,6 +5,7 @@ import * as Checkbox from '@radix-ui/react-checkbox';
 import { Settings, Check } from 'lucide-react';
 import { GetCloseMinimizesToTray, SetCloseMinimizesToTray } from '../generated';
 import { useTheme } from '../context/ThemeContext';
+import { onServerEvent } from '../services/events';
 import { BUILT_IN_THEME_KEYS } from '../theme';
 import { Select, SelectTrigger, SelectValue, SelectContent, SelectItem } from './ui/select';
 
@@ -19,6 +20,16 @@ export function SettingsModal({ isOpen, onClose }) {
     }
   }, [isOpen]);
 
+  // Stay in sync with changes made from the tray, another window or the API
+  useEffect(() => {
+    if (!isOpen) return undefined;
+    return onServerEvent('settings:changed', ({ setting, value }) => {
+      if (setting === 'closeMinimizesToTray') {
+        setCloseMinimizesToTray(value);
+      }
+    });
+  }, [isOpen]);
+
   const loadSettings = async () => {
     try {
       const value = await GetCloseMinimizesToTray();
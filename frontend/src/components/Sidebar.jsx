@@ -29,6 +29,8 @@ import {
   Link,
 } from 'lucide-react';
 import { ScrollArea } from './ui/scroll-area';
+import { useTools } from '../hooks/useTools';
+import { matchesTool } from '../services/tools';
 
 const CATEGORY_ICONS = {
   Text: Type,
@@ -139,40 +141,20 @@ export function Sidebar({ isVisible, onOpenSettings }) {
     }
   });
 
-  const tools = [
-    { id: 'code-encoder', name: 'Code Encoder', category: 'Text' },
-    { id: 'code-encrypter', name: 'Code Encrypter', category: 'Security' },
-    { id: 'hash-generator', name: 'Hash Generator', category: 'Security' },
-    { id: 'code-converter', name: 'Code Converter', category: 'Developer' },
-    { id: 'text-utilities', name: 'Text Utilities', category: 'Text' },
-    { id: 'number-converter', name: 'Number Converter', category: 'Data' },
-    { id: 'datetime-converter', name: 'DateTime Converter', category: 'Data' },
-    { id: 'jwt', name: 'JWT Debugger', category: 'Security' },
-    { id: 'barcode', name: 'Barcode / QR Code', category: 'Generator' },
-    { id: 'data-generator', name: 'Data Generator', category: 'Generator' },
-    { id: 'code-formatter', name: 'Code Formatter', category: 'Developer' },
-    { id: 'color-converter', name: 'Color Converter', category: 'Developer' },
-    { id: 'url-inspector', name: 'URL Inspector', category: 'Developer' },
-    { id: 'cron', name: 'Cron Job Parser', category: 'Developer' },
-    { id: 'regexp', name: 'RegExp Tester', category: 'Developer' },
-    { id: 'diff', name: 'Text Diff', category: 'Text' },
-  ];
+  const tools = useTools();
 
   const filteredTools = useMemo(() => {
     if (!searchQuery) return tools;
-    return tools.filter(
-      (tool) =>
-        tool.name.toLowerCase().includes(searchQuery.toLowerCase()) ||
-        tool.category.toLowerCase().includes(searchQuery.toLowerCase())
-    );
+    return tools.filter((tool) => matchesTool(tool, searchQuery));
   }, [searchQuery, tools]);
 
   const favoriteTools = tools.filter((t) => favorites.includes(t.id));
 
   const toolsByCategory = useMemo(() => {
     return filteredTools.reduce((acc, tool) => {
-      if (!acc[tool.category]) acc[tool.category] = [];
-      acc[tool.category].push(tool);
+      const [category] = tool.categories;
+      if (!acc[category]) acc[category] = [];
+      acc[category].push(tool);
       return acc;
     }, {});
   }, [filteredTools]);
@@ -308,7 +290,7 @@ export function Sidebar({ isVisible, onOpenSettings }) {
                   {favoriteTools.map((tool) => (
                     <SidebarItem
                       key={tool.id}
-                      to={`/tool/${tool.id}`}
+                      to={tool.path}
                       label={tool.name}
                       icon={TOOL_ICONS[tool.id] || Box}
                       collapsed={isCollapsed}
@@ -351,29 +333,9 @@ export function Sidebar({ isVisible, onOpenSettings }) {
                 {toolsByCategory[category].map((tool) => (
                   <SidebarItem
                     key={tool.id}
-                    to={`/tool/${tool.id}`}
+                    to={tool.path}
                     label={tool.name}
                     icon={TOOL_ICONS[tool.id] || Box}
-                    disabled={
-                      ![
-                        'code-encoder',
-                        'code-encrypter',
-                        'hash-generator',
-                        'code-converter',
-                        'text-utilities',
-                        'diff',
-                        'jwt',
-                        'barcode',
-                        'data-generator',
-                        'regexp',
-                        'cron',
-                        'code-formatter',
-                        'color-converter',
-                        'url-inspector',
-                        'number-converter',
-                        'datetime-converter',
-                      ].includes(tool.id)
-                    }
                     collapsed={isCollapsed}
                   />
                 ))}
@@ -1,83 +1,120 @@
 // Auto-generated HTTP client for BarcodeService
 // This file is auto-generated. DO NOT EDIT.
 
-const API_BASE = import.meta.env.VITE_API_URL || 'http://localhost:8081';
+import { API_BASE, apiHeaders } from '../../services/apiConfig';
+import type * as barcode from './types/barcode';
 
 
 
-export async function GenerateBarcode(req: any): Promise<any> {
+export async function GenerateBarcode(req: barcode.GenerateBarcodeRequest): Promise<barcode.GenerateBarcodeResponse> {
   let body;
   
   body = JSON.stringify(req);
   
   const response = await fetch(`${API_BASE}/api/barcode-service/generate-barcode`, {
     method: 'POST',
-    headers: { 'Content-Type': 'application/json' },
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
     body
   });
   
-  if (!response.ok) {
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
     throw new Error(`HTTP error! status: ${response.status}`);
   }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
   
-  return await response.json();
+  return envelope.data;
 }
 
 
-export async function GetBarcodeStandards(): Promise<any[]> {
+export async function GetBarcodeStandards(): Promise<Record<string, string>[]> {
   let body;
   body = '{}';
   
   
   const response = await fetch(`${API_BASE}/api/barcode-service/get-barcode-standards`, {
     method: 'POST',
-    headers: { 'Content-Type': 'application/json' },
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
     body
   });
   
-  if (!response.ok) {
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
     throw new Error(`HTTP error! status: ${response.status}`);
   }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
   
-  return await response.json();
+  return envelope.data;
 }
 
 
-export async function GetQRErrorLevels(): Promise<any[]> {
+export async function GetQRErrorLevels(): Promise<Record<string, string>[]> {
   let body;
   body = '{}';
   
   
   const response = await fetch(`${API_BASE}/api/barcode-service/get-qr-error-levels`, {
     method: 'POST',
-    headers: { 'Content-Type': 'application/json' },
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
     body
   });
   
-  if (!response.ok) {
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
     throw new Error(`HTTP error! status: ${response.status}`);
   }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
   
-  return await response.json();
+  return envelope.data;
 }
 
 
-export async function GetBarcodeSizes(): Promise<any[]> {
+export async function GetBarcodeSizes(): Promise<Record<string, any>[]> {
   let body;
   body = '{}';
   
   
   const response = await fetch(`${API_BASE}/api/barcode-service/get-barcode-sizes`, {
     method: 'POST',
-    headers: { 'Content-Type': 'application/json' },
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
     body
   });
   
-  if (!response.ok) {
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
     throw new Error(`HTTP error! status: ${response.status}`);
   }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
   
-  return await response.json();
+  return envelope.data;
 }
 
 
@@ -85,17 +122,26 @@ export async function ValidateContent(content: string, standard: string): Promis
   let body;
   
   
-  body = JSON.stringify({ arg0: content, arg1: standard, });
+  body = JSON.stringify({ content: content, standard: standard, });
   const response = await fetch(`${API_BASE}/api/barcode-service/validate-content`, {
     method: 'POST',
-    headers: { 'Content-Type': 'application/json' },
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
     body
   });
   
-  if (!response.ok) {
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
     throw new Error(`HTTP error! status: ${response.status}`);
   }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
   
-  return await response.json();
+  return envelope.data;
 }
 
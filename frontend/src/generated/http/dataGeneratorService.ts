@@ -1,63 +1,91 @@
 // Auto-generated HTTP client for DataGeneratorService
 // This file is auto-generated. DO NOT EDIT.
 
-const API_BASE = import.meta.env.VITE_API_URL || 'http://localhost:8081';
+import { API_BASE, apiHeaders } from '../../services/apiConfig';
+import type * as datagenerator from './types/datagenerator';
 
 
 
-export async function Generate(req: any): Promise<any> {
+export async function Generate(req: datagenerator.GenerateRequest): Promise<datagenerator.GenerateResponse> {
   let body;
   
   body = JSON.stringify(req);
   
   const response = await fetch(`${API_BASE}/api/data-generator-service/generate`, {
     method: 'POST',
-    headers: { 'Content-Type': 'application/json' },
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
     body
   });
   
-  if (!response.ok) {
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
     throw new Error(`HTTP error! status: ${response.status}`);
   }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
   
-  return await response.json();
+  return envelope.data;
 }
 
 
-export async function GetPresets(): Promise<any> {
+export async function GetPresets(): Promise<datagenerator.PresetsResponse> {
   let body;
   body = '{}';
   
   
   const response = await fetch(`${API_BASE}/api/data-generator-service/get-presets`, {
     method: 'POST',
-    headers: { 'Content-Type': 'application/json' },
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
     body
   });
   
-  if (!response.ok) {
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
     throw new Error(`HTTP error! status: ${response.status}`);
   }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
   
-  return await response.json();
+  return envelope.data;
 }
 
 
-export async function ValidateTemplate(template: string): Promise<any> {
+export async function ValidateTemplate(template: string): Promise<datagenerator.ValidationResult> {
   let body;
   
   body = JSON.stringify({ value: template });
   
   const response = await fetch(`${API_BASE}/api/data-generator-service/validate-template`, {
     method: 'POST',
-    headers: { 'Content-Type': 'application/json' },
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
     body
   });
   
-  if (!response.ok) {
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
     throw new Error(`HTTP error! status: ${response.status}`);
   }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
   
-  return await response.json();
+  return envelope.data;
 }
 
@@ -1,82 +1,119 @@
 // Auto-generated HTTP client for DateTimeService
 // This file is auto-generated. DO NOT EDIT.
 
-const API_BASE = import.meta.env.VITE_API_URL || 'http://localhost:8081';
+import { API_BASE, apiHeaders } from '../../services/apiConfig';
+import type * as datetimeconverter from './types/datetimeconverter';
 
 
 
-export async function Convert(req: any): Promise<any> {
+export async function Convert(req: datetimeconverter.ConvertRequest): Promise<datetimeconverter.ConvertResponse> {
   let body;
   
   body = JSON.stringify(req);
   
   const response = await fetch(`${API_BASE}/api/date-time-service/convert`, {
     method: 'POST',
-    headers: { 'Content-Type': 'application/json' },
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
     body
   });
   
-  if (!response.ok) {
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
     throw new Error(`HTTP error! status: ${response.status}`);
   }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
   
-  return await response.json();
+  return envelope.data;
 }
 
 
-export async function GetPresets(): Promise<any> {
+export async function GetPresets(): Promise<datetimeconverter.PresetsResponse> {
   let body;
   body = '{}';
   
   
   const response = await fetch(`${API_BASE}/api/date-time-service/get-presets`, {
     method: 'POST',
-    headers: { 'Content-Type': 'application/json' },
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
     body
   });
   
-  if (!response.ok) {
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
     throw new Error(`HTTP error! status: ${response.status}`);
   }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
   
-  return await response.json();
+  return envelope.data;
 }
 
 
-export async function CalculateDelta(req: any): Promise<any> {
+export async function CalculateDelta(req: datetimeconverter.DeltaRequest): Promise<datetimeconverter.DeltaResponse> {
   let body;
   
   body = JSON.stringify(req);
   
   const response = await fetch(`${API_BASE}/api/date-time-service/calculate-delta`, {
     method: 'POST',
-    headers: { 'Content-Type': 'application/json' },
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
     body
   });
   
-  if (!response.ok) {
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
     throw new Error(`HTTP error! status: ${response.status}`);
   }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
   
-  return await response.json();
+  return envelope.data;
 }
 
 
-export async function GetAvailableTimezones(): Promise<any> {
+export async function GetAvailableTimezones(): Promise<datetimeconverter.AvailableTimezonesResponse> {
   let body;
   body = '{}';
   
   
   const response = await fetch(`${API_BASE}/api/date-time-service/get-available-timezones`, {
     method: 'POST',
-    headers: { 'Content-Type': 'application/json' },
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
     body
   });
   
-  if (!response.ok) {
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
     throw new Error(`HTTP error! status: ${response.status}`);
   }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
   
-  return await response.json();
+  return envelope.data;
 }
 
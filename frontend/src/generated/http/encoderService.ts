@@ -1,7 +1,8 @@
 // Auto-generated HTTP client for EncoderService
 // This file is auto-generated. DO NOT EDIT.
 
-const API_BASE = import.meta.env.VITE_API_URL || 'http://localhost:8081';
+import { API_BASE, apiHeaders } from '../../services/apiConfig';
+import type * as devtoolbox from './types/devtoolbox';
 
 
 
@@ -9,18 +10,27 @@ export async function Encode(input: string, method: string): Promise<string> {
   let body;
   
   
-  body = JSON.stringify({ arg0: input, arg1: method, });
+  body = JSON.stringify({ input: input, method: method, });
   const response = await fetch(`${API_BASE}/api/encoder-service/encode`, {
     method: 'POST',
-    headers: { 'Content-Type': 'application/json' },
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
     body
   });
   
-  if (!response.ok) {
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
     throw new Error(`HTTP error! status: ${response.status}`);
   }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
   
-  return await response.json();
+  return envelope.data;
 }
 
 
@@ -28,18 +38,27 @@ export async function Decode(input: string, method: string): Promise<string> {
   let body;
   
   
-  body = JSON.stringify({ arg0: input, arg1: method, });
+  body = JSON.stringify({ input: input, method: method, });
   const response = await fetch(`${API_BASE}/api/encoder-service/decode`, {
     method: 'POST',
-    headers: { 'Content-Type': 'application/json' },
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
     body
   });
   
-  if (!response.ok) {
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
     throw new Error(`HTTP error! status: ${response.status}`);
   }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
   
-  return await response.json();
+  return envelope.data;
 }
 
 
@@ -47,18 +66,27 @@ export async function Escape(input: string, method: string): Promise<string> {
   let body;
   
   
-  body = JSON.stringify({ arg0: input, arg1: method, });
+  body = JSON.stringify({ input: input, method: method, });
   const response = await fetch(`${API_BASE}/api/encoder-service/escape`, {
     method: 'POST',
-    headers: { 'Content-Type': 'application/json' },
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
     body
   });
   
-  if (!response.ok) {
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
     throw new Error(`HTTP error! status: ${response.status}`);
   }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
   
-  return await response.json();
+  return envelope.data;
 }
 
 
@@ -66,17 +94,120 @@ export async function Unescape(input: string, method: string): Promise<string> {
   let body;
   
   
-  body = JSON.stringify({ arg0: input, arg1: method, });
+  body = JSON.stringify({ input: input, method: method, });
   const response = await fetch(`${API_BASE}/api/encoder-service/unescape`, {
     method: 'POST',
-    headers: { 'Content-Type': 'application/json' },
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
+    body
+  });
+  
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
+    throw new Error(`HTTP error! status: ${response.status}`);
+  }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
+  
+  return envelope.data;
+}
+
+
+export async function ListMethods(): Promise<devtoolbox.Method[]> {
+  let body;
+  body = '{}';
+  
+  
+  const response = await fetch(`${API_BASE}/api/encoder-service/list-methods`, {
+    method: 'POST',
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
+    body
+  });
+  
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
+    throw new Error(`HTTP error! status: ${response.status}`);
+  }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
+  
+  return envelope.data;
+}
+
+
+export async function EncodeFile(input: Blob, method: string): Promise<Blob> {
+  let body;
+  // Fields must precede the file so the server can stream it
+  body = new FormData();
+  body.append('method', method);
+  body.append('input', input);
+  const response = await fetch(`${API_BASE}/api/encoder-service/encode-file`, {
+    method: 'POST',
+    headers: apiHeaders(),
     body
   });
   
-  if (!response.ok) {
+  if (response.ok) {
+    return response.blob();
+  }
+  
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
     throw new Error(`HTTP error! status: ${response.status}`);
   }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
+  
+  return envelope.data;
+}
+
+
+export async function DecodeFile(input: Blob, method: string): Promise<Blob> {
+  let body;
+  // Fields must precede the file so the server can stream it
+  body = new FormData();
+  body.append('method', method);
+  body.append('input', input);
+  const response = await fetch(`${API_BASE}/api/encoder-service/decode-file`, {
+    method: 'POST',
+    headers: apiHeaders(),
+    body
+  });
+  
+  if (response.ok) {
+    return response.blob();
+  }
+  
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
+    throw new Error(`HTTP error! status: ${response.status}`);
+  }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
   
-  return await response.json();
+  return envelope.data;
 }
 
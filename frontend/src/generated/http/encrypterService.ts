@@ -1,7 +1,8 @@
 // Auto-generated HTTP client for EncrypterService
 // This file is auto-generated. DO NOT EDIT.
 
-const API_BASE = import.meta.env.VITE_API_URL || 'http://localhost:8081';
+import { API_BASE, apiHeaders } from '../../services/apiConfig';
+import type * as devtoolbox from './types/devtoolbox';
 
 
 
@@ -9,18 +10,27 @@ export async function Encrypt(input: string, method: string, key: string, iv: st
   let body;
   
   
-  body = JSON.stringify({ arg0: input, arg1: method, arg2: key, arg3: iv, });
+  body = JSON.stringify({ input: input, method: method, key: key, iv: iv, });
   const response = await fetch(`${API_BASE}/api/encrypter-service/encrypt`, {
     method: 'POST',
-    headers: { 'Content-Type': 'application/json' },
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
     body
   });
   
-  if (!response.ok) {
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
     throw new Error(`HTTP error! status: ${response.status}`);
   }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
   
-  return await response.json();
+  return envelope.data;
 }
 
 
@@ -28,17 +38,54 @@ export async function Decrypt(input: string, method: string, key: string, iv: st
   let body;
   
   
-  body = JSON.stringify({ arg0: input, arg1: method, arg2: key, arg3: iv, });
+  body = JSON.stringify({ input: input, method: method, key: key, iv: iv, });
   const response = await fetch(`${API_BASE}/api/encrypter-service/decrypt`, {
     method: 'POST',
-    headers: { 'Content-Type': 'application/json' },
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
+    body
+  });
+  
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
+    throw new Error(`HTTP error! status: ${response.status}`);
+  }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
+  
+  return envelope.data;
+}
+
+
+export async function ListMethods(): Promise<devtoolbox.Method[]> {
+  let body;
+  body = '{}';
+  
+  
+  const response = await fetch(`${API_BASE}/api/encrypter-service/list-methods`, {
+    method: 'POST',
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
     body
   });
   
-  if (!response.ok) {
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
     throw new Error(`HTTP error! status: ${response.status}`);
   }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
   
-  return await response.json();
+  return envelope.data;
 }
 
@@ -1,7 +1,8 @@
 // Auto-generated HTTP client for HashGeneratorService
 // This file is auto-generated. DO NOT EDIT.
 
-const API_BASE = import.meta.env.VITE_API_URL || 'http://localhost:8081';
+import { API_BASE, apiHeaders } from '../../services/apiConfig';
+import type * as devtoolbox from './types/devtoolbox';
 
 
 
@@ -9,36 +10,112 @@ export async function Hash(input: string, method: string, config: Record<string,
   let body;
   
   
-  body = JSON.stringify({ arg0: input, arg1: method, arg2: config, });
+  body = JSON.stringify({ input: input, method: method, config: config, });
   const response = await fetch(`${API_BASE}/api/hash-generator-service/hash`, {
     method: 'POST',
-    headers: { 'Content-Type': 'application/json' },
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
     body
   });
   
-  if (!response.ok) {
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
     throw new Error(`HTTP error! status: ${response.status}`);
   }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
   
-  return await response.json();
+  return envelope.data;
 }
 
 
-export async function HashAll(input: string): Promise<Record<string, any>> {
+export async function HashAll(input: string): Promise<Record<string, string>> {
   let body;
   
   body = JSON.stringify({ value: input });
   
   const response = await fetch(`${API_BASE}/api/hash-generator-service/hash-all`, {
     method: 'POST',
-    headers: { 'Content-Type': 'application/json' },
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
+    body
+  });
+  
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
+    throw new Error(`HTTP error! status: ${response.status}`);
+  }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
+  
+  return envelope.data;
+}
+
+
+export async function ListMethods(): Promise<devtoolbox.Method[]> {
+  let body;
+  body = '{}';
+  
+  
+  const response = await fetch(`${API_BASE}/api/hash-generator-service/list-methods`, {
+    method: 'POST',
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
+    body
+  });
+  
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
+    throw new Error(`HTTP error! status: ${response.status}`);
+  }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
+  
+  return envelope.data;
+}
+
+
+export async function HashFile(input: Blob, method: string, config: Record<string, any>): Promise<string> {
+  let body;
+  // Fields must precede the file so the server can stream it
+  body = new FormData();
+  body.append('method', method);
+  body.append('config', JSON.stringify(config ?? null));
+  body.append('input', input);
+  const response = await fetch(`${API_BASE}/api/hash-generator-service/hash-file`, {
+    method: 'POST',
+    headers: apiHeaders(),
     body
   });
   
-  if (!response.ok) {
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
     throw new Error(`HTTP error! status: ${response.status}`);
   }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
   
-  return await response.json();
+  return envelope.data;
 }
 
@@ -1,13 +1,24 @@
+export * as barcodeService from './barcodeService';
 export * as codeConverterService from './codeConverterService';
-export * as textUtilitiesService from './textUtilitiesService';
+export * as codeFormatterService from './codeFormatterService';
+export * as dataGeneratorService from './dataGeneratorService';
+export * as dateTimeService from './dateTimeService';
 export * as encoderService from './encoderService';
+export * as encrypterService from './encrypterService';
 export * as hashGeneratorService from './hashGeneratorService';
 export * as jWTService from './jWTService';
 export * as numberConverterService from './numberConverterService';
-export * as barcodeService from './barcodeService';
-export * as codeFormatterService from './codeFormatterService';
-export * as dataGeneratorService from './dataGeneratorService';
-export * as dateTimeService from './dateTimeService';
+export * as recipeService from './recipeService';
+export * as settingsService from './settingsService';
 export * as spotlightService from './spotlightService';
-export * as encrypterService from './encrypterService';
-export * as settingsService from './settingsService';
\ No newline at end of file
+export * as textUtilitiesService from './textUtilitiesService';
+export * as themesService from './themesService';
+export type * as barcode from './types/barcode';
+export type * as codeformatter from './types/codeformatter';
+export type * as datagenerator from './types/datagenerator';
+export type * as datetimeconverter from './types/datetimeconverter';
+export type * as devtoolbox from './types/devtoolbox';
+export type * as jwt from './types/jwt';
+export type * as numberconverter from './types/numberconverter';
+export type * as recipe from './types/recipe';
+export type * as themes from './types/themes';
\ No newline at end of file
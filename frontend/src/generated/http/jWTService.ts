@@ -1,63 +1,91 @@
 // Auto-generated HTTP client for JWTService
 // This file is auto-generated. DO NOT EDIT.
 
-const API_BASE = import.meta.env.VITE_API_URL || 'http://localhost:8081';
+import { API_BASE, apiHeaders } from '../../services/apiConfig';
+import type * as jwt from './types/jwt';
 
 
 
-export async function Decode(token: string): Promise<any> {
+export async function Decode(token: string): Promise<jwt.DecodeResponse> {
   let body;
   
   body = JSON.stringify({ value: token });
   
   const response = await fetch(`${API_BASE}/api/jwt-service/decode`, {
     method: 'POST',
-    headers: { 'Content-Type': 'application/json' },
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
     body
   });
   
-  if (!response.ok) {
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
     throw new Error(`HTTP error! status: ${response.status}`);
   }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
   
-  return await response.json();
+  return envelope.data;
 }
 
 
-export async function Verify(token: string, secret: string, encoding: string): Promise<any> {
+export async function Verify(token: string, secret: string, encoding: string): Promise<jwt.VerifyResponse> {
   let body;
   
   
-  body = JSON.stringify({ arg0: token, arg1: secret, arg2: encoding, });
+  body = JSON.stringify({ token: token, secret: secret, encoding: encoding, });
   const response = await fetch(`${API_BASE}/api/jwt-service/verify`, {
     method: 'POST',
-    headers: { 'Content-Type': 'application/json' },
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
     body
   });
   
-  if (!response.ok) {
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
     throw new Error(`HTTP error! status: ${response.status}`);
   }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
   
-  return await response.json();
+  return envelope.data;
 }
 
 
-export async function Encode(headerJSON: string, payloadJSON: string, algorithm: string, secret: string): Promise<any> {
+export async function Encode(headerJSON: string, payloadJSON: string, algorithm: string, secret: string): Promise<jwt.EncodeResponse> {
   let body;
   
   
-  body = JSON.stringify({ arg0: headerJSON, arg1: payloadJSON, arg2: algorithm, arg3: secret, });
+  body = JSON.stringify({ headerJSON: headerJSON, payloadJSON: payloadJSON, algorithm: algorithm, secret: secret, });
   const response = await fetch(`${API_BASE}/api/jwt-service/encode`, {
     method: 'POST',
-    headers: { 'Content-Type': 'application/json' },
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
     body
   });
   
-  if (!response.ok) {
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
     throw new Error(`HTTP error! status: ${response.status}`);
   }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
   
-  return await response.json();
+  return envelope.data;
 }
 
@@ -0,0 +1,264 @@
+// Auto-generated HTTP client for RecipeService
+// This file is auto-generated. DO NOT EDIT.
+
+import { API_BASE, apiHeaders } from '../../services/apiConfig';
+import type * as devtoolbox from './types/devtoolbox';
+import type * as recipe from './types/recipe';
+
+
+
+export async function ListSteps(): Promise<devtoolbox.Method[]> {
+  let body;
+  body = '{}';
+  
+  
+  const response = await fetch(`${API_BASE}/api/recipe-service/list-steps`, {
+    method: 'POST',
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
+    body
+  });
+  
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
+    throw new Error(`HTTP error! status: ${response.status}`);
+  }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
+  
+  return envelope.data;
+}
+
+
+export async function Run(req: recipe.RunRequest): Promise<recipe.RunResponse> {
+  let body;
+  
+  body = JSON.stringify(req);
+  
+  const response = await fetch(`${API_BASE}/api/recipe-service/run`, {
+    method: 'POST',
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
+    body
+  });
+  
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
+    throw new Error(`HTTP error! status: ${response.status}`);
+  }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
+  
+  return envelope.data;
+}
+
+
+export async function RunSaved(name: string, input: string): Promise<recipe.RunResponse> {
+  let body;
+  
+  
+  body = JSON.stringify({ name: name, input: input, });
+  const response = await fetch(`${API_BASE}/api/recipe-service/run-saved`, {
+    method: 'POST',
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
+    body
+  });
+  
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
+    throw new Error(`HTTP error! status: ${response.status}`);
+  }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
+  
+  return envelope.data;
+}
+
+
+export async function List(): Promise<devtoolbox.Recipe[]> {
+  let body;
+  body = '{}';
+  
+  
+  const response = await fetch(`${API_BASE}/api/recipe-service/list`, {
+    method: 'POST',
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
+    body
+  });
+  
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
+    throw new Error(`HTTP error! status: ${response.status}`);
+  }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
+  
+  return envelope.data;
+}
+
+
+export async function Get(name: string): Promise<devtoolbox.Recipe> {
+  let body;
+  
+  body = JSON.stringify({ value: name });
+  
+  const response = await fetch(`${API_BASE}/api/recipe-service/get`, {
+    method: 'POST',
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
+    body
+  });
+  
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
+    throw new Error(`HTTP error! status: ${response.status}`);
+  }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
+  
+  return envelope.data;
+}
+
+
+export async function Save(r: devtoolbox.Recipe): Promise<devtoolbox.Recipe> {
+  let body;
+  
+  body = JSON.stringify(r);
+  
+  const response = await fetch(`${API_BASE}/api/recipe-service/save`, {
+    method: 'POST',
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
+    body
+  });
+  
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
+    throw new Error(`HTTP error! status: ${response.status}`);
+  }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
+  
+  return envelope.data;
+}
+
+
+export async function Delete(name: string): Promise<string> {
+  let body;
+  
+  body = JSON.stringify({ value: name });
+  
+  const response = await fetch(`${API_BASE}/api/recipe-service/delete`, {
+    method: 'POST',
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
+    body
+  });
+  
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
+    throw new Error(`HTTP error! status: ${response.status}`);
+  }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
+  
+  return envelope.data;
+}
+
+
+export async function Import(file: Blob): Promise<devtoolbox.Recipe> {
+  let body;
+  // Fields must precede the file so the server can stream it
+  body = new FormData();
+  body.append('file', file);
+  const response = await fetch(`${API_BASE}/api/recipe-service/import`, {
+    method: 'POST',
+    headers: apiHeaders(),
+    body
+  });
+  
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
+    throw new Error(`HTTP error! status: ${response.status}`);
+  }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
+  
+  return envelope.data;
+}
+
+
+export async function Export(name: string): Promise<Blob> {
+  let body;
+  
+  body = JSON.stringify({ value: name });
+  
+  const response = await fetch(`${API_BASE}/api/recipe-service/export`, {
+    method: 'POST',
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
+    body
+  });
+  
+  if (response.ok) {
+    return response.blob();
+  }
+  
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
+    throw new Error(`HTTP error! status: ${response.status}`);
+  }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
+  
+  return envelope.data;
+}
+
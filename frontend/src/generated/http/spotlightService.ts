@@ -1,11 +1,7 @@
 // Auto-generated HTTP client for SpotlightService
 // This file is auto-generated. DO NOT EDIT.
 
-const API_BASE = import.meta.env.VITE_API_URL || 'http://localhost:8081';
-
-
-
-
+import { API_BASE, apiHeaders } from '../../services/apiConfig';
 
 
 
@@ -16,15 +12,23 @@ export async function IsVisible(): Promise<boolean> {
   
   const response = await fetch(`${API_BASE}/api/spotlight-service/is-visible`, {
     method: 'POST',
-    headers: { 'Content-Type': 'application/json' },
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
     body
   });
   
-  if (!response.ok) {
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
     throw new Error(`HTTP error! status: ${response.status}`);
   }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
   
-  return await response.json();
+  return envelope.data;
 }
 
-
@@ -0,0 +1,35 @@
+// Auto-generated HTTP client for ThemesService
+// This file is auto-generated. DO NOT EDIT.
+
+import { API_BASE, apiHeaders } from '../../services/apiConfig';
+import type * as themes from './types/themes';
+
+
+
+export async function List(): Promise<themes.Theme[]> {
+  let body;
+  body = '{}';
+  
+  
+  const response = await fetch(`${API_BASE}/api/themes-service/list`, {
+    method: 'POST',
+    headers: apiHeaders({ 'Content-Type': 'application/json' }),
+    body
+  });
+  
+  const envelope = await response.json().catch(() => null);
+  if (!envelope) {
+    throw new Error(`HTTP error! status: ${response.status}`);
+  }
+  // In-band failures keep the service's response, matching the Wails bindings
+  if (envelope.error && envelope.data == null) {
+    throw Object.assign(new Error(envelope.error.message), {
+      code: envelope.error.code,
+      details: envelope.error.details,
+      status: response.status,
+    });
+  }
+  
+  return envelope.data;
+}
+
@@ -0,0 +1,15 @@
+// Auto-generated types for the Go package barcode
+// This file is auto-generated. DO NOT EDIT.
+
+export interface GenerateBarcodeRequest {
+  content: string;
+  standard: string;
+  size: number;
+  level: string;
+  format: string;
+}
+
+export interface GenerateBarcodeResponse {
+  dataUrl: string;
+  error: string;
+}
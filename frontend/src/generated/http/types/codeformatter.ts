@@ -0,0 +1,14 @@
+// Auto-generated types for the Go package codeformatter
+// This file is auto-generated. DO NOT EDIT.
+
+export interface FormatRequest {
+  input: string;
+  formatType: string;
+  filter?: string;
+  minify: boolean;
+}
+
+export interface FormatResponse {
+  output: string;
+  error?: string;
+}
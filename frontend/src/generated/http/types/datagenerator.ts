@@ -0,0 +1,46 @@
+// Auto-generated types for the Go package datagenerator
+// This file is auto-generated. DO NOT EDIT.
+
+export interface GenerateRequest {
+  template: string;
+  variables: Record<string, any>;
+  batchCount: number;
+  outputFormat: string;
+  separator: string;
+}
+
+export interface GenerateResponse {
+  output: string;
+  count: number;
+  error?: string;
+  durationMs: number;
+}
+
+export interface PresetsResponse {
+  presets: TemplatePreset[];
+  error?: string;
+}
+
+export interface TemplatePreset {
+  id: string;
+  name: string;
+  description: string;
+  template: string;
+  variables: Variable[];
+}
+
+export interface ValidationResult {
+  valid: boolean;
+  error?: string;
+  message?: string;
+}
+
+export interface Variable {
+  name: string;
+  type: string;
+  default: any;
+  options?: string[];
+  min?: number;
+  max?: number;
+  description?: string;
+}
@@ -0,0 +1,81 @@
+// Auto-generated types for the Go package datetimeconverter
+// This file is auto-generated. DO NOT EDIT.
+
+export interface AvailableTimezonesResponse {
+  timezones: TimezoneInfo[];
+}
+
+export interface ConvertRequest {
+  input: string;
+  precision: string;
+  timezone: string;
+  outputFormat: string;
+  customFormat: string;
+}
+
+export interface ConvertResponse {
+  result?: TimeResult;
+  detectedType: string;
+  detectedPrec: string;
+  error?: string;
+}
+
+export interface DeltaRequest {
+  dateA: string;
+  dateB: string;
+}
+
+export interface DeltaResponse {
+  delta?: TimeDelta;
+  error?: string;
+}
+
+export interface Preset {
+  id: string;
+  label: string;
+  description: string;
+  timestamp: number;
+}
+
+export interface PresetsResponse {
+  presets: Preset[];
+}
+
+export interface RelativeBreakdown {
+  days: number;
+  hours: number;
+  minutes: number;
+  seconds: number;
+  totalHours: number;
+  totalMinutes: number;
+  totalSeconds: number;
+  daysSinceEpoch: number;
+}
+
+export interface TimeDelta {
+  days: number;
+  hours: number;
+  minutes: number;
+  seconds: number;
+  totalHours: number;
+  totalMinutes: number;
+  totalSeconds: number;
+  businessDays: number;
+  isFuture: boolean;
+}
+
+export interface TimeResult {
+  unixSeconds: number;
+  unixMillis: number;
+  unixMicros: number;
+  unixNanos: number;
+  utc: string;
+  local: string;
+  relative: string;
+  relativeDetails: RelativeBreakdown;
+}
+
+export interface TimezoneInfo {
+  label: string;
+  timezone: string;
+}
@@ -0,0 +1,44 @@
+// Auto-generated types for the Go package devtoolbox
+// This file is auto-generated. DO NOT EDIT.
+
+export interface ConfigField {
+  key: string;
+  type: FieldType;
+  description?: string;
+  required?: boolean;
+  direction?: Direction;
+}
+
+export type Direction = 'Encode' | 'Decode' | 'Encrypt' | 'Decrypt' | 'Escape' | 'Unescape';
+
+export type FieldType = 'string' | 'number' | 'boolean';
+
+export interface Method {
+  category: string;
+  name: string;
+  aliases?: string[];
+  directions?: Direction[];
+  streams?: boolean;
+  config?: ConfigField[];
+}
+
+export interface Recipe {
+  name: string;
+  description?: string;
+  steps: RecipeStep[];
+}
+
+export interface RecipeStep {
+  category: string;
+  method: string;
+  config?: Record<string, any>;
+}
+
+export interface RecipeStepResult {
+  category: string;
+  method: string;
+  output: string;
+  base64?: boolean;
+  error?: string;
+  durationMs: number;
+}
@@ -0,0 +1,21 @@
+// Auto-generated types for the Go package jwt
+// This file is auto-generated. DO NOT EDIT.
+
+export interface DecodeResponse {
+  header: Record<string, any>;
+  payload: Record<string, any>;
+  signature: string;
+  isValid: boolean;
+  error: string;
+}
+
+export interface EncodeResponse {
+  token: string;
+  error: string;
+}
+
+export interface VerifyResponse {
+  isValid: boolean;
+  validationMessage: string;
+  error: string;
+}
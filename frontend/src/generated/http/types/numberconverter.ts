@@ -0,0 +1,57 @@
+// Auto-generated types for the Go package numberconverter
+// This file is auto-generated. DO NOT EDIT.
+
+export interface ASCIIView {
+  char: string;
+  code: number;
+  printable: boolean;
+}
+
+export interface ByteView {
+  bigEndian: string[];
+  highlighted: number;
+}
+
+export interface ColorView {
+  hex: string;
+  valid: boolean;
+}
+
+export interface ConvertRequest {
+  value: string;
+  base: string;
+}
+
+export interface ConvertResponse {
+  binary: string;
+  decimal: string;
+  hex: string;
+  octal: string;
+  bits: number[];
+  bitValues: number[];
+  bytes: ByteView;
+  ascii: ASCIIView;
+  color: ColorView;
+  ipv4: IPv4View;
+  fileSize: FileSizeView;
+  timestamp: TimestampView;
+  percentage: number;
+  error?: string;
+}
+
+export interface FileSizeView {
+  bytes: number;
+  kb: number;
+  mb: number;
+  human: string;
+}
+
+export interface IPv4View {
+  address: string;
+  type: string;
+}
+
+export interface TimestampView {
+  datetime: string;
+  duration: string;
+}
@@ -0,0 +1,14 @@
+// Auto-generated types for the Go package recipe
+// This file is auto-generated. DO NOT EDIT.
+import type * as devtoolbox from './devtoolbox';
+
+export interface RunRequest {
+  recipe: devtoolbox.Recipe;
+  input: string;
+}
+
+export interface RunResponse {
+  steps: devtoolbox.RecipeStepResult[];
+  output: string;
+  error?: string;
+}
@@ -0,0 +1,7 @@
+// Auto-generated types for the Go package themes
+// This file is auto-generated. DO NOT EDIT.
+
+export interface Theme {
+  name: string;
+  data: any;
+}
@@ -0,0 +1,18 @@
+import { useEffect } from 'react';
+import { useLocation } from 'react-router-dom';
+
+/**
+ * Custom hook filling in a tool's input when it is opened with one, as by
+ * `devtoolbox open <tool> --input <text>`.
+ *
+ * @param {Function} setInput - Setter of the tool's main input
+ */
+export function useHandoffInput(setInput) {
+  const location = useLocation();
+  const input = location.state?.input;
+
+  useEffect(() => {
+    if (typeof input === 'string') setInput(input);
+    // Each navigation has its own key, so the same input may be handed over twice
+  }, [location.key]);
+}
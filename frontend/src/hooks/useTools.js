@@ -0,0 +1,29 @@
+import { useEffect, useState } from 'react';
+import { fetchTools } from '../services/tools';
+
+/**
+ * Custom hook returning the tool catalog from the backend registry.
+ *
+ * @returns {Array} - Tools in display order; empty until the catalog loads
+ */
+export function useTools() {
+  const [tools, setTools] = useState([]);
+
+  useEffect(() => {
+    let cancelled = false;
+
+    fetchTools()
+      .then((loaded) => {
+        if (!cancelled) setTools(loaded);
+      })
+      .catch((err) => {
+        console.error('Failed to load tools:', err);
+      });
+
+    return () => {
+      cancelled = true;
+    };
+  }, []);
+
+  return tools;
+}
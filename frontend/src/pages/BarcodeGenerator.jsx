@@ -4,6 +4,7 @@ import { ScanBarcode, Download, QrCode, Hash, Trash2 } from 'lucide-react';
 import { GenerateBarcode } from '../services/barcodeService';
 import { ToolHeader, ToolPane } from '../components/ToolUI';
 import { ToolLayout } from '../components/layout';
+import { useHandoffInput } from '../hooks/useHandoffInput';
 
 const types = [
   {
@@ -104,6 +105,7 @@ function TypeToggle({ types, value, onChange }) {
 
 export default function BarcodeGenerator() {
   const [input, setInput] = useState('https://github.com/wailsapp/wails');
+  useHandoffInput(setInput);
   const [type, setType] = useState('QR');
   const [output, setOutput] = useState('');
   const [error, setError] = useState('');
@@ -3,6 +3,7 @@ import { codeConverterAPI } from './api/codeConverterAPI';
 import { ToolHeader } from '../../components/ToolUI';
 import { ToolEditorPane, EditorToggle } from '../../components/inputs';
 import { ToolLayout } from '../../components/layout';
+import { useHandoffInput } from '../../hooks/useHandoffInput';
 
 const METHODS = [
   'JSON ↔ YAML',
@@ -29,6 +30,7 @@ export default function CodeConverter() {
   );
   const [method, setMethod] = useState(METHODS[0]);
   const [input, setInput] = useState('');
+  useHandoffInput(setInput);
   const [output, setOutput] = useState('');
   const [error, setError] = useState('');
 
@@ -3,6 +3,7 @@ import { encoderAPI } from './api/encoderAPI';
 import { ToolHeader } from '../../components/ToolUI';
 import { ToolEditorPane, EditorToggle } from '../../components/inputs';
 import { ToolLayout } from '../../components/layout';
+import { useHandoffInput } from '../../hooks/useHandoffInput';
 
 const ENCODE_METHODS = [
   'Base16 (Hex)',
@@ -15,7 +16,7 @@ const ENCODE_METHODS = [
   'HTML Entities',
   'Binary',
   'Morse Code',
-  'Punnycode',
+  'Punycode',
   'Bencoded',
   'Protobuf',
   'ROT13',
@@ -91,6 +92,7 @@ export default function CodeEncoder() {
   const [method, setMethod] = useState('Base64');
   const [mode, setMode] = useState('Encode');
   const [input, setInput] = useState('');
+  useHandoffInput(setInput);
   const [output, setOutput] = useState('');
   const [error, setError] = useState('');
 
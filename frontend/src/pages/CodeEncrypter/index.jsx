@@ -4,6 +4,7 @@ import { encrypterAPI } from './api/encrypterAPI';
 import { ToolHeader } from '../../components/ToolUI';
 import { ToolEditorPane, EditorToggle } from '../../components/inputs';
 import { ToolLayout } from '../../components/layout';
+import { useHandoffInput } from '../../hooks/useHandoffInput';
 
 const METHODS = [
   'AES',
@@ -66,6 +67,7 @@ export default function CodeEncrypter() {
     () => localStorage.getItem(`${STORAGE_KEY}-privateKey`) || ''
   );
   const [input, setInput] = useState('');
+  useHandoffInput(setInput);
   const [output, setOutput] = useState('');
   const [error, setError] = useState('');
   const [loading, setLoading] = useState(false);
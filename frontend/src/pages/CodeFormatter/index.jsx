@@ -5,6 +5,7 @@ import { ToolHeader } from '../../components/ToolUI';
 import { ToolEditorPane, EditorToggle } from '../../components/inputs';
 import { ToolLayout } from '../../components/layout';
 import { Format } from '../../generated';
+import { useHandoffInput } from '../../hooks/useHandoffInput';
 
 const TOOL_KEY = 'code-formatter';
 
@@ -190,6 +191,7 @@ export default function CodeFormatter() {
     () => localStorage.getItem(`${TOOL_KEY}-editor-highlight`) !== 'false'
   );
   const [input, setInput] = useState('');
+  useHandoffInput(setInput);
   const [output, setOutput] = useState('');
   const [language, setLanguage] = useState(
     () => localStorage.getItem('code-formatter-lang') || 'json'
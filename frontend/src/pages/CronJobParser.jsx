@@ -4,6 +4,7 @@ import { Button } from '../components/ui/Button';
 import { ToolHeader } from '../components/ToolUI';
 import { ToolCopyButton } from '../components/inputs';
 import cronstrue from 'cronstrue';
+import { useHandoffInput } from '../hooks/useHandoffInput';
 
 const CRON_FIELDS = {
   minute: {
@@ -199,6 +200,7 @@ function getFieldDescription(value, label) {
 
 export default function CronJobParser() {
   const [expression, setExpression] = useState('*/15 * * * *');
+  useHandoffInput(setExpression);
   const [fields, setFields] = useState(() => parseCronExpression('*/15 * * * *'));
   const [description, setDescription] = useState('');
   const [error, setError] = useState('');
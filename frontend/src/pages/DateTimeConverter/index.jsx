@@ -19,6 +19,7 @@ import {
   verticalListSortingStrategy,
 } from '@dnd-kit/sortable';
 import { CSS } from '@dnd-kit/utilities';
+import { useHandoffInput } from '../../hooks/useHandoffInput';
 
 // Backend service functions (Wails bindings)
 // These will be injected at runtime by Wails
@@ -94,6 +95,7 @@ const TOOL_DESCRIPTION = 'Convert between Unix timestamps, ISO dates, and timezo
 
 export default function DateTimeConverter() {
   const [input, setInput] = useState('1710508200');
+  useHandoffInput(setInput);
   const [detectedFormat, setDetectedFormat] = useState('Unix Timestamp (seconds)');
   const [results, setResults] = useState(null);
   const [favoriteTimezones, setFavoriteTimezones] = useLocalStorage('datetime-favorite-timezones', [
@@ -4,6 +4,7 @@ import MultiHashOutput from './components/MultiHashOutput';
 import { ToolHeader } from '../../components/ToolUI';
 import { ToolEditorPane, EditorToggle } from '../../components/inputs';
 import { ToolLayout } from '../../components/layout';
+import { useHandoffInput } from '../../hooks/useHandoffInput';
 
 const METHODS = [
   'All',
@@ -38,6 +39,7 @@ export default function HashGenerator() {
   );
   const [method, setMethod] = useState('MD5');
   const [input, setInput] = useState('');
+  useHandoffInput(setInput);
   const [output, setOutput] = useState('');
   const [error, setError] = useState('');
   const [hmacKey, setHmacKey] = useState('');
@@ -3,6 +3,7 @@ import { Copy, Check, Dice5 } from 'lucide-react';
 import { Button } from '../../components/ui/Button';
 import { ToolHeader } from '../../components/ToolUI';
 import { numberConverterAPI } from './api/numberConverterAPI';
+import { useHandoffInput } from '../../hooks/useHandoffInput';
 
 function debounce(func, wait) {
   let timeout;
@@ -514,6 +515,7 @@ const TOOL_DESCRIPTION = 'Convert and interpret numbers in different contexts';
 
 export default function NumberConverter() {
   const [input, setInput] = useState('255');
+  useHandoffInput(setInput);
   const [base] = useState('decimal');
   const [result, setResult] = useState(null);
   const [error, setError] = useState('');
@@ -3,6 +3,7 @@ import { Regex, Copy, Check, ChevronDown, ChevronRight } from 'lucide-react';
 import { Button } from '../components/ui/Button';
 import { ToolHeader } from '../components/ToolUI';
 import { ToolLayout } from '../components/layout';
+import { useHandoffInput } from '../hooks/useHandoffInput';
 
 // Escape HTML special characters
 const escapeHtml = (text) => {
@@ -1121,6 +1122,7 @@ export default function RegExpTester() {
   const [regex, setRegex] = useState('(\\w+)\\s(\\w+)');
   const [flags, setFlags] = useState('g');
   const [text, setText] = useState('John Doe, Jane Smith, Alan Turing');
+  useHandoffInput(setText);
   const [matches, setMatches] = useState([]);
   const [error, setError] = useState('');
 
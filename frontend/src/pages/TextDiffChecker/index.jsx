@@ -5,6 +5,7 @@ import { computeDiffResult } from './diffUtils';
 import { ToolHeader } from '../../components/ToolUI';
 import { ToolEditorPane, ToolCopyButton } from '../../components/inputs';
 import { ToolLayout } from '../../components/layout';
+import { useHandoffInput } from '../../hooks/useHandoffInput';
 
 function ToggleGroup({ options, value, onChange, size = 'default' }) {
   return (
@@ -345,6 +346,7 @@ function DiffUnifiedView({ lines }) {
 
 export default function TextDiffChecker() {
   const [original, setOriginal] = useState('');
+  useHandoffInput(setOriginal);
   const [modified, setModified] = useState('');
   const [mode, setMode] = useState('edit'); // 'edit' | 'diff'
   const [viewMode, setViewMode] = useState('split'); // 'split' | 'unified'
@@ -5,6 +5,7 @@ import { textUtilitiesAPI } from './api/textUtilitiesAPI';
 import { ToolHeader } from '../../components/ToolUI';
 import { ToolEditorPane, EditorToggle } from '../../components/inputs';
 import { ToolLayout } from '../../components/layout';
+import { useHandoffInput } from '../../hooks/useHandoffInput';
 
 const ESCAPE_METHODS = ['String Literal', 'Unicode/Hex'];
 
@@ -57,6 +58,7 @@ export default function TextUtilities() {
     () => localStorage.getItem(`${TOOL_KEY}-editor-highlight`) !== 'false'
   );
   const [input, setInput] = useState('');
+  useHandoffInput(setInput);
   const [output, setOutput] = useState('');
   const [stats, setStats] = useState({ chars: 0, words: 0, lines: 0, bytes: 0, sentences: 0 });
   const [escapeMethod, setEscapeMethod] = useState(ESCAPE_METHODS[0]);
@@ -4,6 +4,7 @@ import { Button } from '../../components/ui/Button';
 import { ToolHeader } from '../../components/ToolUI';
 import { ToolInput, ToolCopyButton } from '../../components/inputs';
 import { buildUrl, parseUrlInput, sortQueryRows } from './urlUtils';
+import { useHandoffInput } from '../../hooks/useHandoffInput';
 
 const SAMPLE_URL = 'https://example.com:8443/api/search?q=hello%20world&debug=true#results';
 
@@ -13,6 +14,7 @@ function createRow() {
 
 export default function UrlInspector() {
   const [inputUrl, setInputUrl] = useState(SAMPLE_URL);
+  useHandoffInput(setInputUrl);
   const [parts, setParts] = useState({
     scheme: 'https',
     host: 'example.com:8443',
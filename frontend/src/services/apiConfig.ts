@@ -0,0 +1,43 @@
+// Connection settings for the DevToolbox HTTP API.
+//
+// The desktop app injects window.__DEVTOOLBOX_API__ with the server's address
+// and, when the server requires one, its bearer token. In a plain browser the
+// token can be passed once as ?access_token=... and is remembered locally.
+
+const TOKEN_STORAGE_KEY = 'devtoolbox.apiToken';
+
+const injected = (typeof window !== 'undefined' && (window as any).__DEVTOOLBOX_API__) || {};
+
+export const API_BASE: string =
+  injected.baseURL || import.meta.env.VITE_API_URL || 'http://localhost:8081';
+
+function readToken(): string {
+  if (injected.token) return injected.token;
+  if (typeof window === 'undefined') return '';
+  try {
+    const url = new URL(window.location.href);
+    const fromQuery = url.searchParams.get('access_token');
+    if (fromQuery) {
+      window.localStorage.setItem(TOKEN_STORAGE_KEY, fromQuery);
+      // Keep the token out of the address bar and history
+      url.searchParams.delete('access_token');
+      window.history.replaceState(window.history.state, '', url.toString());
+      return fromQuery;
+    }
+    return window.localStorage.getItem(TOKEN_STORAGE_KEY) || '';
+  } catch {
+    return '';
+  }
+}
+
+const token = readToken();
+
+// apiToken returns the API token, or '' when none is configured
+export function apiToken(): string {
+  return token;
+}
+
+// apiHeaders adds the Authorization header when a token is known
+export function apiHeaders(headers: Record<string, string> = {}): Record<string, string> {
+  return token ? { ...headers, Authorization: `Bearer ${token}` } : headers;
+}
@@ -0,0 +1,41 @@
+// Subscribes to backend events streamed from the HTTP server's /api/events.
+// One EventSource is shared by all listeners on the page.
+
+import { API_BASE, apiToken } from './apiConfig';
+
+let source: EventSource | null = null;
+let listeners = 0;
+
+function openSource(): EventSource {
+  const url = new URL(`${API_BASE}/api/events`);
+  const token = apiToken();
+  // EventSource cannot send headers
+  if (token) url.searchParams.set('access_token', token);
+  return new EventSource(url.toString());
+}
+
+// onServerEvent calls handler with the data of each event called name, such
+// as 'settings:changed', and returns a function that unsubscribes
+export function onServerEvent(name: string, handler: (data: any) => void): () => void {
+  if (typeof EventSource === 'undefined') return () => {};
+
+  if (!source) source = openSource();
+  listeners++;
+
+  const listener = (e: MessageEvent) => {
+    try {
+      handler(JSON.parse(e.data).data);
+    } catch (err) {
+      console.error(`Invalid ${name} event:`, err);
+    }
+  };
+  source.addEventListener(name, listener as EventListener);
+
+  return () => {
+    source?.removeEventListener(name, listener as EventListener);
+    if (--listeners === 0) {
+      source?.close();
+      source = null;
+    }
+  };
+}
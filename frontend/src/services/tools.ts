@@ -0,0 +1,54 @@
+// Loads the tool catalog from the backend tool registry, the single source of
+// the sidebar and spotlight tool lists: through the ToolCatalog binding in the
+// desktop app, and from the HTTP server's /api/tools in browser mode.
+
+import { API_BASE, apiHeaders } from './apiConfig';
+
+export interface Tool {
+  id: string;
+  name: string;
+  keywords?: string[];
+  categories: string[];
+  path: string;
+}
+
+let catalog: Promise<Tool[]> | null = null;
+
+// fetchTools returns the tools in display order. The catalog is loaded once
+// per page; a failed load is retried on the next call.
+export function fetchTools(): Promise<Tool[]> {
+  if (!catalog) {
+    catalog = loadTools().catch((err) => {
+      catalog = null;
+      throw err;
+    });
+  }
+  return catalog;
+}
+
+// loadTools reads the catalog from the desktop binding, or over HTTP without one
+function loadTools(): Promise<Tool[]> {
+  const binding = typeof window !== 'undefined' && (window as any).go?.devtoolbox?.service?.ToolCatalog;
+  if (binding) {
+    return binding.List();
+  }
+  return fetch(`${API_BASE}/api/tools`, { headers: apiHeaders() })
+    .then((res) => {
+      if (!res.ok) throw new Error(`HTTP ${res.status}`);
+      return res.json();
+    })
+    .then((body) => body.data as Tool[]);
+}
+
+// matchesTool reports whether every word of query occurs in the tool's name,
+// ID, keywords or categories
+export function matchesTool(tool: Tool, query: string): boolean {
+  const text = [tool.id, tool.name, ...(tool.keywords || []), ...tool.categories]
+    .join(' ')
+    .toLowerCase();
+  return query
+    .toLowerCase()
+    .split(/\s+/)
+    .filter(Boolean)
+    .every((word) => text.includes(word));
+}
@@ -5,8 +5,7 @@ import nord from './nord.json';
 import catppuccinMocha from './catppuccin-mocha.json';
 import solarizedDark from './solarized-dark.json';
 import solarizedLight from './solarized-light.json';
-
-const API_BASE = import.meta.env.VITE_API_URL || 'http://localhost:8081';
+import { API_BASE, apiHeaders } from '../services/apiConfig';
 
 const BUNDLED_GALLERY = [oneDarkPro, dracula, nord, catppuccinMocha, solarizedDark, solarizedLight];
 
@@ -52,9 +51,9 @@ function makeKey(name) {
 
 async function fetchUserThemes() {
   try {
-    const res = await fetch(`${API_BASE}/api/themes-service/list`);
+    const res = await fetch(`${API_BASE}/api/themes-service/list`, { headers: apiHeaders() });
     if (!res.ok) return [];
-    const data = await res.json();
+    const { data } = await res.json();
     if (!Array.isArray(data)) return [];
     return data.filter((t) => t && t.name);
   } catch {
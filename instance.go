@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"devtoolbox/pkg/instance"
+	"devtoolbox/service"
+)
+
+// openUsage is the command line of "open"
+const openUsage = "usage: devtoolbox open <tool-id> [--input text|-]"
+
+// instanceSocketPath is the socket of the single-instance lock, which later
+// invocations hand their requests to
+func instanceSocketPath() string {
+	return filepath.Join(runtimeDir(), "devtoolbox-instance.sock")
+}
+
+// parseOpenArgs parses the arguments of "devtoolbox open <tool>", which opens
+// a tool in the running app, or in a new one when none is running. An input
+// of "-" is read from stdin.
+func parseOpenArgs(args []string, stdin io.Reader) (instance.Request, error) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return instance.Request{}, errors.New(openUsage)
+	}
+	tool := args[0]
+	if _, ok := service.NewToolRegistry(nil).Get(tool); !ok {
+		return instance.Request{}, fmt.Errorf("unknown tool %q", tool)
+	}
+
+	fs := flag.NewFlagSet("open", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	input := fs.String("input", "", "")
+	if err := fs.Parse(args[1:]); err != nil {
+		return instance.Request{}, fmt.Errorf("%v; %s", err, openUsage)
+	}
+	if fs.NArg() > 0 {
+		return instance.Request{}, errors.New(openUsage)
+	}
+
+	req := instance.Request{Tool: tool, Input: *input}
+	if req.Input == "-" {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return instance.Request{}, err
+		}
+		req.Input = string(data)
+	}
+	return req, nil
+}
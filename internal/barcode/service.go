@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"image/png"
+	"strings"
 
 	"github.com/boombuler/barcode"
 	"github.com/boombuler/barcode/code128"
@@ -34,6 +35,24 @@ type GenerateBarcodeResponse struct {
 	Error   string `json:"error"`
 }
 
+// ContentType returns the MIME type of generated barcodes, which are always PNG
+func (r GenerateBarcodeResponse) ContentType() string {
+	return "image/png"
+}
+
+// Bytes returns the decoded image, or nil when generation failed
+func (r GenerateBarcodeResponse) Bytes() []byte {
+	_, encoded, ok := strings.Cut(r.DataURL, ";base64,")
+	if !ok {
+		return nil
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
 // GenerateBarcode generates a barcode based on the selected standard
 func (s *BarcodeService) GenerateBarcode(req GenerateBarcodeRequest) GenerateBarcodeResponse {
 	if req.Content == "" {
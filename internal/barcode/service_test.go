@@ -1,6 +1,7 @@
 package barcode
 
 import (
+	"bytes"
 	"strings"
 	"testing"
 )
@@ -471,3 +472,24 @@ func TestCalculateEANChecksum(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateBarcodeResponseBytes(t *testing.T) {
+	service := NewBarcodeService()
+
+	resp := service.GenerateBarcode(GenerateBarcodeRequest{Content: "hello", Standard: "QR", Size: 128})
+	if resp.Error != "" {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+	if resp.ContentType() != "image/png" {
+		t.Errorf("expected image/png, got %s", resp.ContentType())
+	}
+	data := resp.Bytes()
+	if !bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")) {
+		t.Errorf("expected PNG bytes, got %d bytes", len(data))
+	}
+
+	failed := service.GenerateBarcode(GenerateBarcodeRequest{})
+	if failed.Bytes() != nil {
+		t.Error("expected no bytes for a failed generation")
+	}
+}
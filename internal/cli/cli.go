@@ -0,0 +1,317 @@
+// Package cli runs the tools from the command line as
+// "devtoolbox <tool> <operation>", calling the same services as the app
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	sharedErrors "devtoolbox/pkg/errors"
+	"devtoolbox/pkg/tools"
+	"devtoolbox/service"
+)
+
+// Exit codes
+const (
+	exitOK    = 0
+	exitError = 1 // the operation failed
+	exitUsage = 2 // the command line is invalid
+)
+
+// program is the command name in usage and completion scripts
+const program = "devtoolbox"
+
+// group is a "<tool>" command and its operations
+type group struct {
+	name string // command word, e.g. "hash"
+	tool string // ID of the tool in the registry, for the description
+	ops  []op
+}
+
+// op is one "<tool> <op>" subcommand
+type op struct {
+	name    string
+	args    string // positional arguments in the usage line
+	summary string
+	// define adds the operation's flags to fs and returns the function that
+	// runs it once they are parsed
+	define func(fs *flag.FlagSet) runFunc
+}
+
+// runFunc performs an operation. Strings are written with a trailing
+// newline, byte slices and readers as they are, anything else as JSON.
+type runFunc func(ctx context.Context, in *input) (interface{}, error)
+
+// input is an operation's positional arguments and stdin
+type input struct {
+	args  []string
+	stdin io.Reader
+}
+
+// shift removes and returns the first positional argument
+func (in *input) shift() (string, bool) {
+	if len(in.args) == 0 {
+		return "", false
+	}
+	arg := in.args[0]
+	in.args = in.args[1:]
+	return arg, true
+}
+
+// fromArgs reports whether the input is given as arguments rather than stdin
+func (in *input) fromArgs() bool {
+	return len(in.args) > 0
+}
+
+// reader returns the arguments joined by spaces, or stdin when there are none
+func (in *input) reader() io.Reader {
+	if in.fromArgs() {
+		return strings.NewReader(strings.Join(in.args, " "))
+	}
+	return in.stdin
+}
+
+// text reads the whole input
+func (in *input) text() (string, error) {
+	data, err := io.ReadAll(in.reader())
+	return string(data), err
+}
+
+// line reads the input without surrounding whitespace, for single values
+// such as tokens and timestamps
+func (in *input) line() (string, error) {
+	text, err := in.text()
+	return strings.TrimSpace(text), err
+}
+
+// usageError is an invalid command line
+type usageError struct{ msg string }
+
+func (e usageError) Error() string { return e.msg }
+
+func usagef(format string, args ...interface{}) error {
+	return usageError{fmt.Sprintf(format, args...)}
+}
+
+// IsCommand reports whether arg, the first command-line argument, names a
+// CLI command rather than an app flag
+func IsCommand(arg string) bool {
+	if arg == "help" || arg == "completion" || arg == completeCommand {
+		return true
+	}
+	_, ok := findGroup(arg)
+	return ok
+}
+
+// Run runs the command in args, without the program name, and returns the
+// process exit code
+func Run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		if len(args) > 1 {
+			if g, ok := findGroup(args[1]); ok {
+				printGroupUsage(stdout, g)
+				return exitOK
+			}
+		}
+		printUsage(stdout)
+		return exitOK
+	}
+
+	switch args[0] {
+	case "completion":
+		return runCompletion(args[1:], stdout, stderr)
+	case completeCommand:
+		for _, candidate := range Complete(args[1:]) {
+			fmt.Fprintln(stdout, candidate)
+		}
+		return exitOK
+	}
+
+	g, ok := findGroup(args[0])
+	if !ok {
+		fmt.Fprintf(stderr, "%s: unknown command %q; see '%s help'\n", program, args[0], program)
+		return exitUsage
+	}
+	if len(args) < 2 || args[1] == "-h" || args[1] == "--help" {
+		printGroupUsage(stderr, g)
+		return exitUsage
+	}
+	o, ok := g.findOp(args[1])
+	if !ok {
+		fmt.Fprintf(stderr, "%s %s: unknown operation %q; see '%s help %s'\n", program, g.name, args[1], program, g.name)
+		return exitUsage
+	}
+
+	name := g.name + " " + o.name
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() { printOpUsage(stderr, g, o, fs) }
+	run := o.define(fs)
+	positional, err := parseInterspersed(fs, args[2:])
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	} else if err != nil {
+		return exitUsage
+	}
+
+	result, err := run(ctx, &input{args: positional, stdin: stdin})
+	if err == nil {
+		err = writeResult(stdout, result)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "%s %s: %s\n", program, name, errorMessage(err))
+		var usage usageError
+		if errors.As(err, &usage) {
+			return exitUsage
+		}
+		return exitError
+	}
+	return exitOK
+}
+
+// parseInterspersed parses flags before, between and after positional
+// arguments, so "time convert 1700000000 --tz UTC" works. Arguments after
+// "--" are positional.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var rest []string
+	for i, arg := range args {
+		if arg == "--" {
+			args, rest = args[:i], args[i+1:]
+			break
+		}
+	}
+
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return append(positional, rest...), nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// writeResult writes an operation's result to w
+func writeResult(w io.Writer, result interface{}) error {
+	switch r := result.(type) {
+	case nil:
+		return nil
+	case string:
+		if !strings.HasSuffix(r, "\n") {
+			r += "\n"
+		}
+		_, err := io.WriteString(w, r)
+		return err
+	case []byte:
+		_, err := w.Write(r)
+		return err
+	case io.Reader:
+		if closer, ok := r.(io.Closer); ok {
+			defer closer.Close()
+		}
+		_, err := io.Copy(w, r)
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(result)
+}
+
+// errorMessage formats err, leading with its code when it has one
+func errorMessage(err error) string {
+	if code := sharedErrors.CodeOf(err); code != "" {
+		return code + ": " + sharedErrors.MessageOf(err)
+	}
+	return err.Error()
+}
+
+// failure returns the error a response DTO reports in-band, if any
+func failure(result interface{}) error {
+	if failed, ok := result.(sharedErrors.Failed); ok {
+		return failed.Err()
+	}
+	return nil
+}
+
+func findGroup(name string) (group, bool) {
+	for _, g := range groups {
+		if g.name == name {
+			return g, true
+		}
+	}
+	return group{}, false
+}
+
+func (g group) findOp(name string) (op, bool) {
+	for _, o := range g.ops {
+		if o.name == name {
+			return o, true
+		}
+	}
+	return op{}, false
+}
+
+// registry is the app's tool registry, which names the tools in help
+var registry = sync.OnceValue(func() *tools.Registry { return service.NewToolRegistry(nil) })
+
+// description is the tool's display name from the registry
+func (g group) description() string {
+	if tool, ok := registry().Get(g.tool); ok {
+		return tool.Name
+	}
+	return ""
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: %s <tool> <operation> [flags] [input...]\n\n", program)
+	fmt.Fprintln(w, "Input is taken from the arguments, or from stdin when there are none.")
+	fmt.Fprintln(w, "Results go to stdout; errors go to stderr with a non-zero exit code.")
+	fmt.Fprintln(w, "\nTools:")
+	for _, g := range groups {
+		fmt.Fprintf(w, "  %-10s %s\n", g.name, g.description())
+	}
+	fmt.Fprintln(w, "\nOther commands:")
+	fmt.Fprintf(w, "  %-10s %s\n", "help", "Show the operations of a tool: help <tool>")
+	fmt.Fprintf(w, "  %-10s %s\n", "open", "Open a tool in the app: open <tool-id> [--input text|-]")
+	fmt.Fprintf(w, "  %-10s %s\n", "completion", "Print a shell completion script: completion bash|zsh|fish")
+	fmt.Fprintf(w, "\nRun without a command to start the desktop app; see '%s -h' for its flags.\n", program)
+}
+
+func printGroupUsage(w io.Writer, g group) {
+	fmt.Fprintf(w, "Usage: %s %s <operation> [flags] [input...]\n\n", program, g.name)
+	if desc := g.description(); desc != "" {
+		fmt.Fprintf(w, "%s operations:\n", desc)
+	}
+	names := make([]string, 0, len(g.ops))
+	width := 0
+	for _, o := range g.ops {
+		usage := strings.TrimSpace(o.name + " " + o.args)
+		names = append(names, usage)
+		width = max(width, len(usage))
+	}
+	for i, o := range g.ops {
+		fmt.Fprintf(w, "  %-*s  %s\n", width, names[i], o.summary)
+	}
+}
+
+func printOpUsage(w io.Writer, g group, o op, fs *flag.FlagSet) {
+	fmt.Fprintf(w, "Usage: %s %s %s [flags] %s\n\n%s\n", program, g.name, o.name, o.args, o.summary)
+	hasFlags := false
+	fs.VisitAll(func(*flag.Flag) { hasFlags = true })
+	if hasFlags {
+		fmt.Fprintln(w, "\nFlags:")
+		fs.PrintDefaults()
+	}
+}
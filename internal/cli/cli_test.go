@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func run(t *testing.T, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := Run(context.Background(), args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun_HashFromStdin(t *testing.T) {
+	code, out, _ := run(t, "hello", "hash", "sha256")
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824\n", out)
+
+	// Arguments take precedence over stdin
+	_, out, _ = run(t, "ignored", "hash", "md5", "hello")
+	assert.Equal(t, "5d41402abc4b2a76b9719d911017c592\n", out)
+}
+
+func TestRun_EncodeRoundTrip(t *testing.T) {
+	_, encoded, _ := run(t, "", "encode", "base64", "hello")
+	assert.Equal(t, "aGVsbG8=\n", encoded)
+	_, decoded, _ := run(t, encoded, "decode", "base64")
+	assert.Equal(t, "hello", strings.TrimSpace(decoded))
+}
+
+func TestRun_JWTDecode(t *testing.T) {
+	token := "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.eyJzdWIiOiIxMjM0NTY3ODkwIn0.sig"
+	code, out, _ := run(t, "", "jwt", "decode", token)
+	require.Equal(t, exitOK, code)
+
+	var resp struct {
+		Header  map[string]interface{} `json:"header"`
+		Payload map[string]interface{} `json:"payload"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &resp))
+	assert.Equal(t, "HS256", resp.Header["alg"])
+	assert.Equal(t, "1234567890", resp.Payload["sub"])
+
+	code, _, stderr := run(t, "", "jwt", "decode", "not-a-token")
+	assert.Equal(t, exitError, code)
+	assert.True(t, strings.HasPrefix(stderr, "devtoolbox jwt decode: "), stderr)
+}
+
+func TestRun_FormatJSONWithFilter(t *testing.T) {
+	code, out, _ := run(t, `{"items":[{"id":1},{"id":2}]}`, "fmt", "json", "--filter", ".items[].id", "--minify")
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "[1,2]", strings.TrimSpace(out))
+}
+
+func TestRun_TimeConvertFlagsAfterArgument(t *testing.T) {
+	code, out, _ := run(t, "", "time", "convert", "1700000000", "--tz", "Asia/Ho_Chi_Minh")
+	require.Equal(t, exitOK, code)
+	assert.Contains(t, out, `"local": "2023-11-15T05:13:20+07:00"`)
+}
+
+func TestRun_TextCase(t *testing.T) {
+	_, out, _ := run(t, "hello world", "text", "case", "snake")
+	assert.Equal(t, "hello_world\n", out)
+
+	code, _, stderr := run(t, "hello", "text", "case", "shouting")
+	assert.Equal(t, exitUsage, code)
+	assert.Contains(t, stderr, "want a case")
+}
+
+func TestRun_ConvertEveryOperation(t *testing.T) {
+	inputs := map[string]string{
+		"json-yaml":       `{"name":"devtoolbox"}`,
+		"yaml-toml":       "name: devtoolbox\n",
+		"json-xml":        `{"name":"devtoolbox"}`,
+		"json-csv":        "name,version\ndevtoolbox,1\n",
+		"csv-tsv":         "name,version\ndevtoolbox,1\n",
+		"markdown-html":   "# devtoolbox\n",
+		"query-string":    "name=devtoolbox",
+		"json-properties": `{"name":"devtoolbox"}`,
+		"json-ini":        `{"app":{"name":"devtoolbox"}}`,
+		"curl-fetch":      "curl https://example.com",
+	}
+	require.Len(t, conversionMethods, len(inputs))
+	for _, m := range conversionMethods {
+		t.Run(m.op, func(t *testing.T) {
+			input, ok := inputs[m.op]
+			require.True(t, ok, "no sample input for %s", m.op)
+			code, out, stderr := run(t, input, "convert", m.op)
+			assert.Equal(t, exitOK, code, stderr)
+			assert.NotEmpty(t, strings.TrimSpace(out))
+		})
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		code int
+	}{
+		{"unknown command", []string{"nope"}, exitUsage},
+		{"missing operation", []string{"hash"}, exitUsage},
+		{"unknown operation", []string{"hash", "nope"}, exitUsage},
+		{"unknown flag", []string{"hash", "sha256", "--nope"}, exitUsage},
+		{"failed operation", []string{"number", "convert", "--base", "binary", "12"}, exitError},
+		{"unsupported shell", []string{"completion", "tcsh"}, exitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, out, stderr := run(t, "", tt.args...)
+			assert.Equal(t, tt.code, code)
+			assert.Empty(t, out)
+			assert.NotEmpty(t, stderr)
+		})
+	}
+}
+
+func TestIsCommand(t *testing.T) {
+	assert.True(t, IsCommand("hash"))
+	assert.True(t, IsCommand("completion"))
+	assert.False(t, IsCommand("--server-only"))
+	assert.False(t, IsCommand("nope"))
+}
+
+func TestComplete(t *testing.T) {
+	assert.Equal(t, []string{"hash", "help"}, Complete([]string{"h"}))
+	assert.Equal(t, []string{"sha256"}, Complete([]string{"hash", "sha25"}))
+	assert.Equal(t, []string{"--tz"}, Complete([]string{"time", "convert", "1700000000", "--t"}))
+	assert.Equal(t, []string{"bash"}, Complete([]string{"completion", "b"}))
+	assert.Equal(t, []string{"jwt"}, Complete([]string{"open", "jw"}))
+	assert.Empty(t, Complete([]string{"nope", "x"}))
+
+	code, out, _ := run(t, "", completeCommand, "jwt", "")
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "decode\nverify\nencode\n", out)
+
+	for _, shell := range shells {
+		code, out, _ := run(t, "", "completion", shell)
+		assert.Equal(t, exitOK, code)
+		assert.Contains(t, out, completeCommand)
+	}
+}
@@ -0,0 +1,528 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"slices"
+	"strings"
+
+	"devtoolbox/internal/barcode"
+	"devtoolbox/internal/codeformatter"
+	"devtoolbox/internal/datagenerator"
+	"devtoolbox/internal/datetimeconverter"
+	"devtoolbox/internal/numberconverter"
+	"devtoolbox/pkg/devtoolbox"
+	"devtoolbox/service"
+)
+
+// groups are the CLI commands, one per tool, in help order
+var groups = []group{
+	{name: "hash", tool: "hash-generator", ops: hashOps()},
+	{name: "encode", tool: "code-encoder", ops: encodingOps("Encode")},
+	{name: "decode", tool: "code-encoder", ops: encodingOps("Decode")},
+	{name: "encrypt", tool: "code-encrypter", ops: encryptionOps("Encrypt")},
+	{name: "decrypt", tool: "code-encrypter", ops: encryptionOps("Decrypt")},
+	{name: "jwt", tool: "jwt", ops: jwtOps()},
+	{name: "fmt", tool: "code-formatter", ops: formatOps()},
+	{name: "convert", tool: "code-converter", ops: convertOps()},
+	{name: "time", tool: "datetime-converter", ops: timeOps()},
+	{name: "number", tool: "number-converter", ops: numberOps()},
+	{name: "text", tool: "text-utilities", ops: textOps()},
+	{name: "data", tool: "data-generator", ops: dataOps()},
+	{name: "barcode", tool: "barcode", ops: barcodeOps()},
+}
+
+// method pairs a CLI operation name with the converter method it calls
+type method struct {
+	op, name string
+}
+
+// hashMethods are the hashing converter's methods
+var hashMethods = []method{
+	{"md5", "MD5"}, {"sha1", "SHA-1"}, {"sha224", "SHA-224"}, {"sha256", "SHA-256"},
+	{"sha384", "SHA-384"}, {"sha512", "SHA-512"}, {"sha3", "SHA-3"},
+	{"blake2b", "BLAKE2b"}, {"blake3", "BLAKE3"}, {"ripemd160", "RIPEMD-160"},
+	{"crc32", "CRC32"}, {"adler32", "Adler-32"}, {"fnv1", "FNV-1"}, {"fnv1a", "FNV-1a"},
+	{"xxhash", "xxHash"}, {"murmur3", "MurmurHash3"}, {"hmac", "HMAC"},
+	{"bcrypt", "bcrypt"}, {"argon2", "Argon2"}, {"scrypt", "scrypt"},
+}
+
+func hashOps() []op {
+	ops := make([]op, 0, len(hashMethods)+1)
+	for _, m := range hashMethods {
+		ops = append(ops, op{
+			name:    m.op,
+			args:    "[text]",
+			summary: "Hash the input with " + m.name,
+			define: func(fs *flag.FlagSet) runFunc {
+				var key *string
+				if m.op == "hmac" {
+					key = fs.String("key", "", "HMAC-SHA256 key")
+				}
+				return func(ctx context.Context, in *input) (interface{}, error) {
+					var opts devtoolbox.HashOptions
+					if key != nil {
+						opts.Key = *key
+					}
+					return devtoolbox.HashReader(ctx, in.reader(), devtoolbox.HashAlgorithm(m.name), opts)
+				}
+			},
+		})
+	}
+	return append(ops, op{
+		name:    "all",
+		args:    "[text]",
+		summary: "Hash the input with every fast hash",
+		define: func(fs *flag.FlagSet) runFunc {
+			return func(ctx context.Context, in *input) (interface{}, error) {
+				text, err := in.text()
+				if err != nil {
+					return nil, err
+				}
+				return service.NewHashGeneratorService(nil).HashAll(ctx, text)
+			}
+		},
+	})
+}
+
+// encodingMethods are the encoding converter's methods
+var encodingMethods = []method{
+	{"base64", "Base64"}, {"base64url", "Base64URL"}, {"base32", "Base32"},
+	{"base58", "Base58"}, {"base85", "Base85"}, {"hex", "Hex"}, {"url", "URL"},
+	{"html", "HTML Entities"}, {"binary", "Binary"}, {"morse", "Morse Code"},
+	{"rot13", "ROT13"}, {"rot47", "ROT47"}, {"quoted-printable", "Quoted-Printable"},
+	{"punycode", "Punycode"},
+}
+
+// encodingOps encodes or decodes. Arguments are converted as text and
+// printed with a newline; stdin is streamed to stdout unchanged.
+func encodingOps(mode string) []op {
+	ops := make([]op, 0, len(encodingMethods))
+	for _, m := range encodingMethods {
+		ops = append(ops, op{
+			name:    m.op,
+			args:    "[text]",
+			summary: mode + " the input as " + m.name,
+			define: func(fs *flag.FlagSet) runFunc {
+				return func(ctx context.Context, in *input) (interface{}, error) {
+					svc := service.NewEncoderService(nil)
+					if in.fromArgs() {
+						text, _ := in.text()
+						if mode == "Decode" {
+							return svc.Decode(text, m.name)
+						}
+						return svc.Encode(text, m.name)
+					}
+					if mode == "Decode" {
+						return devtoolbox.DecodeReader(ctx, in.stdin, devtoolbox.Encoding(m.name)), nil
+					}
+					return devtoolbox.EncodeReader(ctx, in.stdin, devtoolbox.Encoding(m.name)), nil
+				}
+			},
+		})
+	}
+	return ops
+}
+
+// encryptionMethods are the encryption converter's methods
+var encryptionMethods = []method{
+	{"aes", "AES"}, {"aes-gcm", "AES-GCM"}, {"des", "DES"}, {"3des", "Triple DES"},
+	{"chacha20", "ChaCha20"}, {"salsa20", "Salsa20"}, {"xor", "XOR"}, {"rc4", "RC4"},
+	{"rsa", "RSA"},
+}
+
+func encryptionOps(mode string) []op {
+	ops := make([]op, 0, len(encryptionMethods))
+	for _, m := range encryptionMethods {
+		ops = append(ops, op{
+			name:    m.op,
+			args:    "[text]",
+			summary: mode + " the input with " + m.name,
+			define: func(fs *flag.FlagSet) runFunc {
+				key := fs.String("key", "", "Key, or PEM key for RSA")
+				iv := fs.String("iv", "", "Initialization vector or nonce, where the method needs one")
+				return func(ctx context.Context, in *input) (interface{}, error) {
+					text, err := in.text()
+					if err != nil {
+						return nil, err
+					}
+					svc := service.NewEncrypterService(nil)
+					if mode == "Decrypt" {
+						return svc.Decrypt(strings.TrimSpace(text), m.name, *key, *iv)
+					}
+					return svc.Encrypt(text, m.name, *key, *iv)
+				}
+			},
+		})
+	}
+	return ops
+}
+
+func jwtOps() []op {
+	return []op{
+		{
+			name:    "decode",
+			args:    "[token]",
+			summary: "Decode a token's header and payload",
+			define: func(fs *flag.FlagSet) runFunc {
+				return func(ctx context.Context, in *input) (interface{}, error) {
+					token, err := in.line()
+					if err != nil {
+						return nil, err
+					}
+					resp, _ := service.NewJWTService(nil).Decode(token)
+					if err := failure(resp); err != nil {
+						return nil, err
+					}
+					return resp, nil
+				}
+			},
+		},
+		{
+			name:    "verify",
+			args:    "[token]",
+			summary: "Verify a token's signature; exits non-zero when it is invalid",
+			define: func(fs *flag.FlagSet) runFunc {
+				secret := fs.String("secret", "", "HMAC secret or PEM public key")
+				base64Secret := fs.Bool("base64", false, "The secret is base64-encoded")
+				return func(ctx context.Context, in *input) (interface{}, error) {
+					token, err := in.line()
+					if err != nil {
+						return nil, err
+					}
+					encoding := "utf8"
+					if *base64Secret {
+						encoding = "base64"
+					}
+					resp, _ := service.NewJWTService(nil).Verify(token, *secret, encoding)
+					if err := failure(resp); err != nil {
+						return nil, err
+					}
+					if !resp.Valid {
+						return nil, errors.New(resp.Message)
+					}
+					return resp.Message, nil
+				}
+			},
+		},
+		{
+			name:    "encode",
+			args:    "[payload-json]",
+			summary: "Sign a payload into a token",
+			define: func(fs *flag.FlagSet) runFunc {
+				alg := fs.String("alg", "HS256", "Signing algorithm")
+				secret := fs.String("secret", "", "HMAC secret or PEM private key")
+				header := fs.String("header", "", "Header JSON (default: alg and typ)")
+				return func(ctx context.Context, in *input) (interface{}, error) {
+					payload, err := in.text()
+					if err != nil {
+						return nil, err
+					}
+					resp, _ := service.NewJWTService(nil).Encode(*header, payload, *alg, *secret)
+					if err := failure(resp); err != nil {
+						return nil, err
+					}
+					return resp.Token, nil
+				}
+			},
+		},
+	}
+}
+
+func formatOps() []op {
+	var ops []op
+	for _, formatType := range []string{"json", "xml", "html", "css"} {
+		ops = append(ops, op{
+			name:    formatType,
+			args:    "[code]",
+			summary: "Pretty-print or minify " + strings.ToUpper(formatType),
+			define: func(fs *flag.FlagSet) runFunc {
+				minify := fs.Bool("minify", false, "Minify instead of pretty-printing")
+				var filter *string
+				switch formatType {
+				case "json":
+					filter = fs.String("filter", "", "jq filter, e.g. '.items[]'")
+				case "xml", "html":
+					filter = fs.String("filter", "", "XPath or CSS selector")
+				}
+				return func(ctx context.Context, in *input) (interface{}, error) {
+					code, err := in.text()
+					if err != nil {
+						return nil, err
+					}
+					req := codeformatter.FormatRequest{Input: code, FormatType: formatType, Minify: *minify}
+					if filter != nil {
+						req.Filter = *filter
+					}
+					resp := service.NewCodeFormatterService(nil).Format(ctx, req)
+					if resp.Error != "" {
+						return nil, errors.New(resp.Error)
+					}
+					return resp.Output, nil
+				}
+			},
+		})
+	}
+	return ops
+}
+
+// conversionMethods are the formatting converter's methods. Each converts
+// in the direction it detects from the input.
+var conversionMethods = []method{
+	{"json-yaml", string(devtoolbox.ConvertJSONYAML)},
+	{"yaml-toml", string(devtoolbox.ConvertYAMLTOML)},
+	{"json-xml", string(devtoolbox.ConvertJSONXML)},
+	{"json-csv", string(devtoolbox.ConvertJSONCSV)},
+	{"csv-tsv", string(devtoolbox.ConvertCSVTSV)},
+	{"markdown-html", string(devtoolbox.ConvertMarkdownHTML)},
+	{"query-string", string(devtoolbox.ConvertQueryString)},
+	{"json-properties", string(devtoolbox.ConvertPropertiesJSON)},
+	{"json-ini", string(devtoolbox.ConvertINIJSON)},
+	{"curl-fetch", string(devtoolbox.ConvertCurlFetch)},
+}
+
+func convertOps() []op {
+	ops := make([]op, 0, len(conversionMethods))
+	for _, m := range conversionMethods {
+		ops = append(ops, op{
+			name:    m.op,
+			args:    "[input]",
+			summary: "Convert " + m.name + ", in the direction the input suggests",
+			define: func(fs *flag.FlagSet) runFunc {
+				return func(ctx context.Context, in *input) (interface{}, error) {
+					text, err := in.text()
+					if err != nil {
+						return nil, err
+					}
+					return devtoolbox.Convert(ctx, text, devtoolbox.Conversion(m.name))
+				}
+			},
+		})
+	}
+	return ops
+}
+
+func timeOps() []op {
+	return []op{
+		{
+			name:    "convert",
+			args:    "[timestamp-or-date]",
+			summary: "Convert a Unix timestamp or date to every representation",
+			define: func(fs *flag.FlagSet) runFunc {
+				tz := fs.String("tz", "local", "Time zone: local, UTC or an IANA name such as Asia/Ho_Chi_Minh")
+				precision := fs.String("precision", "auto", "Timestamp precision: auto, seconds, millis, micros or nanos")
+				return func(ctx context.Context, in *input) (interface{}, error) {
+					value, err := in.line()
+					if err != nil {
+						return nil, err
+					}
+					resp, err := service.NewDateTimeService(nil).Convert(datetimeconverter.ConvertRequest{
+						Input:     value,
+						Precision: *precision,
+						Timezone:  *tz,
+					})
+					if err == nil {
+						err = failure(resp)
+					}
+					if err != nil {
+						return nil, err
+					}
+					return resp, nil
+				}
+			},
+		},
+		{
+			name:    "delta",
+			args:    "<date-a> <date-b>",
+			summary: "Calculate the time between two dates",
+			define: func(fs *flag.FlagSet) runFunc {
+				return func(ctx context.Context, in *input) (interface{}, error) {
+					if len(in.args) != 2 {
+						return nil, usagef("want two dates, got %d arguments", len(in.args))
+					}
+					resp, err := service.NewDateTimeService(nil).CalculateDelta(datetimeconverter.DeltaRequest{
+						DateA: in.args[0],
+						DateB: in.args[1],
+					})
+					if err == nil {
+						err = failure(resp)
+					}
+					if err != nil {
+						return nil, err
+					}
+					return resp.Delta, nil
+				}
+			},
+		},
+	}
+}
+
+func numberOps() []op {
+	return []op{{
+		name:    "convert",
+		args:    "[number]",
+		summary: "Show a number in every base with its interpretations",
+		define: func(fs *flag.FlagSet) runFunc {
+			base := fs.String("base", "decimal", "Base of the input: binary, octal, decimal or hex")
+			return func(ctx context.Context, in *input) (interface{}, error) {
+				value, err := in.line()
+				if err != nil {
+					return nil, err
+				}
+				resp := service.NewNumberConverterService(nil).Convert(numberconverter.ConvertRequest{
+					Value: value,
+					Base:  *base,
+				})
+				if resp.Error != "" {
+					return nil, errors.New(resp.Error)
+				}
+				return resp, nil
+			}
+		},
+	}}
+}
+
+// textOp adapts a text utility that takes only the input
+func textOp(name, summary string, fn func(*service.TextUtilitiesService, string) (string, error)) op {
+	return op{
+		name:    name,
+		args:    "[text]",
+		summary: summary,
+		define: func(fs *flag.FlagSet) runFunc {
+			return func(ctx context.Context, in *input) (interface{}, error) {
+				text, err := in.text()
+				if err != nil {
+					return nil, err
+				}
+				return fn(service.NewTextUtilitiesService(nil), text)
+			}
+		},
+	}
+}
+
+// textCases are the targets of "text case"
+var textCases = []string{"upper", "lower", "camel", "pascal", "snake", "kebab", "sentence"}
+
+func textOps() []op {
+	return []op{
+		{
+			name:    "sort",
+			args:    "[text]",
+			summary: "Sort lines",
+			define: func(fs *flag.FlagSet) runFunc {
+				reverse := fs.Bool("reverse", false, "Sort in descending order")
+				return func(ctx context.Context, in *input) (interface{}, error) {
+					text, err := in.text()
+					if err != nil {
+						return nil, err
+					}
+					return service.NewTextUtilitiesService(nil).SortLines(text, *reverse)
+				}
+			},
+		},
+		textOp("dedupe", "Remove duplicate lines", (*service.TextUtilitiesService).RemoveDuplicates),
+		textOp("trim", "Trim whitespace from every line", (*service.TextUtilitiesService).TrimLines),
+		textOp("remove-empty", "Remove empty lines", (*service.TextUtilitiesService).RemoveEmptyLines),
+		{
+			name:    "case",
+			args:    "<" + strings.Join(textCases, "|") + "> [text]",
+			summary: "Convert the input's case",
+			define: func(fs *flag.FlagSet) runFunc {
+				return func(ctx context.Context, in *input) (interface{}, error) {
+					target, ok := in.shift()
+					if !ok || !slices.Contains(textCases, target) {
+						return nil, usagef("want a case: %s", strings.Join(textCases, ", "))
+					}
+					text, err := in.text()
+					if err != nil {
+						return nil, err
+					}
+					return service.NewTextUtilitiesService(nil).ConvertCase(text, target)
+				}
+			},
+		},
+		{
+			name:    "stats",
+			args:    "[text]",
+			summary: "Count characters, words and lines",
+			define: func(fs *flag.FlagSet) runFunc {
+				return func(ctx context.Context, in *input) (interface{}, error) {
+					text, err := in.text()
+					if err != nil {
+						return nil, err
+					}
+					return service.NewTextUtilitiesService(nil).GetStats(text)
+				}
+			},
+		},
+	}
+}
+
+func dataOps() []op {
+	return []op{{
+		name:    "generate",
+		args:    "[template]",
+		summary: "Generate mock data from a template or preset",
+		define: func(fs *flag.FlagSet) runFunc {
+			preset := fs.String("preset", "", "Preset ID to use instead of a template")
+			count := fs.Int("count", 10, "Number of records")
+			format := fs.String("format", "json", "Output format: json, xml, csv, yaml or raw")
+			return func(ctx context.Context, in *input) (interface{}, error) {
+				svc := service.NewDataGeneratorService(nil)
+				var template string
+				if *preset != "" {
+					p, ok := datagenerator.GetPresetByID(*preset)
+					if !ok {
+						return nil, usagef("unknown preset %q", *preset)
+					}
+					template = p.Template
+				} else {
+					var err error
+					if template, err = in.text(); err != nil {
+						return nil, err
+					}
+				}
+				resp := svc.Generate(ctx, datagenerator.GenerateRequest{
+					Template:     template,
+					BatchCount:   *count,
+					OutputFormat: *format,
+				})
+				if err := failure(resp); err != nil {
+					return nil, err
+				}
+				return resp.Output, nil
+			}
+		},
+	}}
+}
+
+func barcodeOps() []op {
+	return []op{{
+		name:    "generate",
+		args:    "[content]",
+		summary: "Write a barcode or QR code as PNG to stdout",
+		define: func(fs *flag.FlagSet) runFunc {
+			standard := fs.String("standard", "QR", "QR, EAN-13, EAN-8, Code128 or Code39")
+			size := fs.Int("size", 256, "Image size in pixels")
+			level := fs.String("level", "M", "QR error correction level: L, M, Q or H")
+			return func(ctx context.Context, in *input) (interface{}, error) {
+				content, err := in.line()
+				if err != nil {
+					return nil, err
+				}
+				resp := service.NewBarcodeService(nil).GenerateBarcode(barcode.GenerateBarcodeRequest{
+					Content:  content,
+					Standard: *standard,
+					Size:     *size,
+					Level:    *level,
+					Format:   "png",
+				})
+				if resp.Error != "" {
+					return nil, errors.New(resp.Error)
+				}
+				return resp.Bytes(), nil
+			}
+		},
+	}}
+}
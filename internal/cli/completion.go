@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// completeCommand is the hidden command the completion scripts call with
+// the words typed so far, the last one being completed
+const completeCommand = "__complete"
+
+// shells are the shells "completion" has a script for
+var shells = []string{"bash", "zsh", "fish"}
+
+// Complete returns the candidates for the last of words, the command line
+// after the program name
+func Complete(words []string) []string {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	prefix := words[len(words)-1]
+	words = words[:len(words)-1]
+
+	var candidates []string
+	switch {
+	case len(words) == 0:
+		for _, g := range groups {
+			candidates = append(candidates, g.name)
+		}
+		candidates = append(candidates, "help", "open", "completion")
+	case len(words) == 1 && words[0] == "help":
+		for _, g := range groups {
+			candidates = append(candidates, g.name)
+		}
+	case len(words) == 1 && words[0] == "open":
+		candidates = registry().IDs()
+	case len(words) >= 2 && words[0] == "open":
+		if strings.HasPrefix(prefix, "-") {
+			candidates = []string{"--input"}
+		}
+	case len(words) == 1 && words[0] == "completion":
+		candidates = shells
+	case len(words) == 1:
+		if g, ok := findGroup(words[0]); ok {
+			for _, o := range g.ops {
+				candidates = append(candidates, o.name)
+			}
+		}
+	case strings.HasPrefix(prefix, "-"):
+		g, ok := findGroup(words[0])
+		if !ok {
+			return nil
+		}
+		o, ok := g.findOp(words[1])
+		if !ok {
+			return nil
+		}
+		fs := flag.NewFlagSet(o.name, flag.ContinueOnError)
+		o.define(fs)
+		fs.VisitAll(func(f *flag.Flag) { candidates = append(candidates, "--"+f.Name) })
+	}
+
+	var matches []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, prefix) {
+			matches = append(matches, candidate)
+		}
+	}
+	return matches
+}
+
+// runCompletion prints the completion script for the shell in args
+func runCompletion(args []string, stdout, stderr io.Writer) int {
+	if len(args) != 1 {
+		fmt.Fprintf(stderr, "Usage: %s completion %s\n", program, strings.Join(shells, "|"))
+		return exitUsage
+	}
+	switch args[0] {
+	case "bash":
+		fmt.Fprint(stdout, bashCompletion)
+	case "zsh":
+		fmt.Fprint(stdout, zshCompletion)
+	case "fish":
+		fmt.Fprint(stdout, fishCompletion)
+	default:
+		fmt.Fprintf(stderr, "%s completion: unsupported shell %q, want %s\n", program, args[0], strings.Join(shells, ", "))
+		return exitUsage
+	}
+	return exitOK
+}
+
+// Completion scripts. Each asks the binary for the candidates, so they stay
+// in step with the commands.
+const (
+	bashCompletion = `# bash completion for devtoolbox
+# Load it with: source <(devtoolbox completion bash)
+_devtoolbox() {
+    local IFS=$'\n'
+    COMPREPLY=($(devtoolbox __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null))
+}
+complete -o default -F _devtoolbox devtoolbox
+`
+
+	zshCompletion = `#compdef devtoolbox
+# zsh completion for devtoolbox
+# Load it with: source <(devtoolbox completion zsh)
+_devtoolbox() {
+    local -a candidates
+    candidates=("${(@f)$(devtoolbox __complete "${(@)words[2,CURRENT]}" 2>/dev/null)}")
+    if (( ${#candidates[@]} )) && [[ -n "${candidates[1]}" ]]; then
+        compadd -a candidates
+    else
+        _files
+    fi
+}
+compdef _devtoolbox devtoolbox
+`
+
+	fishCompletion = `# fish completion for devtoolbox
+# Load it with: devtoolbox completion fish | source
+function __devtoolbox_complete
+    set -l words (commandline -opc) (commandline -ct)
+    devtoolbox __complete $words[2..-1] 2>/dev/null
+end
+complete -c devtoolbox -f -a '(__devtoolbox_complete)'
+`
+)
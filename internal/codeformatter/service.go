@@ -1,6 +1,7 @@
 package codeformatter
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -25,7 +26,7 @@ type FormatResponse struct {
 
 // CodeFormatterService defines the interface for code formatting
 type CodeFormatterService interface {
-	Format(req FormatRequest) FormatResponse
+	Format(ctx context.Context, req FormatRequest) FormatResponse
 }
 
 type codeFormatterService struct{}
@@ -35,28 +36,35 @@ func NewCodeFormatterService() CodeFormatterService {
 	return &codeFormatterService{}
 }
 
-// Format formats code based on the request
-func (s *codeFormatterService) Format(req FormatRequest) FormatResponse {
+// Format formats code based on the request. jq filters stop once ctx is
+// done; other formats finish their current step and then report the error.
+func (s *codeFormatterService) Format(ctx context.Context, req FormatRequest) FormatResponse {
 	if strings.TrimSpace(req.Input) == "" {
 		return FormatResponse{Output: ""}
 	}
 
+	var resp FormatResponse
 	switch strings.ToLower(req.FormatType) {
 	case "json":
-		return s.formatJSON(req)
+		resp = s.formatJSON(ctx, req)
 	case "xml":
-		return s.formatXML(req)
+		resp = s.formatXML(req)
 	case "html":
-		return s.formatHTML(req)
+		resp = s.formatHTML(req)
 	case "css":
-		return s.formatCSS(req)
+		resp = s.formatCSS(req)
 	default:
 		return FormatResponse{Error: fmt.Sprintf("unsupported format type: %s", req.FormatType)}
 	}
+
+	if err := ctx.Err(); err != nil {
+		return FormatResponse{Error: fmt.Sprintf("formatting stopped: %v", err)}
+	}
+	return resp
 }
 
 // formatJSON formats JSON with optional jq filter
-func (s *codeFormatterService) formatJSON(req FormatRequest) FormatResponse {
+func (s *codeFormatterService) formatJSON(ctx context.Context, req FormatRequest) FormatResponse {
 	// First, validate and parse the JSON
 	var data interface{}
 	if err := json.Unmarshal([]byte(req.Input), &data); err != nil {
@@ -65,7 +73,7 @@ func (s *codeFormatterService) formatJSON(req FormatRequest) FormatResponse {
 
 	// Apply jq filter if provided
 	if strings.TrimSpace(req.Filter) != "" {
-		filtered, err := applyJQFilter(data, req.Filter)
+		filtered, err := applyJQFilter(ctx, data, req.Filter)
 		if err != nil {
 			return FormatResponse{Error: fmt.Sprintf("jq filter error: %v", err)}
 		}
@@ -87,14 +95,14 @@ func (s *codeFormatterService) formatJSON(req FormatRequest) FormatResponse {
 	return FormatResponse{Output: string(output)}
 }
 
-// applyJQFilter applies a jq filter to JSON data
-func applyJQFilter(data interface{}, filter string) (interface{}, error) {
+// applyJQFilter applies a jq filter to JSON data, stopping once ctx is done
+func applyJQFilter(ctx context.Context, data interface{}, filter string) (interface{}, error) {
 	query, err := gojq.Parse(filter)
 	if err != nil {
 		return nil, fmt.Errorf("invalid jq query: %w", err)
 	}
 
-	iter := query.Run(data)
+	iter := query.RunWithContext(ctx, data)
 	var results []interface{}
 
 	for {
@@ -1,8 +1,11 @@
 package codeformatter
 
 import (
+	"context"
+	"errors"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestCodeFormatterService_Format(t *testing.T) {
@@ -54,7 +57,7 @@ func TestCodeFormatterService_Format(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := svc.Format(tt.req)
+			result := svc.Format(context.Background(), tt.req)
 
 			if tt.wantErr {
 				if result.Error == "" {
@@ -164,7 +167,7 @@ func TestFormatJSON(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := svc.formatJSON(tt.req)
+			result := svc.formatJSON(context.Background(), tt.req)
 
 			if tt.wantErr {
 				if result.Error == "" {
@@ -223,7 +226,7 @@ func TestApplyJQFilter(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := applyJQFilter(tt.data, tt.filter)
+			result, err := applyJQFilter(context.Background(), tt.data, tt.filter)
 
 			if tt.wantErr {
 				if err == nil {
@@ -239,6 +242,26 @@ func TestApplyJQFilter(t *testing.T) {
 	}
 }
 
+func TestApplyJQFilterCancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// An endless generator only stops through the context
+	_, err := applyJQFilter(ctx, map[string]interface{}{}, "repeat(1)")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+
+	result := NewCodeFormatterService().Format(ctx, FormatRequest{
+		Input:      `{"a": 1}`,
+		FormatType: "json",
+		Filter:     "repeat(.a)",
+	})
+	if result.Error == "" || result.Output != "" {
+		t.Errorf("Expected a cancellation error, got %+v", result)
+	}
+}
+
 func TestFormatXML(t *testing.T) {
 	svc := NewCodeFormatterService().(*codeFormatterService)
 
@@ -1,12 +1,16 @@
 package converter
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/base32"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"html"
+	"io"
 	"math"
 	"net/url"
 	"strconv"
@@ -22,25 +26,48 @@ func NewEncodingConverter() ConverterService {
 	return &encodingConverter{}
 }
 
+var encodingMethods = []MethodInfo{
+	{Name: "Base64", Directions: []Direction{Encode, Decode}, Streams: true},
+	{Name: "Base64URL", Directions: []Direction{Encode, Decode}, Streams: true},
+	{Name: "Base32", Directions: []Direction{Encode, Decode}, Streams: true},
+	{Name: "Base58", Directions: []Direction{Encode, Decode}},
+	{Name: "Base85", Aliases: []string{"ASCII85"}, Directions: []Direction{Encode, Decode}},
+	{Name: "Hex", Aliases: []string{"Base16", "Base16 (Hex)"}, Directions: []Direction{Encode, Decode}, Streams: true},
+	{Name: "URL", Directions: []Direction{Encode, Decode}},
+	{Name: "HTML Entities", Aliases: []string{"HTML"}, Directions: []Direction{Encode, Decode}},
+	{Name: "Binary", Directions: []Direction{Encode, Decode}},
+	{Name: "Morse Code", Directions: []Direction{Encode, Decode}},
+	{Name: "JWT Decode", Directions: []Direction{Decode}},
+	{Name: "ROT13", Directions: []Direction{Encode, Decode}},
+	{Name: "ROT47", Directions: []Direction{Encode, Decode}},
+	{Name: "Quoted-Printable", Directions: []Direction{Encode, Decode}},
+	{Name: "Punycode", Directions: []Direction{Encode, Decode}},
+	{Name: "Bencode", Aliases: []string{"Bencoded"}, Directions: []Direction{Encode, Decode}},
+	{Name: "Protobuf", Directions: []Direction{Decode}},
+	{Name: "Gzip", Directions: []Direction{Encode, Decode}, Streams: true},
+}
+
+func (c *encodingConverter) Methods() []MethodInfo {
+	return encodingMethods
+}
+
 func (c *encodingConverter) Convert(req ConversionRequest) (string, error) {
-	subMode := "encode"
-	if val, ok := req.Config["subMode"].(string); ok {
-		subMode = val
+	m, dir, err := resolve("encoding", encodingMethods, req)
+	if err != nil {
+		return "", err
 	}
-	isEncode := strings.ToLower(subMode) != "decode"
-	method := strings.ToLower(req.Method)
+	isEncode := dir == Encode
 
-	switch {
-	case strings.Contains(method, "base64"):
+	switch m.Name {
+	case "Base64", "Base64URL":
 		if isEncode {
-			if method == "base64url" {
+			if m.Name == "Base64URL" {
 				return base64.URLEncoding.EncodeToString([]byte(req.Input)), nil
 			}
 			return base64.StdEncoding.EncodeToString([]byte(req.Input)), nil
 		}
 		var decoded []byte
-		var err error
-		if method == "base64url" {
+		if m.Name == "Base64URL" {
 			decoded, err = base64.URLEncoding.DecodeString(req.Input)
 		} else {
 			decoded, err = base64.StdEncoding.DecodeString(req.Input)
@@ -50,13 +77,13 @@ func (c *encodingConverter) Convert(req ConversionRequest) (string, error) {
 		}
 		return string(decoded), nil
 
-	case strings.Contains(method, "base58"):
+	case "Base58":
 		if isEncode {
 			return base58.Encode([]byte(req.Input)), nil
 		}
 		return string(base58.Decode(req.Input)), nil
 
-	case strings.Contains(method, "hex") || method == "base16":
+	case "Hex":
 		if isEncode {
 			return hex.EncodeToString([]byte(req.Input)), nil
 		}
@@ -66,7 +93,7 @@ func (c *encodingConverter) Convert(req ConversionRequest) (string, error) {
 		}
 		return string(decoded), nil
 
-	case strings.Contains(method, "base32"):
+	case "Base32":
 		if isEncode {
 			return base32.StdEncoding.EncodeToString([]byte(req.Input)), nil
 		}
@@ -76,25 +103,25 @@ func (c *encodingConverter) Convert(req ConversionRequest) (string, error) {
 		}
 		return string(decoded), nil
 
-	case method == "url":
+	case "URL":
 		if isEncode {
 			return url.QueryEscape(req.Input), nil
 		}
 		return url.QueryUnescape(req.Input)
 
-	case strings.Contains(method, "html"):
+	case "HTML Entities":
 		if isEncode {
 			return html.EscapeString(req.Input), nil
 		}
 		return html.UnescapeString(req.Input), nil
 
-	case method == "morse code":
+	case "Morse Code":
 		if isEncode {
 			return textToMorse(req.Input), nil
 		}
 		return morseToText(req.Input), nil
 
-	case method == "jwt decode":
+	case "JWT Decode":
 		// JWT decode is only for decoding
 		parts := strings.Split(req.Input, ".")
 		if len(parts) != 3 {
@@ -114,7 +141,7 @@ func (c *encodingConverter) Convert(req ConversionRequest) (string, error) {
 		out, _ := json.MarshalIndent(res, "", "  ")
 		return string(out), nil
 
-	case method == "binary":
+	case "Binary":
 		if isEncode {
 			var res []string
 			for _, b := range []byte(req.Input) {
@@ -133,36 +160,100 @@ func (c *encodingConverter) Convert(req ConversionRequest) (string, error) {
 		}
 		return string(res), nil
 
-	case method == "rot13":
+	case "ROT13":
 		// ROT13 is symmetric - encode and decode are the same
 		return rot13(req.Input), nil
 
-	case method == "rot47":
+	case "ROT47":
 		// ROT47 is also symmetric
 		return rot47(req.Input), nil
 
-	case strings.Contains(method, "quoted-printable"):
+	case "Quoted-Printable":
 		if isEncode {
 			return encodeQuotedPrintable(req.Input), nil
 		}
 		return decodeQuotedPrintable(req.Input)
 
-	case strings.Contains(method, "punycode"):
+	case "Punycode":
 		return convertPunycode(req.Input, isEncode)
 
-	case strings.Contains(method, "base85"):
+	case "Base85":
 		return convertBase85(req.Input, isEncode)
 
-	case strings.Contains(method, "bencode"):
+	case "Bencode":
 		return convertBencode(req.Input, isEncode)
 
-	case strings.Contains(method, "protobuf"):
+	case "Protobuf":
 		return convertProtobuf(req.Input, isEncode)
+
+	case "Gzip":
+		return convertGzip(req.Input, isEncode)
 	}
 
 	return "", fmt.Errorf("encoding method %s not supported", req.Method)
 }
 
+// ConvertStream encodes or decodes r without buffering it for the Base64,
+// Base32 and hex families and gzip. Other methods read the whole input.
+func (c *encodingConverter) ConvertStream(ctx context.Context, r io.Reader, w io.Writer, req ConversionRequest) error {
+	m, dir, err := resolve("encoding", encodingMethods, req)
+	if err != nil {
+		return err
+	}
+	isEncode := dir == Encode
+
+	switch m.Name {
+	case "Base64", "Base64URL":
+		enc := base64.StdEncoding
+		if m.Name == "Base64URL" {
+			enc = base64.URLEncoding
+		}
+		if isEncode {
+			return copyEncoded(base64.NewEncoder(enc, w), r)
+		}
+		_, err := io.Copy(w, base64.NewDecoder(enc, r))
+		return err
+
+	case "Hex":
+		if isEncode {
+			_, err := io.Copy(hex.NewEncoder(w), r)
+			return err
+		}
+		_, err := io.Copy(w, hex.NewDecoder(r))
+		return err
+
+	case "Base32":
+		if isEncode {
+			return copyEncoded(base32.NewEncoder(base32.StdEncoding, w), r)
+		}
+		_, err := io.Copy(w, base32.NewDecoder(base32.StdEncoding, r))
+		return err
+
+	case "Gzip":
+		if isEncode {
+			return copyEncoded(gzip.NewWriter(w), r)
+		}
+		zr, err := gzip.NewReader(r)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		_, err = io.Copy(w, zr)
+		return err
+	}
+
+	return convertBuffered(ctx, c, r, w, req)
+}
+
+// copyEncoded copies r into a block encoder and flushes its final block
+func copyEncoded(enc io.WriteCloser, r io.Reader) error {
+	if _, err := io.Copy(enc, r); err != nil {
+		enc.Close()
+		return err
+	}
+	return enc.Close()
+}
+
 // ROT13 implementation
 func rot13(input string) string {
 	var result strings.Builder
@@ -327,6 +418,30 @@ func convertPunycode(input string, isEncode bool) (string, error) {
 	return decoded, nil
 }
 
+// Gzip compresses input, or decompresses it to the original bytes
+func convertGzip(input string, isEncode bool) (string, error) {
+	var out bytes.Buffer
+	if isEncode {
+		zw := gzip.NewWriter(&out)
+		if _, err := io.WriteString(zw, input); err != nil {
+			return "", err
+		}
+		if err := zw.Close(); err != nil {
+			return "", err
+		}
+		return out.String(), nil
+	}
+	zr, err := gzip.NewReader(strings.NewReader(input))
+	if err != nil {
+		return "", fmt.Errorf("gzip decoding error: %w", err)
+	}
+	defer zr.Close()
+	if _, err := io.Copy(&out, zr); err != nil {
+		return "", fmt.Errorf("gzip decoding error: %w", err)
+	}
+	return out.String(), nil
+}
+
 // Base85 encoding/decoding - supports both Adobe ASCII85 and Z85 variants
 func convertBase85(input string, isEncode bool) (string, error) {
 	// Default to ASCII85, could be extended to detect variant
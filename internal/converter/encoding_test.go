@@ -164,6 +164,11 @@ func TestEncodingConverterSymmetricRoundTrip(t *testing.T) {
 			method: "quoted-printable",
 			input:  "Hello=World! Special chars: \t\n",
 		},
+		{
+			name:   "Gzip Round-Trip",
+			method: "gzip",
+			input:  "Hello World! Hello World! Hello World!\n",
+		},
 	}
 
 	for _, tt := range tests {
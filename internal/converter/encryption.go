@@ -22,13 +22,63 @@ func NewEncryptionConverter() ConverterService {
 	return &encryptionConverter{}
 }
 
+// keyField and ivField describe the secret key and IV of the symmetric
+// ciphers, which are used as raw bytes
+func keyField(desc string) ConfigField {
+	return ConfigField{Key: "key", Type: FieldString, Required: true, Description: desc}
+}
+
+func ivField(desc string) ConfigField {
+	return ConfigField{Key: "iv", Type: FieldString, Required: true, Description: desc}
+}
+
+var encryptionMethods = []MethodInfo{
+	{Name: "AES", Directions: []Direction{Encrypt, Decrypt}, Config: []ConfigField{
+		keyField("AES-256 key, exactly 32 bytes"),
+		ivField("CBC IV, exactly 16 bytes"),
+	}},
+	{Name: "AES-GCM", Directions: []Direction{Encrypt, Decrypt}, Config: []ConfigField{
+		keyField("AES-256 key, exactly 32 bytes"),
+		ivField("GCM nonce, exactly 12 bytes"),
+	}},
+	{Name: "DES", Directions: []Direction{Encrypt, Decrypt}, Config: []ConfigField{
+		keyField("DES key, exactly 8 bytes"),
+		ivField("CBC IV, exactly 8 bytes"),
+	}},
+	{Name: "Triple DES", Aliases: []string{"3DES", "TripleDES"}, Directions: []Direction{Encrypt, Decrypt}, Config: []ConfigField{
+		keyField("Triple DES key, exactly 24 bytes"),
+		ivField("CBC IV, exactly 8 bytes"),
+	}},
+	{Name: "ChaCha20", Directions: []Direction{Encrypt, Decrypt}, Config: []ConfigField{
+		keyField("ChaCha20 key, exactly 32 bytes"),
+		ivField("RFC 7539 nonce, exactly 12 bytes"),
+	}},
+	{Name: "Salsa20", Directions: []Direction{Encrypt, Decrypt}, Config: []ConfigField{
+		keyField("Salsa20 key, exactly 32 bytes"),
+		ivField("XSalsa20 nonce, exactly 24 bytes"),
+	}},
+	{Name: "XOR", Directions: []Direction{Encrypt, Decrypt}, Config: []ConfigField{
+		keyField("Key repeated over the input"),
+	}},
+	{Name: "RC4", Directions: []Direction{Encrypt, Decrypt}, Config: []ConfigField{
+		keyField("RC4 key"),
+	}},
+	{Name: "RSA", Directions: []Direction{Encrypt, Decrypt}, Config: []ConfigField{
+		{Key: "publicKey", Type: FieldString, Required: true, Direction: Encrypt, Description: "Base64 encoded PEM public key"},
+		{Key: "privateKey", Type: FieldString, Required: true, Direction: Decrypt, Description: "Base64 encoded PEM private key"},
+	}},
+}
+
+func (c *encryptionConverter) Methods() []MethodInfo {
+	return encryptionMethods
+}
+
 func (c *encryptionConverter) Convert(req ConversionRequest) (string, error) {
-	subMode := "encrypt"
-	if val, ok := req.Config["subMode"].(string); ok {
-		subMode = val
+	m, dir, err := resolve("encryption", encryptionMethods, req)
+	if err != nil {
+		return "", err
 	}
-	isEncrypt := strings.ToLower(subMode) != "decrypt"
-	method := strings.ToLower(req.Method)
+	isEncrypt := dir == Encrypt
 
 	key := []byte("")
 	if val, ok := req.Config["key"].(string); ok {
@@ -40,8 +90,8 @@ func (c *encryptionConverter) Convert(req ConversionRequest) (string, error) {
 		iv = []byte(val)
 	}
 
-	switch {
-	case method == "aes":
+	switch m.Name {
+	case "AES":
 		// Validate key and IV lengths first
 		if len(key) != 32 {
 			return "", fmt.Errorf("AES-256 requires exactly 32 bytes key (received %d bytes). Please ensure your key is exactly 32 characters", len(key))
@@ -80,7 +130,7 @@ func (c *encryptionConverter) Convert(req ConversionRequest) (string, error) {
 			return string(plaintext[:len(plaintext)-unpadding]), nil
 		}
 
-	case method == "aes-gcm":
+	case "AES-GCM":
 		// AES-GCM mode - provides authenticated encryption
 		if len(key) != 32 {
 			return "", fmt.Errorf("AES-256-GCM requires exactly 32 bytes key (received %d bytes)", len(key))
@@ -116,7 +166,7 @@ func (c *encryptionConverter) Convert(req ConversionRequest) (string, error) {
 			return string(plaintext), nil
 		}
 
-	case method == "des":
+	case "DES":
 		block, err := des.NewCipher(key) // Key must be 8 bytes
 		if err != nil {
 			return "", err
@@ -143,7 +193,7 @@ func (c *encryptionConverter) Convert(req ConversionRequest) (string, error) {
 			return string(plaintext[:len(plaintext)-unpadding]), nil
 		}
 
-	case method == "triple des" || method == "3des" || method == "tripledes":
+	case "Triple DES":
 		// Triple DES (3DES) using Encrypt-Decrypt-Encrypt (EDE) mode
 		if len(key) != 24 {
 			return "", fmt.Errorf("Triple DES requires exactly 24 bytes key (3 * 8 bytes)")
@@ -188,7 +238,7 @@ func (c *encryptionConverter) Convert(req ConversionRequest) (string, error) {
 			return string(plaintext[:len(plaintext)-unpadding]), nil
 		}
 
-	case method == "chacha20":
+	case "ChaCha20":
 		if len(key) != 32 {
 			return "", fmt.Errorf("chacha20 requires 32 byte key")
 		}
@@ -213,7 +263,7 @@ func (c *encryptionConverter) Convert(req ConversionRequest) (string, error) {
 		}
 		return string(out), nil
 
-	case method == "salsa20":
+	case "Salsa20":
 		if len(key) != 32 {
 			return "", fmt.Errorf("salsa20 requires 32 byte key")
 		}
@@ -239,7 +289,7 @@ func (c *encryptionConverter) Convert(req ConversionRequest) (string, error) {
 		}
 		return string(out), nil
 
-	case method == "xor":
+	case "XOR":
 		input := []byte(req.Input)
 		if !isEncrypt {
 			var err error
@@ -257,7 +307,7 @@ func (c *encryptionConverter) Convert(req ConversionRequest) (string, error) {
 		}
 		return string(out), nil
 
-	case method == "rc4":
+	case "RC4":
 		if len(key) == 0 {
 			return "", fmt.Errorf("RC4 requires a key")
 		}
@@ -301,7 +351,7 @@ func (c *encryptionConverter) Convert(req ConversionRequest) (string, error) {
 		}
 		return string(out), nil
 
-	case method == "rsa":
+	case "RSA":
 		// RSA encryption/decryption - accepts base64-encoded PEM keys
 		if isEncrypt {
 			// Get public key from config
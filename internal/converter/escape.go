@@ -14,32 +14,43 @@ func NewEscapeConverter() ConverterService {
 	return &escapeConverter{}
 }
 
+var escapeMethods = []MethodInfo{
+	{Name: "String Literal", Directions: []Direction{Escape, Unescape}},
+	{Name: "Unicode/Hex", Directions: []Direction{Escape, Unescape}},
+	{Name: "HTML/XML", Directions: []Direction{Escape, Unescape}},
+	{Name: "URL", Directions: []Direction{Escape, Unescape}},
+	{Name: "Regex", Directions: []Direction{Escape, Unescape}},
+}
+
+func (c *escapeConverter) Methods() []MethodInfo {
+	return escapeMethods
+}
+
 func (c *escapeConverter) Convert(req ConversionRequest) (string, error) {
-	subMode := "escape"
-	if val, ok := req.Config["subMode"].(string); ok {
-		subMode = val
+	m, dir, err := resolve("escape", escapeMethods, req)
+	if err != nil {
+		return "", err
 	}
-	isEscape := strings.ToLower(subMode) != "unescape"
-	method := strings.ToLower(req.Method)
+	isEscape := dir == Escape
 	input := req.Input
 
-	switch method {
-	case "string literal":
+	switch m.Name {
+	case "String Literal":
 		if isEscape {
 			return escapeStringLiteral(input), nil
 		}
 		return unescapeStringLiteral(input), nil
-	case "unicode/hex":
+	case "Unicode/Hex":
 		if isEscape {
 			return escapeUnicodeHex(input), nil
 		}
 		return unescapeUnicodeHex(input), nil
-	case "html/xml":
+	case "HTML/XML":
 		if isEscape {
 			return escapeHTMLXMLEntities(input), nil
 		}
 		return unescapeHTMLXMLEntities(input), nil
-	case "url":
+	case "URL":
 		if isEscape {
 			return url.QueryEscape(input), nil
 		}
@@ -48,7 +59,7 @@ func (c *escapeConverter) Convert(req ConversionRequest) (string, error) {
 			return "", fmt.Errorf("invalid URL encoding: %w", err)
 		}
 		return unescaped, nil
-	case "regex":
+	case "Regex":
 		if isEscape {
 			return escapeRegex(input), nil
 		}
@@ -23,11 +23,36 @@ func NewFormattingConverter() ConverterService {
 	return &formattingConverter{}
 }
 
+// formattingMethods convert both ways, detecting which format the input is in
+var formattingMethods = []MethodInfo{
+	{Name: "JSON ↔ YAML", Aliases: []string{"JSON -> YAML", "YAML -> JSON"}},
+	{Name: "JSON ↔ XML"},
+	{Name: "JSON ↔ CSV / TSV", Aliases: []string{"CSV -> JSON"}},
+	{Name: "YAML ↔ TOML"},
+	{Name: "Markdown ↔ HTML"},
+	{Name: "CSV ↔ TSV"},
+	{Name: "Key-Value ↔ Query String"},
+	{Name: "Properties ↔ JSON", Aliases: []string{"Properties"}},
+	{Name: "INI ↔ JSON", Aliases: []string{"INI"}},
+	{Name: "CURL ↔ Fetch"},
+	{Name: "Cron ↔ Text"},
+	{Name: "Case Swapping"},
+	{Name: "Number Bases"},
+	{Name: "Color Codes", Aliases: []string{"Color"}},
+}
+
+func (c *formattingConverter) Methods() []MethodInfo {
+	return formattingMethods
+}
+
 func (c *formattingConverter) Convert(req ConversionRequest) (string, error) {
-	method := strings.ToLower(req.Method)
+	m, _, err := resolve("formatting", formattingMethods, req)
+	if err != nil {
+		return "", err
+	}
 
-	switch {
-	case method == "json ↔ yaml" || method == "json -> yaml" || method == "yaml -> json":
+	switch m.Name {
+	case "JSON ↔ YAML":
 		// Auto-detect or use specific direction if we implement it.
 		// For now, let's try JSON first, then YAML.
 		var obj interface{}
@@ -41,7 +66,7 @@ func (c *formattingConverter) Convert(req ConversionRequest) (string, error) {
 		}
 		return "", fmt.Errorf("invalid JSON or YAML")
 
-	case method == "yaml ↔ toml":
+	case "YAML ↔ TOML":
 		var obj interface{}
 		if err := yaml.Unmarshal([]byte(req.Input), &obj); err == nil {
 			res, _ := toml.Marshal(obj)
@@ -53,7 +78,7 @@ func (c *formattingConverter) Convert(req ConversionRequest) (string, error) {
 		}
 		return "", fmt.Errorf("invalid YAML or TOML")
 
-	case method == "json ↔ xml":
+	case "JSON ↔ XML":
 		// Try to detect if input is JSON or XML
 		input := strings.TrimSpace(req.Input)
 
@@ -66,12 +91,12 @@ func (c *formattingConverter) Convert(req ConversionRequest) (string, error) {
 		// Assume JSON to XML
 		return jsonToXML(input)
 
-	case method == "markdown ↔ html":
+	case "Markdown ↔ HTML":
 		// Markdown to HTML is easier
 		output := markdown.ToHTML([]byte(req.Input), nil, nil)
 		return string(output), nil
 
-	case method == "json ↔ csv / tsv" || method == "csv -> json":
+	case "JSON ↔ CSV / TSV":
 		r := csv.NewReader(strings.NewReader(req.Input))
 		headers, err := r.Read()
 		if err != nil {
@@ -97,7 +122,7 @@ func (c *formattingConverter) Convert(req ConversionRequest) (string, error) {
 		res, _ := json.MarshalIndent(result, "", "  ")
 		return string(res), nil
 
-	case method == "csv ↔ tsv":
+	case "CSV ↔ TSV":
 		// Detect input format and convert
 		if strings.Contains(req.Input, "\t") {
 			// TSV to CSV
@@ -106,7 +131,7 @@ func (c *formattingConverter) Convert(req ConversionRequest) (string, error) {
 		// CSV to TSV
 		return convertCSVToTSV(req.Input)
 
-	case method == "key-value ↔ query string":
+	case "Key-Value ↔ Query String":
 		// Try to detect format
 		if strings.Contains(req.Input, "=") && (strings.Contains(req.Input, "&") || strings.Contains(req.Input, "?")) {
 			// Query string to Key-Value
@@ -115,7 +140,7 @@ func (c *formattingConverter) Convert(req ConversionRequest) (string, error) {
 		// Key-Value to Query String
 		return keyValueToQueryString(req.Input)
 
-	case method == "number bases":
+	case "Number Bases":
 		// Assume Input is decimal for now, convert to Hex/Binary/Octal
 		val, err := strconv.ParseInt(req.Input, 10, 64)
 		if err != nil {
@@ -123,7 +148,7 @@ func (c *formattingConverter) Convert(req ConversionRequest) (string, error) {
 		}
 		return fmt.Sprintf("Hex: %x\nBinary: %b\nOctal: %o", val, val, val), nil
 
-	case method == "case swapping":
+	case "Case Swapping":
 		// Implement basic case swap
 		var res strings.Builder
 		for _, r := range req.Input {
@@ -135,7 +160,7 @@ func (c *formattingConverter) Convert(req ConversionRequest) (string, error) {
 		}
 		return res.String(), nil
 
-	case strings.Contains(method, "properties"):
+	case "Properties ↔ JSON":
 		// Properties file format (Java .properties)
 		// Try to detect format: if it contains "key=value" or "key: value" format
 		if isPropertiesFormat(req.Input) {
@@ -144,7 +169,7 @@ func (c *formattingConverter) Convert(req ConversionRequest) (string, error) {
 		// Assume it's JSON to Properties
 		return jsonToProperties(req.Input)
 
-	case strings.Contains(method, "ini"):
+	case "INI ↔ JSON":
 		// INI file format
 		// Try to detect format: if it contains [section] headers
 		if isINIFormat(req.Input) {
@@ -153,7 +178,7 @@ func (c *formattingConverter) Convert(req ConversionRequest) (string, error) {
 		// Assume it's JSON to INI
 		return jsonToINI(req.Input)
 
-	case method == "curl ↔ fetch":
+	case "CURL ↔ Fetch":
 		// Detect input format and convert bidirectionally
 		input := strings.TrimSpace(req.Input)
 		if strings.HasPrefix(strings.ToLower(input), "curl") {
@@ -161,7 +186,7 @@ func (c *formattingConverter) Convert(req ConversionRequest) (string, error) {
 		}
 		return fetchToCurl(input)
 
-	case method == "cron ↔ text":
+	case "Cron ↔ Text":
 		// Detect input format and convert bidirectionally
 		input := strings.TrimSpace(req.Input)
 		// Check if input looks like a cron expression (5 fields separated by spaces)
@@ -170,7 +195,7 @@ func (c *formattingConverter) Convert(req ConversionRequest) (string, error) {
 		}
 		return textToCron(input)
 
-	case strings.Contains(method, "color"):
+	case "Color Codes":
 		return convertColor(req.Input)
 	}
 
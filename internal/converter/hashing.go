@@ -1,6 +1,8 @@
 package converter
 
 import (
+	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/md5"
 	"crypto/sha1"
@@ -8,11 +10,13 @@ import (
 	"crypto/sha512"
 	"encoding/binary"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"hash"
 	"hash/adler32"
 	"hash/crc32"
 	"hash/fnv"
-	"strings"
+	"io"
 
 	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
@@ -28,95 +32,225 @@ func NewHashingConverter() ConverterService {
 	return &hashingConverter{}
 }
 
+// allHashes are the fast algorithms the All method runs, in output order
+var allHashes = []string{
+	"MD5", "SHA-1", "SHA-224", "SHA-256", "SHA-384", "SHA-512",
+	"SHA-3 (Keccak)", "BLAKE2b", "BLAKE3", "RIPEMD-160",
+	"CRC32", "Adler-32", "MurmurHash3", "xxHash", "FNV-1a",
+}
+
+var hashingMethods = []MethodInfo{
+	{Name: "All"},
+	{Name: "MD5", Streams: true},
+	{Name: "SHA-1", Streams: true},
+	{Name: "SHA-224", Streams: true},
+	{Name: "SHA-256", Streams: true},
+	{Name: "SHA-384", Streams: true},
+	{Name: "SHA-512", Streams: true},
+	{Name: "SHA-3 (Keccak)", Aliases: []string{"SHA-3"}, Streams: true},
+	{Name: "BLAKE2b", Streams: true},
+	{Name: "BLAKE3", Streams: true},
+	{Name: "RIPEMD-160", Streams: true},
+	{Name: "CRC32", Streams: true},
+	{Name: "Adler-32", Streams: true},
+	{Name: "FNV-1", Aliases: []string{"FNV1"}, Streams: true},
+	{Name: "FNV-1a", Aliases: []string{"FNV1a"}, Streams: true},
+	{Name: "HMAC", Streams: true, Config: []ConfigField{
+		{Key: "key", Type: FieldString, Description: "HMAC-SHA256 key, \"defaultkey\" when empty"},
+	}},
+	{Name: "xxHash", Aliases: []string{"xxHash64"}},
+	{Name: "MurmurHash3", Aliases: []string{"Murmur3"}},
+	{Name: "bcrypt"},
+	{Name: "Argon2"},
+	{Name: "scrypt"},
+}
+
+func (c *hashingConverter) Methods() []MethodInfo {
+	return hashingMethods
+}
+
 func (c *hashingConverter) Convert(req ConversionRequest) (string, error) {
-	method := strings.ToLower(req.Method)
+	return c.ConvertContext(context.Background(), req)
+}
+
+// ConvertContext hashes req.Input, giving up when ctx is done. Key
+// derivation functions cannot be interrupted, so a cancelled call returns
+// at once and leaves them to finish in the background.
+func (c *hashingConverter) ConvertContext(ctx context.Context, req ConversionRequest) (string, error) {
+	m, _, err := resolve("hashing", hashingMethods, req)
+	if err != nil {
+		return "", err
+	}
 	input := []byte(req.Input)
 
-	switch {
-	case method == "md5":
-		sum := md5.Sum(input)
-		return hex.EncodeToString(sum[:]), nil
-	case method == "sha-1":
-		sum := sha1.Sum(input)
-		return hex.EncodeToString(sum[:]), nil
-	case method == "sha-224":
-		sum := sha256.Sum224(input)
-		return hex.EncodeToString(sum[:]), nil
-	case method == "sha-256":
-		sum := sha256.Sum256(input)
-		return hex.EncodeToString(sum[:]), nil
-	case method == "sha-384":
-		sum := sha512.Sum384(input)
-		return hex.EncodeToString(sum[:]), nil
-	case method == "sha-512":
-		sum := sha512.Sum512(input)
-		return hex.EncodeToString(sum[:]), nil
-	case strings.Contains(method, "sha-3") || method == "sha-3 (keccak)":
-		sum := sha3.Sum256(input)
-		return hex.EncodeToString(sum[:]), nil
-	case method == "blake2b":
-		sum, _ := blake2b.New256(nil)
-		sum.Write(input)
-		return hex.EncodeToString(sum.Sum(nil)), nil
-	case method == "ripemd-160":
-		h := ripemd160.New()
-		h.Write(input)
+	if h := newStreamingHash(m.Name, req.Config); h != nil {
+		if _, err := io.Copy(h, contextReader{ctx: ctx, r: bytes.NewReader(input)}); err != nil {
+			return "", err
+		}
 		return hex.EncodeToString(h.Sum(nil)), nil
-	case method == "bcrypt":
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	switch m.Name {
+	case "All":
+		return c.computeAllHashes(ctx, req.Input)
+	case "bcrypt", "Argon2", "scrypt":
+		return runContext(ctx, func() (string, error) {
+			return deriveKey(m.Name, input)
+		})
+	case "xxHash":
+		// xxHash64 implementation using FNV as base (simplified)
+		// For production, use github.com/cespare/xxhash
+		hash := xxhash64(input)
+		return fmt.Sprintf("%016x", hash), nil
+	case "MurmurHash3":
+		hash := murmurHash3(input)
+		return fmt.Sprintf("%08x", hash), nil
+	}
+
+	return "", fmt.Errorf("hashing method %s not supported", req.Method)
+}
+
+// computeAllHashes computes all available hash algorithms and returns them as JSON
+func (c *hashingConverter) computeAllHashes(ctx context.Context, input string) (string, error) {
+	results := make(map[string]string)
+
+	for _, method := range allHashes {
+		req := ConversionRequest{
+			Input:    input,
+			Category: CategoryHash,
+			Method:   method,
+			Config:   make(map[string]interface{}),
+		}
+
+		result, err := c.ConvertContext(ctx, req)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return "", ctxErr
+		}
+		if err != nil {
+			results[method] = fmt.Sprintf("Error: %s", err.Error())
+		} else {
+			results[method] = result
+		}
+	}
+
+	// Marshal to JSON for structured output
+	jsonOutput, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal hash results: %w", err)
+	}
+
+	return string(jsonOutput), nil
+}
+
+// deriveKey runs one of the password hashing functions
+func deriveKey(method string, input []byte) (string, error) {
+	switch method {
+	case "bcrypt":
 		hash, err := bcrypt.GenerateFromPassword(input, bcrypt.DefaultCost)
 		if err != nil {
 			return "", err
 		}
 		return string(hash), nil
-	case method == "crc32":
-		return fmt.Sprintf("%08x", crc32.ChecksumIEEE(input)), nil
-	case method == "adler-32":
-		return fmt.Sprintf("%08x", adler32.Checksum(input)), nil
-	case method == "hmac":
-		key := []byte("defaultkey")
-		if val, ok := req.Config["key"].(string); ok && val != "" {
-			key = []byte(val)
-		}
-		h := hmac.New(sha256.New, key)
-		h.Write(input)
-		return hex.EncodeToString(h.Sum(nil)), nil
-	case method == "argon2":
+	case "Argon2":
 		// Simple Argon2ID implementation
 		salt := []byte("defaultsalt1234") // In real world, salt should be provided
 		hash := argon2.IDKey(input, salt, 1, 64*1024, 4, 32)
 		return hex.EncodeToString(hash), nil
-	case method == "scrypt":
+	case "scrypt":
 		salt := []byte("defaultsalt1234")
 		hash, err := scrypt.Key(input, salt, 16384, 8, 1, 32)
 		if err != nil {
 			return "", err
 		}
 		return hex.EncodeToString(hash), nil
-	case method == "xxhash" || method == "xxhash64":
-		// xxHash64 implementation using FNV as base (simplified)
-		// For production, use github.com/cespare/xxhash
-		hash := xxhash64(input)
-		return fmt.Sprintf("%016x", hash), nil
-	case method == "fnv-1a" || method == "fnv1a":
-		h := fnv.New64a()
-		h.Write(input)
-		return fmt.Sprintf("%016x", h.Sum64()), nil
-	case method == "fnv-1" || method == "fnv1":
-		h := fnv.New64()
-		h.Write(input)
-		return fmt.Sprintf("%016x", h.Sum64()), nil
-	case method == "blake3":
-		// BLAKE3 implementation - since Go stdlib doesn't have BLAKE3,
-		// we use BLAKE2b as a fallback or implement a simplified version
-		// For production, use github.com/zeebo/blake3
-		hash := blake3Hash(input)
-		return hex.EncodeToString(hash), nil
-	case method == "murmurhash3" || method == "murmur3":
-		hash := murmurHash3(input)
-		return fmt.Sprintf("%08x", hash), nil
 	}
+	return "", fmt.Errorf("hashing method %s not supported", method)
+}
 
-	return "", fmt.Errorf("hashing method %s not supported", req.Method)
+// runContext runs fn in the background and returns its result, or ctx's
+// error if ctx is done first
+func runContext(ctx context.Context, fn func() (string, error)) (string, error) {
+	type result struct {
+		out string
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		out, err := fn()
+		done <- result{out, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.out, r.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// ConvertStream hashes r without buffering it. Methods that need the whole
+// input (bcrypt, argon2, scrypt, xxHash, MurmurHash3) read it into memory.
+func (c *hashingConverter) ConvertStream(ctx context.Context, r io.Reader, w io.Writer, req ConversionRequest) error {
+	m, _, err := resolve("hashing", hashingMethods, req)
+	if err != nil {
+		return err
+	}
+	h := newStreamingHash(m.Name, req.Config)
+	if h == nil {
+		return convertBuffered(ctx, c, r, w, req)
+	}
+	if _, err := io.Copy(h, r); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, hex.EncodeToString(h.Sum(nil)))
+	return err
+}
+
+// newStreamingHash returns a hash.Hash for the methods that digest input
+// incrementally, or nil. Checksums are big-endian, so hex-encoding Sum
+// matches their zero-padded hex form.
+func newStreamingHash(method string, config map[string]interface{}) hash.Hash {
+	switch method {
+	case "MD5":
+		return md5.New()
+	case "SHA-1":
+		return sha1.New()
+	case "SHA-224":
+		return sha256.New224()
+	case "SHA-256":
+		return sha256.New()
+	case "SHA-384":
+		return sha512.New384()
+	case "SHA-512":
+		return sha512.New()
+	case "SHA-3 (Keccak)":
+		return sha3.New256()
+	case "BLAKE2b":
+		h, _ := blake2b.New256(nil)
+		return h
+	case "RIPEMD-160":
+		return ripemd160.New()
+	case "CRC32":
+		return crc32.NewIEEE()
+	case "Adler-32":
+		return adler32.New()
+	case "HMAC":
+		key := []byte("defaultkey")
+		if val, ok := config["key"].(string); ok && val != "" {
+			key = []byte(val)
+		}
+		return hmac.New(sha256.New, key)
+	case "FNV-1a":
+		return fnv.New64a()
+	case "FNV-1":
+		return fnv.New64()
+	case "BLAKE3":
+		return newBlake3Hash()
+	}
+	return nil
 }
 
 // Simple xxHash64 implementation (based on xxHash algorithm)
@@ -221,12 +355,11 @@ func rotateLeft(x uint64, n uint) uint64 {
 
 // BLAKE3 implementation using BLAKE2b as base
 // For production, use github.com/zeebo/blake3
-func blake3Hash(input []byte) []byte {
+func newBlake3Hash() hash.Hash {
 	// BLAKE3 produces a 256-bit (32-byte) output by default
 	// We'll use BLAKE2b with 256-bit output as a close approximation
 	h, _ := blake2b.New256(nil)
-	h.Write(input)
-	return h.Sum(nil)
+	return h
 }
 
 // MurmurHash3 implementation (32-bit variant)
@@ -0,0 +1,275 @@
+package converter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Categories the built-in converters are registered under
+const (
+	CategoryEncode  = "Encode - Decode"
+	CategoryEncrypt = "Encrypt - Decrypt"
+	CategoryHash    = "Hash"
+	CategoryConvert = "Convert"
+	CategoryEscape  = "Escape"
+)
+
+// Direction is the way a two-way method runs. Requests pick one with the
+// subMode config key.
+type Direction string
+
+// Directions
+const (
+	Encode   Direction = "Encode"
+	Decode   Direction = "Decode"
+	Encrypt  Direction = "Encrypt"
+	Decrypt  Direction = "Decrypt"
+	Escape   Direction = "Escape"
+	Unescape Direction = "Unescape"
+)
+
+// FieldType is the type a config value must have
+type FieldType string
+
+// Field types
+const (
+	FieldString FieldType = "string"
+	FieldNumber FieldType = "number"
+	FieldBool   FieldType = "boolean"
+)
+
+// ConfigField describes a config key a method reads
+type ConfigField struct {
+	Key         string    `json:"key"`
+	Type        FieldType `json:"type"`
+	Description string    `json:"description,omitempty"`
+	// Required fields must be set and not empty
+	Required bool `json:"required,omitempty"`
+	// Direction, if set, is the only direction the field is read in
+	Direction Direction `json:"direction,omitempty"`
+}
+
+// MethodInfo describes a method a converter accepts: the names it answers
+// to, the directions it runs in and the config it reads
+type MethodInfo struct {
+	Category string `json:"category"`
+	Name     string `json:"name"`
+	// Aliases are other names the method is found by, such as those older
+	// clients send
+	Aliases []string `json:"aliases,omitempty"`
+	// Directions are the values subMode accepts, the default first. One-way
+	// methods have none and ignore subMode.
+	Directions []Direction `json:"directions,omitempty"`
+	// Streams reports whether the method converts a stream without holding
+	// it in memory
+	Streams bool          `json:"streams,omitempty"`
+	Config  []ConfigField `json:"config,omitempty"`
+}
+
+// Is reports whether name is the method's name or one of its aliases,
+// ignoring case
+func (m MethodInfo) Is(name string) bool {
+	if strings.EqualFold(m.Name, name) {
+		return true
+	}
+	for _, alias := range m.Aliases {
+		if strings.EqualFold(alias, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Direction returns the direction config's subMode selects, or the default
+// when it is not set. One-way methods return the empty Direction.
+func (m MethodInfo) Direction(config map[string]interface{}) (Direction, error) {
+	if len(m.Directions) == 0 {
+		return "", nil
+	}
+	subMode, ok := config["subMode"]
+	if !ok || subMode == "" {
+		return m.Directions[0], nil
+	}
+	if s, ok := subMode.(string); ok {
+		for _, d := range m.Directions {
+			if strings.EqualFold(string(d), s) {
+				return d, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("%s does not support subMode %v", m.Name, subMode)
+}
+
+// Validate checks config against the method's schema. Keys the method does
+// not read are ignored.
+func (m MethodInfo) Validate(config map[string]interface{}) error {
+	dir, err := m.Direction(config)
+	if err != nil {
+		return err
+	}
+	for _, f := range m.Config {
+		if f.Direction != "" && f.Direction != dir {
+			continue
+		}
+		val, ok := config[f.Key]
+		if !ok || val == nil || val == "" {
+			if f.Required {
+				return fmt.Errorf("%s requires %s in config", m.Name, f.Key)
+			}
+			continue
+		}
+		if !f.Type.accepts(val) {
+			return fmt.Errorf("%s config %s must be a %s", m.Name, f.Key, f.Type)
+		}
+	}
+	return nil
+}
+
+func (t FieldType) accepts(val interface{}) bool {
+	switch val.(type) {
+	case string:
+		return t == FieldString
+	case bool:
+		return t == FieldBool
+	case float64, float32, int, int64:
+		return t == FieldNumber
+	}
+	return false
+}
+
+// resolve finds req's method among methods and validates its config,
+// returning the method and the direction to run it in. kind names the
+// converter in errors.
+func resolve(kind string, methods []MethodInfo, req ConversionRequest) (MethodInfo, Direction, error) {
+	m, ok := findMethod(methods, req.Method)
+	if !ok {
+		return MethodInfo{}, "", fmt.Errorf("%s method %s not supported", kind, req.Method)
+	}
+	if err := m.Validate(req.Config); err != nil {
+		return MethodInfo{}, "", err
+	}
+	dir, err := m.Direction(req.Config)
+	return m, dir, err
+}
+
+func findMethod(methods []MethodInfo, name string) (MethodInfo, bool) {
+	for _, m := range methods {
+		if m.Is(name) {
+			return m, true
+		}
+	}
+	return MethodInfo{}, false
+}
+
+// MethodConverter is a converter that describes the methods it accepts
+type MethodConverter interface {
+	ConverterService
+	Methods() []MethodInfo
+}
+
+// Registry routes requests to the converter registered for their category
+// and lists the methods each converter accepts
+type Registry struct {
+	categories []registered
+}
+
+type registered struct {
+	name string
+	conv MethodConverter
+}
+
+// NewRegistry returns a registry of the built-in converters
+func NewRegistry() *Registry {
+	r := &Registry{}
+	r.Register(CategoryEncode, NewEncodingConverter().(MethodConverter))
+	r.Register(CategoryEncrypt, NewEncryptionConverter().(MethodConverter))
+	r.Register(CategoryHash, NewHashingConverter().(MethodConverter))
+	r.Register(CategoryConvert, NewFormattingConverter().(MethodConverter))
+	r.Register(CategoryEscape, NewEscapeConverter().(MethodConverter))
+	return r
+}
+
+// NewConverterService returns a registry of the built-in converters as a
+// ConverterService
+func NewConverterService() ConverterService {
+	return NewRegistry()
+}
+
+// Register adds conv under category, replacing any converter already there
+func (r *Registry) Register(category string, conv MethodConverter) {
+	for i, c := range r.categories {
+		if strings.EqualFold(c.name, category) {
+			r.categories[i].conv = conv
+			return
+		}
+	}
+	r.categories = append(r.categories, registered{name: category, conv: conv})
+}
+
+// Methods lists the methods of category, or of every category when it is
+// empty, in registration order
+func (r *Registry) Methods(category string) []MethodInfo {
+	var methods []MethodInfo
+	for _, c := range r.categories {
+		if category != "" && !strings.EqualFold(c.name, category) {
+			continue
+		}
+		for _, m := range c.conv.Methods() {
+			m.Category = c.name
+			methods = append(methods, m)
+		}
+	}
+	return methods
+}
+
+// Lookup finds a method of category by its name or an alias
+func (r *Registry) Lookup(category, method string) (MethodInfo, bool) {
+	return findMethod(r.Methods(category), method)
+}
+
+// Validate checks that req names a registered method and that its config
+// matches the method's schema
+func (r *Registry) Validate(req ConversionRequest) error {
+	if _, err := r.converter(req.Category); err != nil {
+		return err
+	}
+	m, ok := r.Lookup(req.Category, req.Method)
+	if !ok {
+		return fmt.Errorf("method %s not supported in category %s", req.Method, req.Category)
+	}
+	return m.Validate(req.Config)
+}
+
+func (r *Registry) converter(category string) (MethodConverter, error) {
+	for _, c := range r.categories {
+		if strings.EqualFold(c.name, category) {
+			return c.conv, nil
+		}
+	}
+	return nil, fmt.Errorf("category %s not supported", category)
+}
+
+func (r *Registry) Convert(req ConversionRequest) (string, error) {
+	return r.ConvertContext(context.Background(), req)
+}
+
+// ConvertContext routes req to its category's converter, giving up when ctx is done
+func (r *Registry) ConvertContext(ctx context.Context, req ConversionRequest) (string, error) {
+	conv, err := r.converter(req.Category)
+	if err != nil {
+		return "", err
+	}
+	return ConvertContext(ctx, conv, req)
+}
+
+// ConvertStream routes req to its category's converter, streaming when the
+// converter can
+func (r *Registry) ConvertStream(ctx context.Context, rd io.Reader, w io.Writer, req ConversionRequest) error {
+	conv, err := r.converter(req.Category)
+	if err != nil {
+		return err
+	}
+	return ConvertStream(ctx, conv, rd, w, req)
+}
@@ -0,0 +1,173 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegistryLookup(t *testing.T) {
+	r := NewRegistry()
+
+	tests := []struct {
+		name     string
+		category string
+		method   string
+		expected string
+		found    bool
+	}{
+		{"Exact name", CategoryEncode, "Base64", "Base64", true},
+		{"Any case", "encode - decode", "base64url", "Base64URL", true},
+		{"Alias", CategoryEncode, "Base16 (Hex)", "Hex", true},
+		{"Hash alias", CategoryHash, "sha-3", "SHA-3 (Keccak)", true},
+		{"Cipher alias", CategoryEncrypt, "3des", "Triple DES", true},
+		{"No partial match", CategoryEncode, "Base64 Standard", "", false},
+		{"Wrong category", CategoryHash, "Base64", "", false},
+		{"Unknown category", "Compress", "gzip", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ok := r.Lookup(tt.category, tt.method)
+			if ok != tt.found {
+				t.Fatalf("Expected found %v, got %v", tt.found, ok)
+			}
+			if m.Name != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, m.Name)
+			}
+		})
+	}
+}
+
+func TestRegistryMethodsAreUnique(t *testing.T) {
+	r := NewRegistry()
+
+	for _, category := range []string{CategoryEncode, CategoryEncrypt, CategoryHash, CategoryConvert, CategoryEscape} {
+		methods := r.Methods(category)
+		if len(methods) == 0 {
+			t.Fatalf("No methods registered for %s", category)
+		}
+		seen := map[string]string{}
+		for _, m := range methods {
+			if m.Category != category {
+				t.Errorf("%s has category %q, expected %q", m.Name, m.Category, category)
+			}
+			for _, name := range append([]string{m.Name}, m.Aliases...) {
+				key := strings.ToLower(name)
+				if other, ok := seen[key]; ok {
+					t.Errorf("%s: %q names both %s and %s", category, name, other, m.Name)
+				}
+				seen[key] = m.Name
+			}
+		}
+	}
+}
+
+// Every listed method must reach its converter's implementation rather
+// than fall through to "not supported"
+func TestRegistryMethodsAreImplemented(t *testing.T) {
+	r := NewRegistry()
+
+	for _, m := range r.Methods("") {
+		for _, dir := range append([]Direction{""}, m.Directions...) {
+			config := map[string]interface{}{}
+			if dir != "" {
+				config["subMode"] = string(dir)
+			}
+			for _, f := range m.Config {
+				config[f.Key] = "x"
+			}
+			_, err := r.Convert(ConversionRequest{Input: "", Category: m.Category, Method: m.Name, Config: config})
+			if err != nil && strings.Contains(err.Error(), "not supported") {
+				t.Errorf("%s %s %s: %v", m.Category, m.Name, dir, err)
+			}
+		}
+	}
+}
+
+func TestMethodValidate(t *testing.T) {
+	r := NewRegistry()
+
+	tests := []struct {
+		name    string
+		req     ConversionRequest
+		wantErr bool
+	}{
+		{
+			name: "AES with key and IV",
+			req: ConversionRequest{Category: CategoryEncrypt, Method: "AES", Config: map[string]interface{}{
+				"subMode": "Encrypt", "key": "12345678901234567890123456789012", "iv": "1234567890123456",
+			}},
+		},
+		{
+			name:    "AES without key",
+			req:     ConversionRequest{Category: CategoryEncrypt, Method: "AES", Config: map[string]interface{}{"iv": "1234567890123456"}},
+			wantErr: true,
+		},
+		{
+			name:    "XOR with a numeric key",
+			req:     ConversionRequest{Category: CategoryEncrypt, Method: "XOR", Config: map[string]interface{}{"key": 42.0}},
+			wantErr: true,
+		},
+		{
+			name: "RSA encrypt reads only the public key",
+			req: ConversionRequest{Category: CategoryEncrypt, Method: "RSA", Config: map[string]interface{}{
+				"subMode": "Encrypt", "publicKey": "pem",
+			}},
+		},
+		{
+			name: "RSA decrypt needs the private key",
+			req: ConversionRequest{Category: CategoryEncrypt, Method: "RSA", Config: map[string]interface{}{
+				"subMode": "Decrypt", "publicKey": "pem",
+			}},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown direction",
+			req:     ConversionRequest{Category: CategoryEncode, Method: "Base64", Config: map[string]interface{}{"subMode": "Encrypt"}},
+			wantErr: true,
+		},
+		{
+			name:    "Decode-only method",
+			req:     ConversionRequest{Category: CategoryEncode, Method: "JWT Decode", Config: map[string]interface{}{"subMode": "Encode"}},
+			wantErr: true,
+		},
+		{
+			name: "One-way method ignores subMode",
+			req:  ConversionRequest{Category: CategoryHash, Method: "MD5", Config: map[string]interface{}{"subMode": "Encode"}},
+		},
+		{
+			name:    "Unknown method",
+			req:     ConversionRequest{Category: CategoryHash, Method: "SHA-257"},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown category",
+			req:     ConversionRequest{Category: "Compress", Method: "gzip"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := r.Validate(tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegistryConvertValidates(t *testing.T) {
+	r := NewRegistry()
+
+	// XOR used to divide by the length of an empty key
+	_, err := r.Convert(ConversionRequest{
+		Input:    "hello",
+		Category: CategoryEncrypt,
+		Method:   "XOR",
+		Config:   map[string]interface{}{"subMode": "Encrypt"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "requires key") {
+		t.Errorf("Expected a missing key error, got %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package converter
+
+import (
+	"context"
+	"io"
+)
+
+// StreamConverter is implemented by converters that can process input
+// incrementally, so large files never have to be held in memory
+type StreamConverter interface {
+	ConvertStream(ctx context.Context, r io.Reader, w io.Writer, req ConversionRequest) error
+}
+
+// ContextConverter is implemented by converters whose work can be cancelled
+type ContextConverter interface {
+	ConvertContext(ctx context.Context, req ConversionRequest) (string, error)
+}
+
+// ConvertContext converts req, giving up when ctx is done. Converters that
+// do not implement ContextConverter are only checked before and after.
+func ConvertContext(ctx context.Context, c ConverterService, req ConversionRequest) (string, error) {
+	if cc, ok := c.(ContextConverter); ok {
+		return cc.ConvertContext(ctx, req)
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	out, err := c.Convert(req)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return "", ctxErr
+	}
+	return out, err
+}
+
+// ConvertStream converts r into w, streaming when c supports it and reading
+// the whole input otherwise. req.Input is ignored. Reading stops once ctx is
+// done.
+func ConvertStream(ctx context.Context, c ConverterService, r io.Reader, w io.Writer, req ConversionRequest) error {
+	r = contextReader{ctx: ctx, r: r}
+	if sc, ok := c.(StreamConverter); ok {
+		return sc.ConvertStream(ctx, r, w, req)
+	}
+	return convertBuffered(ctx, c, r, w, req)
+}
+
+// convertBuffered reads all of r and converts it with ConvertContext, for
+// methods that need the whole input at once
+func convertBuffered(ctx context.Context, c ConverterService, r io.Reader, w io.Writer, req ConversionRequest) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	req.Input = string(data)
+
+	out, err := ConvertContext(ctx, c, req)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, out)
+	return err
+}
+
+// contextReader fails reads once ctx is done. It deliberately hides any
+// WriterTo on r so io.Copy reads in chunks and notices cancellation.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (cr contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.r.Read(p)
+}
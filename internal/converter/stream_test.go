@@ -0,0 +1,148 @@
+package converter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConvertStreamMatchesConvert(t *testing.T) {
+	input := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 100)
+
+	tests := []struct {
+		name    string
+		conv    ConverterService
+		method  string
+		config  map[string]interface{}
+		decoded bool // input is the encoded form of input
+	}{
+		{"MD5", NewHashingConverter(), "MD5", nil, false},
+		{"SHA-256", NewHashingConverter(), "SHA-256", nil, false},
+		{"SHA-3", NewHashingConverter(), "SHA-3 (Keccak)", nil, false},
+		{"BLAKE3", NewHashingConverter(), "BLAKE3", nil, false},
+		{"CRC32", NewHashingConverter(), "CRC32", nil, false},
+		{"Adler-32", NewHashingConverter(), "Adler-32", nil, false},
+		{"FNV-1a", NewHashingConverter(), "FNV-1a", nil, false},
+		{"HMAC", NewHashingConverter(), "HMAC", map[string]interface{}{"key": "secret"}, false},
+		{"xxHash buffered", NewHashingConverter(), "xxHash", nil, false},
+		{"Base64 encode", NewEncodingConverter(), "Base64", map[string]interface{}{"subMode": "Encode"}, false},
+		{"Base64URL encode", NewEncodingConverter(), "Base64URL", map[string]interface{}{"subMode": "Encode"}, false},
+		{"Base32 encode", NewEncodingConverter(), "Base32", map[string]interface{}{"subMode": "Encode"}, false},
+		{"Hex encode", NewEncodingConverter(), "Hex", map[string]interface{}{"subMode": "Encode"}, false},
+		{"Base58 buffered", NewEncodingConverter(), "Base58", map[string]interface{}{"subMode": "Encode"}, false},
+		{"Base64 decode", NewEncodingConverter(), "Base64", map[string]interface{}{"subMode": "Decode"}, true},
+		{"Hex decode", NewEncodingConverter(), "Hex", map[string]interface{}{"subMode": "Decode"}, true},
+		{"Base32 decode", NewEncodingConverter(), "Base32", map[string]interface{}{"subMode": "Decode"}, true},
+		{"Gzip encode", NewEncodingConverter(), "Gzip", map[string]interface{}{"subMode": "Encode"}, false},
+		{"Gzip decode", NewEncodingConverter(), "Gzip", map[string]interface{}{"subMode": "Decode"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.config == nil {
+				tt.config = map[string]interface{}{}
+			}
+			source := input
+			if tt.decoded {
+				encoded, err := tt.conv.Convert(ConversionRequest{
+					Input:  input,
+					Method: tt.method,
+					Config: map[string]interface{}{"subMode": "Encode"},
+				})
+				if err != nil {
+					t.Fatalf("Encode error: %v", err)
+				}
+				source = encoded
+			}
+
+			req := ConversionRequest{Input: source, Method: tt.method, Config: tt.config}
+			expected, err := tt.conv.Convert(req)
+			if err != nil {
+				t.Fatalf("Convert error: %v", err)
+			}
+
+			var out bytes.Buffer
+			req.Input = ""
+			if err := ConvertStream(context.Background(), tt.conv, strings.NewReader(source), &out, req); err != nil {
+				t.Fatalf("ConvertStream error: %v", err)
+			}
+			if out.String() != expected {
+				t.Errorf("Expected %.40q..., got %.40q...", expected, out.String())
+			}
+		})
+	}
+}
+
+func TestConvertStreamInvalidInput(t *testing.T) {
+	var out bytes.Buffer
+	err := ConvertStream(context.Background(), NewEncodingConverter(), strings.NewReader("not base64!"), &out, ConversionRequest{
+		Method: "Base64",
+		Config: map[string]interface{}{"subMode": "Decode"},
+	})
+	if err == nil {
+		t.Error("Expected error for invalid Base64 input")
+	}
+
+	err = ConvertStream(context.Background(), NewHashingConverter(), strings.NewReader("x"), &out, ConversionRequest{
+		Method: "Nope",
+		Config: map[string]interface{}{},
+	})
+	if err == nil {
+		t.Error("Expected error for unsupported method")
+	}
+}
+
+func TestConvertStreamCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var out bytes.Buffer
+	err := ConvertStream(ctx, NewHashingConverter(), strings.NewReader("data"), &out, ConversionRequest{
+		Method: "SHA-256",
+		Config: map[string]interface{}{},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestConvertContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, method := range []string{"SHA-256", "bcrypt", "scrypt", "xxHash", "All"} {
+		t.Run(method, func(t *testing.T) {
+			_, err := ConvertContext(ctx, NewConverterService(), ConversionRequest{
+				Input:    "data",
+				Category: "Hash",
+				Method:   method,
+				Config:   map[string]interface{}{},
+			})
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("Expected context.Canceled, got %v", err)
+			}
+		})
+	}
+}
+
+func TestConvertContextDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	// Argon2 takes far longer than the deadline and cannot be interrupted
+	start := time.Now()
+	_, err := ConvertContext(ctx, NewHashingConverter(), ConversionRequest{
+		Input:  "data",
+		Method: "argon2",
+		Config: map[string]interface{}{},
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected to return at the deadline, took %v", elapsed)
+	}
+}
@@ -35,6 +35,20 @@ type GenerateResponse struct {
 	Count    int    `json:"count"`
 	Error    string `json:"error,omitempty"`
 	Duration int64  `json:"durationMs"` // Generation time in milliseconds
+	err      error
+}
+
+// Err returns the typed error behind Error, if any
+func (r GenerateResponse) Err() error {
+	return r.err
+}
+
+// ErrorResponse creates a GenerateResponse reporting err
+func ErrorResponse(err error) GenerateResponse {
+	return GenerateResponse{
+		Error: err.Error(),
+		err:   err,
+	}
 }
 
 // ValidationResult represents template validation result
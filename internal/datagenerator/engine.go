@@ -2,7 +2,9 @@ package datagenerator
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"io"
 	"strings"
 	"text/template"
 	"time"
@@ -233,6 +235,11 @@ func NewEngineWithSeed(seed int64) *Engine {
 
 // Execute parses and executes a template with the given data
 func (e *Engine) Execute(templateStr string, data interface{}) (string, error) {
+	return e.execute(context.Background(), templateStr, data)
+}
+
+// execute runs a template, stopping at its next output once ctx is done
+func (e *Engine) execute(ctx context.Context, templateStr string, data interface{}) (string, error) {
 	if strings.TrimSpace(templateStr) == "" {
 		return "", ErrEmptyTemplate
 	}
@@ -247,13 +254,29 @@ func (e *Engine) Execute(templateStr string, data interface{}) (string, error) {
 
 	// Execute template
 	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
+	if err := tmpl.Execute(contextWriter{ctx: ctx, w: &buf}, data); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return "", ctxErr
+		}
 		return "", fmt.Errorf("%w: %v", ErrGenerationFailed, err)
 	}
 
 	return buf.String(), nil
 }
 
+// contextWriter fails writes once ctx is done, which aborts template execution
+type contextWriter struct {
+	ctx context.Context
+	w   io.Writer
+}
+
+func (cw contextWriter) Write(p []byte) (int, error) {
+	if err := cw.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cw.w.Write(p)
+}
+
 // Validate checks if a template is valid
 func (e *Engine) Validate(templateStr string) error {
 	if strings.TrimSpace(templateStr) == "" {
@@ -271,8 +294,9 @@ func (e *Engine) Validate(templateStr string) error {
 	return nil
 }
 
-// GenerateBatch generates multiple records using a template
-func (e *Engine) GenerateBatch(templateStr string, batchCount int, variables map[string]interface{}) ([]string, error) {
+// GenerateBatch generates multiple records using a template. It stops with
+// ctx's error once ctx is done.
+func (e *Engine) GenerateBatch(ctx context.Context, templateStr string, batchCount int, variables map[string]interface{}) ([]string, error) {
 	if batchCount < 1 || batchCount > 1000 {
 		return nil, ErrInvalidBatchCount
 	}
@@ -286,11 +310,15 @@ func (e *Engine) GenerateBatch(templateStr string, batchCount int, variables map
 
 	results := make([]string, batchCount)
 	for i := 0; i < batchCount; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		// Create a new faker for each iteration to ensure randomness
 		seed := time.Now().UnixNano() + int64(i)
 		e.fakerFuncs = NewFakerFuncsWithSeed(seed)
 
-		result, err := e.Execute(templateStr, data)
+		result, err := e.execute(ctx, templateStr, data)
 		if err != nil {
 			return nil, err
 		}
@@ -1,11 +1,20 @@
 package datagenerator
 
-import "errors"
+import sharedErrors "devtoolbox/pkg/errors"
+
+// Error codes for datagenerator package
+const (
+	ErrCodeInvalidTemplate   = "INVALID_TEMPLATE"
+	ErrCodeInvalidBatchCount = "INVALID_BATCH_COUNT"
+	ErrCodeInvalidFormat     = "INVALID_FORMAT"
+	ErrCodeGenerationFailed  = "GENERATION_FAILED"
+	ErrCodeEmptyTemplate     = "EMPTY_TEMPLATE"
+)
 
 var (
-	ErrInvalidTemplate   = errors.New("invalid template syntax")
-	ErrInvalidBatchCount = errors.New("batch count must be between 10 and 1000")
-	ErrInvalidFormat     = errors.New("invalid output format")
-	ErrGenerationFailed  = errors.New("data generation failed")
-	ErrEmptyTemplate     = errors.New("template cannot be empty")
+	ErrInvalidTemplate   = sharedErrors.NewDomainError(ErrCodeInvalidTemplate, "invalid template syntax").WithKind(sharedErrors.KindInvalid)
+	ErrInvalidBatchCount = sharedErrors.NewDomainError(ErrCodeInvalidBatchCount, "batch count must be between 10 and 1000").WithKind(sharedErrors.KindInvalid)
+	ErrInvalidFormat     = sharedErrors.NewDomainError(ErrCodeInvalidFormat, "invalid output format").WithKind(sharedErrors.KindInvalid)
+	ErrGenerationFailed  = sharedErrors.NewDomainError(ErrCodeGenerationFailed, "data generation failed")
+	ErrEmptyTemplate     = sharedErrors.NewDomainError(ErrCodeEmptyTemplate, "template cannot be empty").WithKind(sharedErrors.KindInvalid)
 )
@@ -1,13 +1,14 @@
 package datagenerator
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
 
 // DataGeneratorService defines the interface for data generation
 type DataGeneratorService interface {
-	Generate(req GenerateRequest) (*GenerateResponse, error)
+	Generate(ctx context.Context, req GenerateRequest) (*GenerateResponse, error)
 	GetPresets() (*PresetsResponse, error)
 	ValidateTemplate(template string) (*ValidationResult, error)
 }
@@ -26,20 +27,18 @@ func NewDataGeneratorService() DataGeneratorService {
 	}
 }
 
-// Generate generates data based on the request
-func (s *dataGeneratorService) Generate(req GenerateRequest) (*GenerateResponse, error) {
+// Generate generates data based on the request, stopping once ctx is done
+func (s *dataGeneratorService) Generate(ctx context.Context, req GenerateRequest) (*GenerateResponse, error) {
 	// Validate batch count (allow 1 for single mode, otherwise 10-1000)
 	if req.BatchCount < 1 || req.BatchCount > 1000 {
-		return &GenerateResponse{
-			Error: ErrInvalidBatchCount.Error(),
-		}, nil
+		resp := ErrorResponse(ErrInvalidBatchCount)
+		return &resp, nil
 	}
 
 	// Validate template
 	if err := s.engine.Validate(req.Template); err != nil {
-		return &GenerateResponse{
-			Error: err.Error(),
-		}, nil
+		resp := ErrorResponse(err)
+		return &resp, nil
 	}
 
 	// Convert variables to proper types (JSON numbers come as float64)
@@ -64,23 +63,21 @@ func (s *dataGeneratorService) Generate(req GenerateRequest) (*GenerateResponse,
 
 	// Generate data
 	start := time.Now()
-	results, err := s.engine.GenerateBatch(req.Template, req.BatchCount, convertedVars)
+	results, err := s.engine.GenerateBatch(ctx, req.Template, req.BatchCount, convertedVars)
 	duration := time.Since(start).Milliseconds()
 
 	if err != nil {
-		return &GenerateResponse{
-			Error:    err.Error(),
-			Duration: duration,
-		}, nil
+		resp := ErrorResponse(err)
+		resp.Duration = duration
+		return &resp, nil
 	}
 
 	// Format output
 	output, err := s.formatter.Format(results, req.OutputFormat, req.Separator)
 	if err != nil {
-		return &GenerateResponse{
-			Error:    err.Error(),
-			Duration: duration,
-		}, nil
+		resp := ErrorResponse(err)
+		resp.Duration = duration
+		return &resp, nil
 	}
 
 	// Pretty print if JSON and requested
@@ -1,8 +1,11 @@
 package datagenerator
 
 import (
+	"context"
+	"errors"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestEngine_Execute(t *testing.T) {
@@ -365,7 +368,7 @@ func TestEngine_GenerateBatch(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			results, err := engine.GenerateBatch(tt.template, tt.batchCount, tt.variables)
+			results, err := engine.GenerateBatch(context.Background(), tt.template, tt.batchCount, tt.variables)
 
 			if tt.wantErr {
 				if err == nil {
@@ -397,6 +400,28 @@ func TestEngine_GenerateBatch(t *testing.T) {
 	}
 }
 
+func TestEngine_GenerateBatchCancelled(t *testing.T) {
+	engine := NewEngine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := engine.GenerateBatch(ctx, "{{UUID}}", 10, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("GenerateBatch() error = %v, want context.Canceled", err)
+	}
+
+	// A deadline interrupts a single long-running record too
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := engine.GenerateBatch(ctx, "{{range 1000000000}}{{UUID}}{{end}}", 1, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GenerateBatch() error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("GenerateBatch() took %v after the deadline", elapsed)
+	}
+}
+
 func TestDataGeneratorService_Generate(t *testing.T) {
 	service := NewDataGeneratorService()
 
@@ -547,7 +572,7 @@ func TestDataGeneratorService_Generate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			response, err := service.Generate(tt.request)
+			response, err := service.Generate(context.Background(), tt.request)
 
 			if err != nil {
 				t.Errorf("Generate() unexpected error = %v", err)
@@ -1135,7 +1160,7 @@ func TestAllPresetTemplates(t *testing.T) {
 			}
 
 			// Test batch generation (small batch)
-			results, err := engine.GenerateBatch(preset.Template, 5, variables)
+			results, err := engine.GenerateBatch(context.Background(), preset.Template, 5, variables)
 			if err != nil {
 				t.Errorf("Preset %s failed batch generation: %v", preset.ID, err)
 				return
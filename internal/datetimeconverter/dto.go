@@ -15,6 +15,12 @@ type ConvertResponse struct {
 	DetectedType string      `json:"detectedType"` // "timestamp", "iso", "date", "unknown"
 	DetectedPrec string      `json:"detectedPrec"` // "seconds", "millis", "micros", "nanos"
 	Error        string      `json:"error,omitempty"`
+	err          error
+}
+
+// Err returns the typed error behind Error, if any
+func (r ConvertResponse) Err() error {
+	return r.err
 }
 
 // PresetsResponse returns all available presets
@@ -32,6 +38,12 @@ type DeltaRequest struct {
 type DeltaResponse struct {
 	Delta *TimeDelta `json:"delta,omitempty"`
 	Error string     `json:"error,omitempty"`
+	err   error
+}
+
+// Err returns the typed error behind Error, if any
+func (r DeltaResponse) Err() error {
+	return r.err
 }
 
 // ArithmeticRequest represents a date arithmetic request
@@ -46,6 +58,12 @@ type ArithmeticRequest struct {
 type ArithmeticResponse struct {
 	Result *TimeResult `json:"result,omitempty"`
 	Error  string      `json:"error,omitempty"`
+	err    error
+}
+
+// Err returns the typed error behind Error, if any
+func (r ArithmeticResponse) Err() error {
+	return r.err
 }
 
 // BatchRequest represents a batch conversion request
@@ -88,5 +106,14 @@ func FromTimeResult(result *TimeResult, detectedType, detectedPrec string) Conve
 func ErrorResponse(err error) ConvertResponse {
 	return ConvertResponse{
 		Error: err.Error(),
+		err:   err,
+	}
+}
+
+// DeltaErrorResponse creates a DeltaResponse reporting err
+func DeltaErrorResponse(err error) DeltaResponse {
+	return DeltaResponse{
+		Error: err.Error(),
+		err:   err,
 	}
 }
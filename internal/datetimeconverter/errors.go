@@ -1,12 +1,21 @@
 package datetimeconverter
 
-import "fmt"
+import sharedErrors "devtoolbox/pkg/errors"
+
+// Error codes for datetimeconverter package
+const (
+	ErrCodeInvalidTimestamp = "INVALID_TIMESTAMP"
+	ErrCodeInvalidDate      = "INVALID_DATE"
+	ErrCodeInvalidPrecision = "INVALID_PRECISION"
+	ErrCodeInvalidTimezone  = "INVALID_TIMEZONE"
+	ErrCodeInvalidUnit      = "INVALID_UNIT"
+)
 
 // Domain errors for unixtime package
 var (
-	ErrInvalidTimestamp = fmt.Errorf("invalid timestamp")
-	ErrInvalidDate      = fmt.Errorf("invalid date format")
-	ErrInvalidPrecision = fmt.Errorf("invalid precision")
-	ErrInvalidTimezone  = fmt.Errorf("invalid timezone")
-	ErrInvalidUnit      = fmt.Errorf("invalid time unit")
+	ErrInvalidTimestamp = sharedErrors.NewDomainError(ErrCodeInvalidTimestamp, "invalid timestamp").WithKind(sharedErrors.KindInvalid)
+	ErrInvalidDate      = sharedErrors.NewDomainError(ErrCodeInvalidDate, "invalid date format").WithKind(sharedErrors.KindInvalid)
+	ErrInvalidPrecision = sharedErrors.NewDomainError(ErrCodeInvalidPrecision, "invalid precision").WithKind(sharedErrors.KindInvalid)
+	ErrInvalidTimezone  = sharedErrors.NewDomainError(ErrCodeInvalidTimezone, "invalid timezone").WithKind(sharedErrors.KindInvalid)
+	ErrInvalidUnit      = sharedErrors.NewDomainError(ErrCodeInvalidUnit, "invalid time unit").WithKind(sharedErrors.KindInvalid)
 )
@@ -161,12 +161,12 @@ func (s *service) GetPresets() PresetsResponse {
 func (s *service) CalculateDelta(req DeltaRequest) DeltaResponse {
 	dateA, err := parseFlexibleDate(req.DateA)
 	if err != nil {
-		return DeltaResponse{Error: err.Error()}
+		return DeltaErrorResponse(err)
 	}
 
 	dateB, err := parseFlexibleDate(req.DateB)
 	if err != nil {
-		return DeltaResponse{Error: err.Error()}
+		return DeltaErrorResponse(err)
 	}
 
 	diff := dateB.Sub(dateA)
@@ -198,7 +198,7 @@ func (s *service) CalculateDelta(req DeltaRequest) DeltaResponse {
 func (s *service) AddTime(req ArithmeticRequest) ArithmeticResponse {
 	baseDate, err := parseFlexibleDate(req.BaseDate)
 	if err != nil {
-		return ArithmeticResponse{Error: err.Error()}
+		return ArithmeticResponse{Error: err.Error(), err: err}
 	}
 
 	unit := TimeUnit(req.Unit)
@@ -224,7 +224,7 @@ func (s *service) AddTime(req ArithmeticRequest) ArithmeticResponse {
 		baseDate = addYears(baseDate, req.Value)
 		duration = 0
 	default:
-		return ArithmeticResponse{Error: ErrInvalidUnit.Error()}
+		return ArithmeticResponse{Error: ErrInvalidUnit.Error(), err: ErrInvalidUnit}
 	}
 
 	if req.Operation == "subtract" {
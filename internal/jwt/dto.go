@@ -8,6 +8,12 @@ type DecodeResponse struct {
 	Signature string                 `json:"signature"`
 	Valid     bool                   `json:"isValid"`
 	Error     string                 `json:"error"`
+	err       error
+}
+
+// Err returns the typed error behind Error, if any
+func (r DecodeResponse) Err() error {
+	return r.err
 }
 
 // VerifyResponse is the response for JWT verify operation
@@ -16,16 +22,35 @@ type VerifyResponse struct {
 	Valid   bool   `json:"isValid"`
 	Message string `json:"validationMessage"`
 	Error   string `json:"error"`
+	err     error
+}
+
+// Err returns the typed error behind Error, if any
+func (r VerifyResponse) Err() error {
+	return r.err
 }
 
 // EncodeResponse is the response for JWT encode operation
 type EncodeResponse struct {
 	Token string `json:"token"`
 	Error string `json:"error"`
+	err   error
 }
 
-// FromToken converts Token to DecodeResponse
-func FromToken(token *Token) DecodeResponse {
+// Err returns the typed error behind Error, if any
+func (r EncodeResponse) Err() error {
+	return r.err
+}
+
+// FromToken converts Token and decoding error to DecodeResponse
+func FromToken(token *Token, err error) DecodeResponse {
+	if err != nil {
+		return DecodeResponse{
+			Valid: false,
+			Error: err.Error(),
+			err:   err,
+		}
+	}
 	return DecodeResponse{
 		Header:    token.Header,
 		Payload:   token.Payload,
@@ -35,8 +60,16 @@ func FromToken(token *Token) DecodeResponse {
 	}
 }
 
-// FromValidation converts ValidationResult to VerifyResponse
-func FromValidation(result *ValidationResult) VerifyResponse {
+// FromValidation converts ValidationResult and verification error to
+// VerifyResponse
+func FromValidation(result *ValidationResult, err error) VerifyResponse {
+	if err != nil {
+		return VerifyResponse{
+			Valid: false,
+			Error: err.Error(),
+			err:   err,
+		}
+	}
 	return VerifyResponse{
 		Valid:   result.Valid,
 		Message: result.Message,
@@ -50,6 +83,7 @@ func FromEncoded(token string, err error) EncodeResponse {
 		return EncodeResponse{
 			Token: "",
 			Error: err.Error(),
+			err:   err,
 		}
 	}
 	return EncodeResponse{
@@ -4,6 +4,15 @@ import (
 	sharedErrors "devtoolbox/pkg/errors"
 )
 
+// Error codes for jwt package
+const (
+	ErrCodeMalformedToken   = "MALFORMED_TOKEN"
+	ErrCodeInvalidSignature = "INVALID_SIGNATURE"
+	ErrCodeExpiredToken     = "EXPIRED_TOKEN"
+	ErrCodeInvalidAlgorithm = "INVALID_ALGORITHM"
+	ErrCodeInvalidSecret    = sharedErrors.ErrCodeInvalidSecret
+)
+
 // JWTError represents a JWT-specific error
 type JWTError struct {
 	Code    string
@@ -22,6 +31,21 @@ func (e *JWTError) Unwrap() error {
 	return e.Err
 }
 
+// ErrorCode returns the machine-readable error code
+func (e *JWTError) ErrorCode() string {
+	return e.Code
+}
+
+// ErrorKind classifies the error: malformed tokens and unsupported
+// algorithms are invalid input, failed verifications are not
+func (e *JWTError) ErrorKind() sharedErrors.Kind {
+	switch e.Code {
+	case ErrCodeMalformedToken, ErrCodeInvalidAlgorithm:
+		return sharedErrors.KindInvalid
+	}
+	return sharedErrors.KindFailed
+}
+
 // NewJWTError creates a new JWT error
 func NewJWTError(code, message string, err error) *JWTError {
 	return &JWTError{
@@ -33,11 +57,11 @@ func NewJWTError(code, message string, err error) *JWTError {
 
 // Common JWT errors
 var (
-	ErrMalformedToken   = NewJWTError("MALFORMED_TOKEN", "malformed JWT token", nil)
-	ErrInvalidSignature = NewJWTError("INVALID_SIGNATURE", "invalid JWT signature", nil)
-	ErrExpiredToken     = NewJWTError("EXPIRED_TOKEN", "JWT token has expired", nil)
-	ErrInvalidAlgorithm = NewJWTError("INVALID_ALGORITHM", "invalid JWT algorithm", nil)
-	ErrInvalidSecret    = NewJWTError("INVALID_SECRET", "secret cannot be empty", nil)
+	ErrMalformedToken   = NewJWTError(ErrCodeMalformedToken, "malformed JWT token", nil)
+	ErrInvalidSignature = NewJWTError(ErrCodeInvalidSignature, "invalid JWT signature", nil)
+	ErrExpiredToken     = NewJWTError(ErrCodeExpiredToken, "JWT token has expired", nil)
+	ErrInvalidAlgorithm = NewJWTError(ErrCodeInvalidAlgorithm, "invalid JWT algorithm", nil)
+	ErrInvalidSecret    = NewJWTError(ErrCodeInvalidSecret, "secret cannot be empty", nil)
 )
 
 // FromError converts any error to JWT error
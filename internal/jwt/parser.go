@@ -98,22 +98,26 @@ func (p *jwtParser) Parse(token string) (*Token, error) {
 		WithValidity(true), nil
 }
 
-// Verify verifies a JWT signature with the given secret
-func (p *jwtParser) Verify(token, secret, encoding string) (*ValidationResult, error) {
+// Verify verifies a JWT signature with the given secret, which is used as
+// given unless secretEncoding is "base64"
+func (p *jwtParser) Verify(token, secret, secretEncoding string) (*ValidationResult, error) {
 	// Basic validation
 	if !validation.IsValidJWTStructure(token) {
-		return ErrorResult("Invalid JWT structure"), nil
+		return ErrorResult(ErrCodeMalformedToken, "Invalid JWT structure"), nil
 	}
 
 	if !validation.ValidateSecret(secret) {
-		return ErrorResult("Invalid secret"), nil
+		return ErrorResult(ErrCodeInvalidSecret, "Invalid secret"), nil
 	}
 
-	// Handle encoding (UTF-8 vs Base64)
+	// Handle encoding (UTF-8 vs Base64, in either alphabet)
 	secretBytes := []byte(secret)
-	if encoding == "base64" {
-		// TODO: Implement Base64 secret decoding
-		// For now, treat as UTF-8
+	if secretEncoding == "base64" {
+		decoded, err := encoding.DecodeBase64URL(strings.TrimRight(secret, "="))
+		if err != nil {
+			return ErrorResult(ErrCodeInvalidSecret, "Secret is not valid Base64"), nil
+		}
+		secretBytes = decoded
 	}
 
 	// Parse with verification
@@ -34,11 +34,11 @@ func (s *jwtServiceImpl) Decode(token string) (*Token, error) {
 // Verify verifies a JWT signature with the given secret
 func (s *jwtServiceImpl) Verify(token, secret, encoding string) (*ValidationResult, error) {
 	if token == "" {
-		return ErrorResult("Token cannot be empty"), nil
+		return ErrorResult(ErrCodeMalformedToken, "Token cannot be empty"), nil
 	}
 
 	if secret == "" {
-		return ErrorResult("Secret cannot be empty"), nil
+		return ErrorResult(ErrCodeInvalidSecret, "Secret cannot be empty"), nil
 	}
 
 	// Use parser to verify
@@ -57,6 +57,14 @@ func (t *Token) WithError(err string) *Token {
 	return t
 }
 
+// Err returns the decoding error as a MALFORMED_TOKEN JWTError, if any
+func (t *Token) Err() error {
+	if t.Error == "" {
+		return nil
+	}
+	return NewJWTError(ErrCodeMalformedToken, t.Error, nil)
+}
+
 // GetAlgorithm returns the algorithm from header
 func (t *Token) GetAlgorithm() string {
 	if alg, ok := t.Header["alg"].(string); ok {
@@ -128,6 +136,7 @@ type ValidationResult struct {
 	Valid   bool   // Signature validity
 	Message string // Verification status message
 	Error   string // Verification error
+	Code    string // Error code of Error
 }
 
 // NewValidationResult creates a new ValidationResult
@@ -152,12 +161,22 @@ func (v *ValidationResult) WithMessage(message string) *ValidationResult {
 }
 
 // WithError sets error and returns the result
-func (v *ValidationResult) WithError(err string) *ValidationResult {
+func (v *ValidationResult) WithError(code, err string) *ValidationResult {
+	v.Code = code
 	v.Error = err
 	v.Valid = false
 	return v
 }
 
+// Err returns the verification error as a JWTError, if any. A signature
+// that does not match is not an error.
+func (v *ValidationResult) Err() error {
+	if v.Error == "" {
+		return nil
+	}
+	return NewJWTError(v.Code, v.Error, nil)
+}
+
 // Success creates a successful validation result
 func Success(message string) *ValidationResult {
 	return &ValidationResult{
@@ -177,10 +196,11 @@ func Failure(message string) *ValidationResult {
 }
 
 // ErrorResult creates a validation result with error
-func ErrorResult(err string) *ValidationResult {
+func ErrorResult(code, err string) *ValidationResult {
 	return &ValidationResult{
 		Valid:   false,
 		Message: "",
 		Error:   err,
+		Code:    code,
 	}
 }
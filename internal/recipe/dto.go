@@ -0,0 +1,37 @@
+package recipe
+
+import "devtoolbox/pkg/devtoolbox"
+
+// RunRequest represents a request to run a recipe on an input
+type RunRequest struct {
+	Recipe devtoolbox.Recipe `json:"recipe"`
+	Input  string            `json:"input"`
+}
+
+// RunResponse represents the response from running a recipe: the output of
+// every step, and of the last one. A failed run holds the steps up to the
+// one that failed.
+type RunResponse struct {
+	Steps  []devtoolbox.RecipeStepResult `json:"steps"`
+	Output string                        `json:"output"`
+	Error  string                        `json:"error,omitempty"`
+	err    error
+}
+
+// Err returns the typed error behind Error, if any
+func (r RunResponse) Err() error {
+	return r.err
+}
+
+// NewRunResponse reports run, and err if it failed
+func NewRunResponse(run devtoolbox.RecipeRun, err error) RunResponse {
+	resp := RunResponse{Steps: run.Steps, Output: run.Output}
+	if resp.Steps == nil {
+		resp.Steps = []devtoolbox.RecipeStepResult{}
+	}
+	if err != nil {
+		resp.Error = err.Error()
+		resp.err = err
+	}
+	return resp
+}
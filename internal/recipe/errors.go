@@ -0,0 +1,19 @@
+package recipe
+
+import (
+	"devtoolbox/pkg/devtoolbox"
+	sharedErrors "devtoolbox/pkg/errors"
+)
+
+// Error codes for recipe package. Recipes that fail to validate or run
+// report devtoolbox.ErrCodeInvalidRecipe and devtoolbox.ErrCodeStepFailed.
+const (
+	ErrCodeRecipeNotFound = "RECIPE_NOT_FOUND"
+	ErrCodeRecipeExists   = "RECIPE_EXISTS"
+)
+
+var (
+	ErrMissingName    = sharedErrors.NewDomainError(devtoolbox.ErrCodeInvalidRecipe, "recipe name must contain a letter or digit").WithKind(sharedErrors.KindInvalid)
+	ErrRecipeNotFound = sharedErrors.NewDomainError(ErrCodeRecipeNotFound, "recipe not found").WithKind(sharedErrors.KindNotFound)
+	ErrNameTaken      = sharedErrors.NewDomainError(ErrCodeRecipeExists, "another recipe is saved under a name that differs only in punctuation").WithKind(sharedErrors.KindConflict)
+)
@@ -0,0 +1,163 @@
+// Package recipe keeps saved recipes, chains of conversions the devtoolbox
+// package runs, and describes runs to the desktop bindings.
+package recipe
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"devtoolbox/pkg/devtoolbox"
+)
+
+// Store keeps recipes as JSON files in a directory, one per recipe, named
+// after the recipe
+type Store struct {
+	dir string
+	mu  sync.RWMutex
+}
+
+// NewStore creates a store of the recipes in dir, which is created on the
+// first save
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// List returns the saved recipes sorted by name. Files that are not
+// recipes are skipped.
+func (s *Store) List() ([]devtoolbox.Recipe, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []devtoolbox.Recipe{}, nil
+		}
+		return nil, err
+	}
+
+	recipes := []devtoolbox.Recipe{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		r, err := devtoolbox.ParseRecipe(data)
+		if err != nil {
+			continue
+		}
+		recipes = append(recipes, r)
+	}
+	sort.Slice(recipes, func(i, j int) bool {
+		return strings.ToLower(recipes[i].Name) < strings.ToLower(recipes[j].Name)
+	})
+	return recipes, nil
+}
+
+// Get returns the recipe saved under name
+func (s *Store) Get(name string) (devtoolbox.Recipe, error) {
+	path, err := s.path(name)
+	if err != nil {
+		return devtoolbox.Recipe{}, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return devtoolbox.Recipe{}, ErrRecipeNotFound
+		}
+		return devtoolbox.Recipe{}, err
+	}
+	return devtoolbox.ParseRecipe(data)
+}
+
+// Save writes r, replacing any recipe saved under the same name. Names that
+// only differ in case are the same; names that share a file but differ
+// otherwise fail with ErrNameTaken.
+func (s *Store) Save(r devtoolbox.Recipe) error {
+	path, err := s.path(r.Name)
+	if err != nil {
+		return err
+	}
+	data, err := devtoolbox.MarshalRecipe(r)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if data, err := os.ReadFile(path); err == nil {
+		if existing, err := devtoolbox.ParseRecipe(data); err == nil && !strings.EqualFold(existing.Name, r.Name) {
+			return ErrNameTaken.WithDetails(map[string]interface{}{"existing": existing.Name})
+		}
+	}
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return err
+	}
+	// Write to a temporary file first so a failed save keeps the old recipe
+	tmp, err := os.CreateTemp(s.dir, ".recipe-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Delete removes the recipe saved under name
+func (s *Store) Delete(name string) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			return ErrRecipeNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// path is the file of the recipe called name: its letters and digits in
+// lower case, with dashes between words
+func (s *Store) path(name string) (string, error) {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	if b.Len() == 0 {
+		return "", ErrMissingName
+	}
+	return filepath.Join(s.dir, b.String()+".json"), nil
+}
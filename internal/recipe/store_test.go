@@ -0,0 +1,78 @@
+package recipe
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"devtoolbox/pkg/devtoolbox"
+	sharedErrors "devtoolbox/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "recipes")
+	store := NewStore(dir)
+
+	recipes, err := store.List()
+	require.NoError(t, err)
+	assert.Empty(t, recipes)
+
+	unpack := devtoolbox.Recipe{Name: "Unpack Payload", Steps: []devtoolbox.RecipeStep{{Category: "Encode - Decode", Method: "Base64"}}}
+	hash := devtoolbox.Recipe{Name: "checksum", Steps: []devtoolbox.RecipeStep{{Category: "Hash", Method: "SHA-256"}}}
+	require.NoError(t, store.Save(unpack))
+	require.NoError(t, store.Save(hash))
+	assert.FileExists(t, filepath.Join(dir, "unpack-payload.json"))
+
+	// Files that are not recipes are skipped
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.json"), []byte(`{"todo": []}`), 0644))
+
+	recipes, err = store.List()
+	require.NoError(t, err)
+	assert.Equal(t, []devtoolbox.Recipe{hash, unpack}, recipes)
+
+	got, err := store.Get("unpack payload")
+	require.NoError(t, err)
+	assert.Equal(t, unpack, got)
+
+	unpack.Description = "Updated"
+	require.NoError(t, store.Save(unpack))
+	got, err = store.Get(unpack.Name)
+	require.NoError(t, err)
+	assert.Equal(t, "Updated", got.Description)
+
+	require.NoError(t, store.Delete(unpack.Name))
+	_, err = store.Get(unpack.Name)
+	assert.ErrorIs(t, err, ErrRecipeNotFound)
+	assert.ErrorIs(t, store.Delete(unpack.Name), ErrRecipeNotFound)
+}
+
+func TestStore_NameCollision(t *testing.T) {
+	store := NewStore(t.TempDir())
+	steps := []devtoolbox.RecipeStep{{Category: "Encode - Decode", Method: "Base64"}}
+
+	original := devtoolbox.Recipe{Name: "Base64 → JSON", Steps: steps}
+	require.NoError(t, store.Save(original))
+
+	err := store.Save(devtoolbox.Recipe{Name: "base64 json", Steps: steps})
+	assert.ErrorIs(t, err, ErrNameTaken)
+	assert.Equal(t, map[string]interface{}{"existing": original.Name}, sharedErrors.DetailsOf(err))
+
+	got, err := store.Get(original.Name)
+	require.NoError(t, err)
+	assert.Equal(t, original, got)
+
+	// Case alone does not make a different recipe
+	require.NoError(t, store.Save(devtoolbox.Recipe{Name: "BASE64 → JSON", Steps: steps}))
+}
+
+func TestStore_InvalidName(t *testing.T) {
+	store := NewStore(t.TempDir())
+
+	for _, name := range []string{"", "  ", "../"} {
+		err := store.Save(devtoolbox.Recipe{Name: name, Steps: []devtoolbox.RecipeStep{{Category: "Hash", Method: "MD5"}}})
+		assert.Equal(t, devtoolbox.ErrCodeInvalidRecipe, sharedErrors.CodeOf(err), name)
+	}
+}
@@ -0,0 +1,243 @@
+package settings
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// ServerConfigFiles are the config file names looked up in the config
+// directory, in order
+var ServerConfigFiles = []string{"server.yaml", "server.yml", "server.toml"}
+
+// EnvPrefix starts the names of the environment variables that override
+// the config file, e.g. DEVTOOLBOX_PORT
+const EnvPrefix = "DEVTOOLBOX_"
+
+// ServerConfig configures the HTTP server. It is read from a YAML or TOML
+// file, then overridden by DEVTOOLBOX_* environment variables.
+type ServerConfig struct {
+	// Host is the bind address; empty means every interface
+	Host string `yaml:"host" toml:"host"`
+	// Port is the listen port
+	Port int `yaml:"port" toml:"port"`
+	// AllowOrigins is the CORS origin allowlist
+	AllowOrigins []string `yaml:"allow_origins" toml:"allow_origins"`
+	// TLS serves HTTPS with the given files, or with a local certificate
+	TLS TLSFiles `yaml:"tls" toml:"tls"`
+	// Socket is the path of a Unix socket to serve on as well; "default"
+	// picks devtoolbox.sock in the runtime or config directory
+	Socket string `yaml:"socket" toml:"socket"`
+	// Token is the bearer token required on the API; it implies RequireToken
+	Token string `yaml:"token" toml:"token"`
+	// RequireToken requires the per-install token when Token is empty
+	RequireToken bool `yaml:"require_token" toml:"require_token"`
+	// Tools selects the tools the server exposes
+	Tools ToolSelection `yaml:"tools" toml:"tools"`
+	// Limits bounds request bodies and call durations
+	Limits ServerLimits `yaml:"limits" toml:"limits"`
+	// LogLevel is the lowest level logged: debug, info, warn or error
+	LogLevel string `yaml:"log_level" toml:"log_level"`
+}
+
+// TLSFiles are the PEM certificate and key of the HTTPS listener. Auto
+// serves HTTPS with a certificate from the local CA when no files are set.
+type TLSFiles struct {
+	Cert string `yaml:"cert" toml:"cert"`
+	Key  string `yaml:"key" toml:"key"`
+	Auto bool   `yaml:"auto" toml:"auto"`
+}
+
+// ToolSelection lists tool IDs. An empty Enabled list means every tool;
+// Disabled tools are removed from it.
+type ToolSelection struct {
+	Enabled  []string `yaml:"enabled" toml:"enabled"`
+	Disabled []string `yaml:"disabled" toml:"disabled"`
+}
+
+// ServerLimits bounds request bodies and call durations; zero means no limit
+type ServerLimits struct {
+	MaxBodyBytes   int64               `yaml:"max_body_bytes" toml:"max_body_bytes"`
+	MaxUploadBytes int64               `yaml:"max_upload_bytes" toml:"max_upload_bytes"`
+	Timeout        Duration            `yaml:"timeout" toml:"timeout"`
+	RouteTimeouts  map[string]Duration `yaml:"route_timeouts" toml:"route_timeouts"`
+}
+
+// Duration is a time.Duration written as a string such as "90s" or "2m"
+type Duration time.Duration
+
+// UnmarshalText parses a duration string
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// MarshalText formats the duration as a string
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// FindServerConfig returns the first of ServerConfigFiles in configDir, or
+// "" when there is none
+func FindServerConfig(configDir string) string {
+	for _, name := range ServerConfigFiles {
+		path := filepath.Join(configDir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// LoadServerConfig reads the YAML or TOML file at path, by its extension,
+// over cfg. Keys missing from the file keep their value in cfg; unknown keys
+// are an error.
+func LoadServerConfig(path string, cfg *ServerConfig) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		// An empty file is an empty config
+		if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	case ".toml":
+		dec := toml.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(cfg); err != nil {
+			var strict *toml.StrictMissingError
+			if errors.As(err, &strict) {
+				var keys []string
+				for _, e := range strict.Errors {
+					keys = append(keys, strings.Join(e.Key(), "."))
+				}
+				return fmt.Errorf("%s: unknown keys %s", path, strings.Join(keys, ", "))
+			}
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	default:
+		return fmt.Errorf("%s: unsupported config format %q, want .yaml, .yml or .toml", path, ext)
+	}
+	return nil
+}
+
+// ApplyEnv overrides cfg with the DEVTOOLBOX_* variables lookup finds, such
+// as os.LookupEnv. Lists are comma-separated.
+func (cfg *ServerConfig) ApplyEnv(lookup func(string) (string, bool)) error {
+	var errs []error
+	env := func(name string, apply func(string) error) {
+		if value, ok := lookup(EnvPrefix + name); ok {
+			if err := apply(strings.TrimSpace(value)); err != nil {
+				errs = append(errs, fmt.Errorf("%s%s: %w", EnvPrefix, name, err))
+			}
+		}
+	}
+
+	env("HOST", setString(&cfg.Host))
+	env("PORT", func(v string) error {
+		port, err := strconv.Atoi(v)
+		cfg.Port = port
+		return err
+	})
+	env("ALLOW_ORIGINS", setList(&cfg.AllowOrigins))
+	env("TLS_CERT", setString(&cfg.TLS.Cert))
+	env("TLS_KEY", setString(&cfg.TLS.Key))
+	env("TLS", setBool(&cfg.TLS.Auto))
+	env("SOCKET", setString(&cfg.Socket))
+	env("TOKEN", setString(&cfg.Token))
+	env("REQUIRE_TOKEN", setBool(&cfg.RequireToken))
+	env("ENABLED_TOOLS", setList(&cfg.Tools.Enabled))
+	env("DISABLED_TOOLS", setList(&cfg.Tools.Disabled))
+	env("MAX_BODY_BYTES", setInt64(&cfg.Limits.MaxBodyBytes))
+	env("MAX_UPLOAD_BYTES", setInt64(&cfg.Limits.MaxUploadBytes))
+	env("TIMEOUT", func(v string) error { return cfg.Limits.Timeout.UnmarshalText([]byte(v)) })
+	env("ROUTE_TIMEOUTS", func(v string) error {
+		if cfg.Limits.RouteTimeouts == nil {
+			cfg.Limits.RouteTimeouts = map[string]Duration{}
+		}
+		return ParseRouteTimeouts(v, cfg.Limits.RouteTimeouts)
+	})
+	env("LOG_LEVEL", setString(&cfg.LogLevel))
+
+	return errors.Join(errs...)
+}
+
+// ParseRouteTimeouts adds "Service.Method=duration" pairs from a
+// comma-separated list to timeouts
+func ParseRouteTimeouts(list string, timeouts map[string]Duration) error {
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok || !strings.Contains(name, ".") {
+			return fmt.Errorf("invalid route timeout %q: want Service.Method=duration", entry)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("invalid route timeout %q: %w", entry, err)
+		}
+		timeouts[strings.TrimSpace(name)] = Duration(d)
+	}
+	return nil
+}
+
+// SplitList splits a comma-separated list, dropping empty entries
+func SplitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func setString(dst *string) func(string) error {
+	return func(v string) error {
+		*dst = v
+		return nil
+	}
+}
+
+func setList(dst *[]string) func(string) error {
+	return func(v string) error {
+		*dst = SplitList(v)
+		return nil
+	}
+}
+
+func setBool(dst *bool) func(string) error {
+	return func(v string) error {
+		b, err := strconv.ParseBool(v)
+		*dst = b
+		return err
+	}
+}
+
+func setInt64(dst *int64) func(string) error {
+	return func(v string) error {
+		n, err := strconv.ParseInt(v, 10, 64)
+		*dst = n
+		return err
+	}
+}
@@ -0,0 +1,179 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoadServerConfig(t *testing.T) {
+	want := ServerConfig{
+		Host:         "0.0.0.0",
+		Port:         9000,
+		AllowOrigins: []string{"https://tools.example.com"},
+		TLS:          TLSFiles{Cert: "/etc/devtoolbox/cert.pem", Key: "/etc/devtoolbox/key.pem"},
+		Socket:       "default",
+		RequireToken: true,
+		Tools:        ToolSelection{Disabled: []string{"barcode"}},
+		Limits: ServerLimits{
+			MaxBodyBytes:   8 << 20,
+			MaxUploadBytes: 0,
+			Timeout:        Duration(time.Minute),
+			RouteTimeouts:  map[string]Duration{"DataGeneratorService.Generate": Duration(2 * time.Minute)},
+		},
+		LogLevel: "warn",
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{"yaml", "server.yaml", `
+host: 0.0.0.0
+port: 9000
+allow_origins: [https://tools.example.com]
+tls:
+  cert: /etc/devtoolbox/cert.pem
+  key: /etc/devtoolbox/key.pem
+socket: default
+require_token: true
+tools:
+  disabled: [barcode]
+limits:
+  max_upload_bytes: 0
+  timeout: 1m
+  route_timeouts:
+    DataGeneratorService.Generate: 2m
+log_level: warn
+`},
+		{"toml", "server.toml", `
+host = "0.0.0.0"
+port = 9000
+allow_origins = ["https://tools.example.com"]
+socket = "default"
+require_token = true
+log_level = "warn"
+
+[tls]
+cert = "/etc/devtoolbox/cert.pem"
+key = "/etc/devtoolbox/key.pem"
+
+[tools]
+disabled = ["barcode"]
+
+[limits]
+max_upload_bytes = 0
+timeout = "1m"
+
+[limits.route_timeouts]
+"DataGeneratorService.Generate" = "2m"
+`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Keys missing from the file keep their defaults
+			cfg := ServerConfig{Limits: ServerLimits{MaxBodyBytes: 8 << 20, MaxUploadBytes: 1 << 30}}
+			require.NoError(t, LoadServerConfig(writeConfig(t, tt.file, tt.content), &cfg))
+			assert.Equal(t, want, cfg)
+		})
+	}
+}
+
+func TestLoadServerConfig_Errors(t *testing.T) {
+	var cfg ServerConfig
+	assert.ErrorContains(t, LoadServerConfig(writeConfig(t, "server.yaml", "prot: 9000\n"), &cfg), "prot")
+	assert.ErrorContains(t, LoadServerConfig(writeConfig(t, "server.toml", "prot = 9000\n"), &cfg), "prot")
+	assert.ErrorContains(t, LoadServerConfig(writeConfig(t, "server.yaml", "limits:\n  timeout: soon\n"), &cfg), "soon")
+	assert.ErrorContains(t, LoadServerConfig(writeConfig(t, "server.json", "{}"), &cfg), "unsupported")
+	assert.Error(t, LoadServerConfig(filepath.Join(t.TempDir(), "missing.yaml"), &cfg))
+
+	assert.NoError(t, LoadServerConfig(writeConfig(t, "server.yaml", ""), &cfg), "an empty file is allowed")
+}
+
+func TestFindServerConfig(t *testing.T) {
+	dir := t.TempDir()
+	assert.Empty(t, FindServerConfig(dir))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "server.toml"), nil, 0600))
+	assert.Equal(t, filepath.Join(dir, "server.toml"), FindServerConfig(dir))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "server.yaml"), nil, 0600))
+	assert.Equal(t, filepath.Join(dir, "server.yaml"), FindServerConfig(dir), "YAML is looked up first")
+}
+
+func TestServerConfig_ApplyEnv(t *testing.T) {
+	env := map[string]string{
+		"DEVTOOLBOX_HOST":           "",
+		"DEVTOOLBOX_PORT":           "9090",
+		"DEVTOOLBOX_ALLOW_ORIGINS":  "https://a.example.com, https://b.example.com",
+		"DEVTOOLBOX_TLS_CERT":       "cert.pem",
+		"DEVTOOLBOX_TLS_KEY":        "key.pem",
+		"DEVTOOLBOX_TLS":            "1",
+		"DEVTOOLBOX_SOCKET":         "/run/user/1000/devtoolbox.sock",
+		"DEVTOOLBOX_TOKEN":          "secret",
+		"DEVTOOLBOX_REQUIRE_TOKEN":  "true",
+		"DEVTOOLBOX_ENABLED_TOOLS":  "jwt,hash-generator",
+		"DEVTOOLBOX_DISABLED_TOOLS": "hash-generator",
+		"DEVTOOLBOX_MAX_BODY_BYTES": "1024",
+		"DEVTOOLBOX_TIMEOUT":        "5s",
+		"DEVTOOLBOX_ROUTE_TIMEOUTS": "HashGeneratorService.HashFile=0s",
+		"DEVTOOLBOX_LOG_LEVEL":      "debug",
+		"OTHER_PORT":                "1",
+	}
+	lookup := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+
+	cfg := ServerConfig{Host: "127.0.0.1", Port: 8081, Limits: ServerLimits{MaxUploadBytes: 1 << 30}}
+	require.NoError(t, cfg.ApplyEnv(lookup))
+
+	assert.Equal(t, ServerConfig{
+		Host:         "",
+		Port:         9090,
+		AllowOrigins: []string{"https://a.example.com", "https://b.example.com"},
+		TLS:          TLSFiles{Cert: "cert.pem", Key: "key.pem", Auto: true},
+		Socket:       "/run/user/1000/devtoolbox.sock",
+		Token:        "secret",
+		RequireToken: true,
+		Tools:        ToolSelection{Enabled: []string{"jwt", "hash-generator"}, Disabled: []string{"hash-generator"}},
+		Limits: ServerLimits{
+			MaxBodyBytes:   1024,
+			MaxUploadBytes: 1 << 30,
+			Timeout:        Duration(5 * time.Second),
+			RouteTimeouts:  map[string]Duration{"HashGeneratorService.HashFile": 0},
+		},
+		LogLevel: "debug",
+	}, cfg)
+}
+
+func TestServerConfig_ApplyEnvErrors(t *testing.T) {
+	env := map[string]string{
+		"DEVTOOLBOX_PORT":           "http",
+		"DEVTOOLBOX_TIMEOUT":        "soon",
+		"DEVTOOLBOX_TLS":            "maybe",
+		"DEVTOOLBOX_ROUTE_TIMEOUTS": "Generate=1m",
+	}
+	var cfg ServerConfig
+	err := cfg.ApplyEnv(func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	})
+	require.Error(t, err)
+	for name := range env {
+		assert.ErrorContains(t, err, name)
+	}
+}
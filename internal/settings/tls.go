@@ -0,0 +1,230 @@
+package settings
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// TLSDir is the config subdirectory holding the local CA and the server
+// certificate it issues
+const TLSDir = "tls"
+
+// Files in TLSDir. Keys are readable by the owner only.
+const (
+	caCertFile   = "ca.pem"
+	caKeyFile    = "ca-key.pem"
+	leafCertFile = "server.pem"
+	leafKeyFile  = "server-key.pem"
+)
+
+const (
+	caValidity   = 10 * 365 * 24 * time.Hour
+	leafValidity = 397 * 24 * time.Hour
+	// leafRenewal reissues a server certificate this close to expiry
+	leafRenewal = 30 * 24 * time.Hour
+)
+
+// LocalCertificate is a server certificate issued by the per-install CA
+type LocalCertificate struct {
+	// CertFile and KeyFile are the server certificate and key, in PEM
+	CertFile string
+	KeyFile  string
+	// CAFile is the CA certificate to trust once in the browser or OS
+	CAFile string
+}
+
+// LoadOrCreateLocalCertificate returns a server certificate for hosts, DNS
+// names or IP addresses, issued by a CA kept in configDir. Both are created
+// on first use and reused across restarts. The CA stays the same so it only
+// needs trusting once; the server certificate is reissued when it nears
+// expiry or does not cover every host.
+func LoadOrCreateLocalCertificate(configDir string, hosts []string) (LocalCertificate, error) {
+	dir := filepath.Join(configDir, TLSDir)
+	files := LocalCertificate{
+		CertFile: filepath.Join(dir, leafCertFile),
+		KeyFile:  filepath.Join(dir, leafKeyFile),
+		CAFile:   filepath.Join(dir, caCertFile),
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return files, err
+	}
+
+	ca, caKey, err := loadOrCreateCA(dir)
+	if err != nil {
+		return files, fmt.Errorf("local CA: %w", err)
+	}
+
+	if leaf, err := loadCertificate(files.CertFile); err == nil && leafUsable(leaf, ca, hosts) {
+		if _, err := os.Stat(files.KeyFile); err == nil {
+			return files, nil
+		}
+	}
+
+	if err := issueLeaf(files, ca, caKey, hosts); err != nil {
+		return files, fmt.Errorf("server certificate: %w", err)
+	}
+	return files, nil
+}
+
+// loadOrCreateCA loads the CA from dir, creating one when it is missing or
+// expired
+func loadOrCreateCA(dir string) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	certPath := filepath.Join(dir, caCertFile)
+	keyPath := filepath.Join(dir, caKeyFile)
+
+	ca, err := loadCertificate(certPath)
+	if err == nil && ca.IsCA && time.Now().Before(ca.NotAfter) {
+		key, err := loadKey(keyPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		return ca, key, nil
+	}
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	hostname, _ := os.Hostname()
+	template := &x509.Certificate{
+		Subject: pkix.Name{
+			Organization: []string{"DevToolbox"},
+			CommonName:   "DevToolbox Local CA " + hostname,
+		},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := createCertificate(template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := writeKey(keyPath, key); err != nil {
+		return nil, nil, err
+	}
+	if err := writePEM(certPath, "CERTIFICATE", der, 0644); err != nil {
+		return nil, nil, err
+	}
+	ca, err = x509.ParseCertificate(der)
+	return ca, key, err
+}
+
+// leafUsable reports whether leaf was issued by ca, is not near expiry and
+// covers every host
+func leafUsable(leaf, ca *x509.Certificate, hosts []string) bool {
+	if leaf.CheckSignatureFrom(ca) != nil || time.Until(leaf.NotAfter) < leafRenewal {
+		return false
+	}
+	for _, host := range hosts {
+		if leaf.VerifyHostname(host) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// issueLeaf writes a server certificate for hosts signed by ca
+func issueLeaf(files LocalCertificate, ca *x509.Certificate, caKey *ecdsa.PrivateKey, hosts []string) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	template := &x509.Certificate{
+		Subject: pkix.Name{
+			Organization: []string{"DevToolbox"},
+			CommonName:   "DevToolbox",
+		},
+		NotBefore:   time.Now().Add(-time.Hour),
+		NotAfter:    time.Now().Add(leafValidity),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else if host != "" {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := createCertificate(template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		return err
+	}
+	if err := writeKey(files.KeyFile, key); err != nil {
+		return err
+	}
+	return writePEM(files.CertFile, "CERTIFICATE", der, 0644)
+}
+
+// createCertificate signs template with a random serial number
+func createCertificate(template, parent *x509.Certificate, pub *ecdsa.PublicKey, signer *ecdsa.PrivateKey) ([]byte, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	template.SerialNumber = serial
+	return x509.CreateCertificate(rand.Reader, template, parent, pub, signer)
+}
+
+func loadCertificate(path string) (*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("%s: no PEM certificate", path)
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func loadKey(path string) (*ecdsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM key", path)
+	}
+	key, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return key, nil
+}
+
+func writeKey(path string, key *ecdsa.PrivateKey) error {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	return writePEM(path, "EC PRIVATE KEY", der, 0600)
+}
+
+func writePEM(path, blockType string, der []byte, perm os.FileMode) error {
+	var buf bytes.Buffer
+	if err := pem.Encode(&buf, &pem.Block{Type: blockType, Bytes: der}); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), perm)
+}
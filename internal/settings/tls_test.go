@@ -0,0 +1,68 @@
+package settings
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadOrCreateLocalCertificate(t *testing.T) {
+	dir := t.TempDir()
+	hosts := []string{"localhost", "127.0.0.1", "::1"}
+
+	files, err := LoadOrCreateLocalCertificate(dir, hosts)
+	require.NoError(t, err)
+
+	ca, err := loadCertificate(files.CAFile)
+	require.NoError(t, err)
+	assert.True(t, ca.IsCA)
+
+	pair, err := tls.LoadX509KeyPair(files.CertFile, files.KeyFile)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	for _, host := range hosts {
+		_, err := leaf.Verify(x509.VerifyOptions{DNSName: host, Roots: roots})
+		assert.NoError(t, err, host)
+	}
+
+	for _, path := range []string{files.KeyFile, filepath.Join(dir, TLSDir, caKeyFile)} {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), path)
+	}
+}
+
+func TestLoadOrCreateLocalCertificate_Reuse(t *testing.T) {
+	dir := t.TempDir()
+
+	files, err := LoadOrCreateLocalCertificate(dir, []string{"localhost"})
+	require.NoError(t, err)
+	caPEM, _ := os.ReadFile(files.CAFile)
+	leafPEM, _ := os.ReadFile(files.CertFile)
+
+	_, err = LoadOrCreateLocalCertificate(dir, []string{"localhost"})
+	require.NoError(t, err)
+	again, _ := os.ReadFile(files.CertFile)
+	assert.Equal(t, leafPEM, again, "a covering certificate is reused")
+
+	// A new host reissues the server certificate from the same CA
+	_, err = LoadOrCreateLocalCertificate(dir, []string{"localhost", "devbox.lan"})
+	require.NoError(t, err)
+	reissued, _ := os.ReadFile(files.CertFile)
+	assert.NotEqual(t, leafPEM, reissued)
+	sameCA, _ := os.ReadFile(files.CAFile)
+	assert.Equal(t, caPEM, sameCA)
+
+	leaf, err := loadCertificate(files.CertFile)
+	require.NoError(t, err)
+	assert.NoError(t, leaf.VerifyHostname("devbox.lan"))
+}
@@ -0,0 +1,41 @@
+package settings
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// APITokenFile is the name of the file holding the HTTP API token
+const APITokenFile = "api-token"
+
+// LoadOrCreateAPIToken returns the per-install HTTP API token, generating and
+// saving one in configDir on first use. The file is readable by the owner only.
+func LoadOrCreateAPIToken(configDir string) (string, error) {
+	path := filepath.Join(configDir, APITokenFile)
+
+	data, err := os.ReadFile(path)
+	if err == nil {
+		if token := strings.TrimSpace(string(data)); token != "" {
+			return token, nil
+		}
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(buf)
+
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(token+"\n"), 0600); err != nil {
+		return "", err
+	}
+	return token, nil
+}
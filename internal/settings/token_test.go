@@ -0,0 +1,37 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadOrCreateAPIToken(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "devtoolbox")
+
+	token, err := LoadOrCreateAPIToken(dir)
+	require.NoError(t, err)
+	assert.Len(t, token, 64)
+
+	info, err := os.Stat(filepath.Join(dir, APITokenFile))
+	require.NoError(t, err)
+	if os.PathSeparator == '/' {
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+
+	again, err := LoadOrCreateAPIToken(dir)
+	require.NoError(t, err)
+	assert.Equal(t, token, again, "token should persist across loads")
+}
+
+func TestLoadOrCreateAPIToken_ExistingFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, APITokenFile), []byte("my-token\n"), 0600))
+
+	token, err := LoadOrCreateAPIToken(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "my-token", token)
+}
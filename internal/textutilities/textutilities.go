@@ -0,0 +1,197 @@
+// Package textutilities implements the line and case operations of the
+// Text Utilities tool
+package textutilities
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// SortLines sorts the lines of input, in descending order when reverse is set
+func SortLines(input string, reverse bool) string {
+	if input == "" {
+		return ""
+	}
+	lines := strings.Split(input, "\n")
+	sort.Strings(lines)
+	if reverse {
+		for i, j := 0, len(lines)-1; i < j; i, j = i+1, j-1 {
+			lines[i], lines[j] = lines[j], lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// RemoveDuplicates keeps the first of each set of identical lines
+func RemoveDuplicates(input string) string {
+	if input == "" {
+		return ""
+	}
+	lines := strings.Split(input, "\n")
+	seen := make(map[string]bool)
+	result := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if !seen[line] {
+			seen[line] = true
+			result = append(result, line)
+		}
+	}
+	return strings.Join(result, "\n")
+}
+
+// TrimLines removes leading and trailing whitespace from every line
+func TrimLines(input string) string {
+	if input == "" {
+		return ""
+	}
+	lines := strings.Split(input, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// RemoveEmptyLines removes lines that are empty or only whitespace
+func RemoveEmptyLines(input string) string {
+	if input == "" {
+		return ""
+	}
+	lines := strings.Split(input, "\n")
+	result := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			result = append(result, line)
+		}
+	}
+	return strings.Join(result, "\n")
+}
+
+// ConvertCase converts input to targetCase: upper, lower, camel, pascal,
+// snake, kebab or sentence. Other cases leave it unchanged.
+func ConvertCase(input, targetCase string) string {
+	switch targetCase {
+	case "upper":
+		return strings.ToUpper(input)
+	case "lower":
+		return strings.ToLower(input)
+	case "camel":
+		return toCamelCase(input)
+	case "pascal":
+		return toPascalCase(input)
+	case "snake":
+		return toSnakeCase(input)
+	case "kebab":
+		return toKebabCase(input)
+	case "sentence":
+		return toSentenceCase(input)
+	default:
+		return input
+	}
+}
+
+// Stats are the counts GetStats reports
+type Stats struct {
+	Chars     int `json:"chars"`
+	Words     int `json:"words"`
+	Lines     int `json:"lines"`
+	Bytes     int `json:"bytes"`
+	Sentences int `json:"sentences"`
+}
+
+// GetStats counts the characters, words, lines, bytes and sentences of input
+func GetStats(input string) Stats {
+	stats := Stats{Chars: len(input), Bytes: len([]byte(input))}
+	if strings.TrimSpace(input) != "" {
+		stats.Words = len(strings.Fields(input))
+		stats.Sentences = countSentences(input)
+	}
+	if input != "" {
+		stats.Lines = len(strings.Split(input, "\n"))
+	}
+	return stats
+}
+
+func toCamelCase(input string) string {
+	words := strings.Fields(input)
+	for i, word := range words {
+		word = strings.ToLower(word)
+		if i > 0 {
+			word = titleCase(word)
+		}
+		words[i] = word
+	}
+	return strings.Join(words, "")
+}
+
+func toPascalCase(input string) string {
+	words := strings.Fields(input)
+	for i, word := range words {
+		words[i] = titleCase(strings.ToLower(word))
+	}
+	return strings.Join(words, "")
+}
+
+func toSnakeCase(input string) string {
+	var result strings.Builder
+	for i, r := range input {
+		if unicode.IsUpper(r) && i > 0 {
+			result.WriteRune('_')
+		}
+		if r == ' ' || r == '-' {
+			result.WriteRune('_')
+		} else {
+			result.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return result.String()
+}
+
+func toKebabCase(input string) string {
+	var result strings.Builder
+	for i, r := range input {
+		if unicode.IsUpper(r) && i > 0 {
+			result.WriteRune('-')
+		}
+		if r == ' ' || r == '_' {
+			result.WriteRune('-')
+		} else {
+			result.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return result.String()
+}
+
+func toSentenceCase(input string) string {
+	if input == "" {
+		return ""
+	}
+	r := []rune(input)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+func titleCase(s string) string {
+	if s == "" {
+		return ""
+	}
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	for i := 1; i < len(r); i++ {
+		r[i] = unicode.ToLower(r[i])
+	}
+	return string(r)
+}
+
+func countSentences(input string) int {
+	count := 0
+	parts := strings.FieldsFunc(input, func(r rune) bool {
+		return r == '.' || r == '!' || r == '?'
+	})
+	for _, part := range parts {
+		if strings.TrimSpace(part) != "" {
+			count++
+		}
+	}
+	return count
+}
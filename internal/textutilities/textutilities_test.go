@@ -0,0 +1,50 @@
+package textutilities
+
+import "testing"
+
+func TestConvertCase(t *testing.T) {
+	tests := []struct {
+		targetCase string
+		input      string
+		want       string
+	}{
+		{"upper", "Hello World", "HELLO WORLD"},
+		{"camel", "hello big world", "helloBigWorld"},
+		{"pascal", "hello world", "HelloWorld"},
+		{"snake", "helloWorld now", "hello_world_now"},
+		{"kebab", "helloWorld now", "hello-world-now"},
+		{"sentence", "hello world", "Hello world"},
+		{"unknown", "Hello", "Hello"},
+	}
+	for _, tt := range tests {
+		if got := ConvertCase(tt.input, tt.targetCase); got != tt.want {
+			t.Errorf("ConvertCase(%q, %q) = %q, want %q", tt.input, tt.targetCase, got, tt.want)
+		}
+	}
+}
+
+func TestGetStats(t *testing.T) {
+	got := GetStats("One two. Three!\nFour?")
+	want := Stats{Chars: 21, Words: 4, Lines: 2, Bytes: 21, Sentences: 3}
+	if got != want {
+		t.Fatalf("GetStats = %+v, want %+v", got, want)
+	}
+	if empty := GetStats(""); empty != (Stats{}) {
+		t.Fatalf("GetStats(\"\") = %+v, want zero", empty)
+	}
+}
+
+func TestLineOperations(t *testing.T) {
+	if got := SortLines("b\na\nc", true); got != "c\nb\na" {
+		t.Errorf("SortLines reverse = %q", got)
+	}
+	if got := RemoveDuplicates("a\nb\na"); got != "a\nb" {
+		t.Errorf("RemoveDuplicates = %q", got)
+	}
+	if got := TrimLines("  a \n\tb"); got != "a\nb" {
+		t.Errorf("TrimLines = %q", got)
+	}
+	if got := RemoveEmptyLines("a\n \n\nb"); got != "a\nb" {
+		t.Errorf("RemoveEmptyLines = %q", got)
+	}
+}
@@ -2,16 +2,25 @@
 package main
 
 import (
+	"context"
+	"devtoolbox/internal/cli"
 	"devtoolbox/internal/settings"
+	appevents "devtoolbox/pkg/events"
+	"devtoolbox/pkg/instance"
 	"devtoolbox/service"
 	"embed"
+	"errors"
 	"flag"
+	"fmt"
+	"io/fs"
 	"log"
 	"net/http"
 	"os"
-	"path/filepath"
+	"os/signal"
 	"runtime"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -24,14 +33,86 @@ import (
 var assets embed.FS
 
 func main() {
+	// "devtoolbox <tool> <op>" runs one operation and exits
+	if len(os.Args) > 1 && cli.IsCommand(os.Args[1]) {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		code := cli.Run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+		stop()
+		os.Exit(code)
+	}
+
+	// "devtoolbox open <tool>" starts the app on a tool, or hands it to the
+	// running one
+	args := os.Args[1:]
+	var open instance.Request
+	if len(args) > 0 && args[0] == "open" {
+		var err error
+		if open, err = parseOpenArgs(args[1:], os.Stdin); err != nil {
+			fmt.Fprintln(os.Stderr, "devtoolbox open:", err)
+			os.Exit(2)
+		}
+		args = nil
+	}
+
 	serverOnly := flag.Bool("server-only", false, "Run in server-only mode (no GUI)")
-	port := flag.Int("port", 8081, "HTTP server port")
-	flag.Parse()
+	mcp := flag.Bool("mcp", false, "Serve the tools over the Model Context Protocol on stdin/stdout (no GUI)")
+	serverOpts := registerServerFlags()
+	flag.CommandLine.Parse(args)
+
+	serverSettings, err := loadServerConfig(serverOpts)
+	if err != nil {
+		log.Fatal(err)
+	}
+	serverConfig, err := httpServerConfig(serverSettings)
+	if err != nil {
+		log.Fatal(err)
+	}
+	port := serverSettings.Port
+
+	// Every tool's service. The desktop app binds them all; the HTTP server
+	// and MCP serve the ones the server config enables.
+	registry := service.NewToolRegistry(nil)
+	served, err := enabledTools(registry, serverSettings)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *mcp {
+		if err := ServeMCP(serverConfig, served); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 
 	if *serverOnly {
-		log.Printf("Starting server-only mode on port %d...", *port)
-		StartHTTPServer(*port)
+		// Shared deployments need to see usage; the desktop app stays quiet
+		serverConfig.AccessLog, err = accessLogger(serverSettings.LogLevel)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Starting server-only mode on port %d...", port)
+		frontend, err := fs.Sub(assets, "frontend/dist")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		// SIGTERM and Ctrl+C drain in-flight requests before exiting
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		StartHTTPServer(ctx, serverConfig, port, served, appevents.NewBus(), frontend)
+		return
+	}
+
+	// One app runs at a time; later launches hand over to it and exit
+	// instead of opening another window and fighting over the port
+	lock, err := instance.Acquire(instanceSocketPath())
+	if errors.Is(err, instance.ErrRunning) {
+		if err := instance.Send(instanceSocketPath(), open); err != nil {
+			log.Fatal(err)
+		}
 		return
+	} else if err != nil {
+		log.Printf("Single-instance lock unavailable, continuing without it: %v", err)
 	}
 
 	ginEngine := gin.New()
@@ -52,60 +133,49 @@ func main() {
 	})
 
 	// Initialize settings manager
-	var configDir string
-	if runtime.GOOS == "darwin" {
-		configDir = filepath.Join(os.Getenv("HOME"), "Library", "Application Support", "DevToolbox")
-	} else if runtime.GOOS == "windows" {
-		configDir = filepath.Join(os.Getenv("APPDATA"), "DevToolbox")
-	} else {
-		configDir = filepath.Join(os.Getenv("HOME"), ".config", "devtoolbox")
-	}
-
-	settingsManager := settings.NewManager(configDir)
+	settingsManager := settings.NewManager(configDir())
 	if err := settingsManager.Load(); err != nil {
 		log.Printf("Failed to load settings: %v", err)
 	}
 
-	settingsService := service.NewSettingsService(nil, settingsManager)
-	spotlightService := service.NewSpotlightService(nil)
+	// Backend events reach the webviews through Wails and browsers through /api/events
+	eventBus := appevents.NewBus()
+
+	settingsService := service.NewSettingsService(nil, settingsManager, eventBus)
+	spotlightService := service.NewSpotlightService(nil, eventBus)
 	windowControls := service.NewWindowControls(nil)
 
 	// Create application with options
 	app := application.New(application.Options{
 		Name:        "DevToolbox",
 		Description: "Set of tools for daily development",
-		Services: []application.Service{
+		Services: append(service.BindTools(registry),
 			application.NewService(&GreetService{}),
-			application.NewService(service.NewJWTService(nil)),
-			application.NewService(service.NewDateTimeService(nil)),
-			application.NewService(service.NewEncrypterService(nil)),
-			application.NewService(service.NewEncoderService(nil)),
-			application.NewService(service.NewHashGeneratorService(nil)),
-			application.NewService(service.NewCodeConverterService(nil)),
-			application.NewService(service.NewTextUtilitiesService(nil)),
-			application.NewService(service.NewBarcodeService(nil)),
-			application.NewService(service.NewDataGeneratorService(nil)),
-			application.NewService(service.NewCodeFormatterService(nil)),
-			application.NewService(service.NewNumberConverterService(nil)),
 			application.NewService(settingsService),
 			application.NewService(spotlightService),
 			application.NewService(windowControls),
-		},
+			application.NewService(service.NewToolCatalog(registry)),
+		),
 		Mac: application.MacOptions{
 			ApplicationShouldTerminateAfterLastWindowClosed: false,
 		},
 		Assets: application.AssetOptions{
 			// Handler:    ginEngine,
 			// Middleware: GinMiddleware(ginEngine),
-			Handler: application.AssetFileServerFS(assets),
+			Handler:    application.AssetFileServerFS(assets),
+			Middleware: WebviewAPIMiddleware(newWebviewAPIConfig(serverConfig, port)),
 		},
 	})
 
 	settingsService.SetApp(app)
 
+	eventBus.AddSink(func(e appevents.Event) {
+		app.Event.Emit(e.Name, e.Data)
+	})
+
 	// Start HTTP server for browser support (background)
 	go func() {
-		StartHTTPServer(*port)
+		StartHTTPServer(context.Background(), serverConfig, port, served, eventBus, nil)
 	}()
 
 	// Create main window
@@ -255,6 +325,52 @@ func main() {
 		app.Quit()
 	})
 
+	// Open the tool a launch asked for, bringing the main window forward
+	openTool := func(req instance.Request) error {
+		if req.Tool != "" {
+			if _, ok := registry.Get(req.Tool); !ok {
+				return fmt.Errorf("unknown tool %q", req.Tool)
+			}
+		}
+		if !mainWindow.IsVisible() {
+			mainWindow.Show()
+		}
+		if mainWindow.IsMinimised() {
+			mainWindow.Restore()
+		}
+		mainWindow.Focus()
+		if req.Tool != "" {
+			mainWindow.EmitEvent("navigate:to", map[string]string{
+				"path":  "/tool/" + req.Tool,
+				"input": req.Input,
+			})
+		}
+		return nil
+	}
+
+	// The frontend announces it is listening, so this launch's own tool can
+	// be opened without the event getting lost during startup
+	var openInitial sync.Once
+	app.Event.On("app:ready", func(_ *application.CustomEvent) {
+		openInitial.Do(func() {
+			if open.Tool == "" {
+				return
+			}
+			if err := openTool(open); err != nil {
+				log.Printf("Open %s: %v", open.Tool, err)
+			}
+		})
+	})
+
+	if lock != nil {
+		defer lock.Close()
+		go func() {
+			if err := lock.Serve(openTool); err != nil {
+				log.Printf("Single-instance hand-off stopped: %v", err)
+			}
+		}()
+	}
+
 	// Setup system tray
 	systray := app.SystemTray.New()
 
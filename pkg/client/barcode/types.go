@@ -0,0 +1,17 @@
+// Code generated by genservices. DO NOT EDIT.
+
+// Package barcode holds the server's barcode types as the API sends them
+package barcode
+
+type GenerateBarcodeRequest struct {
+	Content  string `json:"content"`
+	Standard string `json:"standard"`
+	Size     int    `json:"size"`
+	Level    string `json:"level"`
+	Format   string `json:"format"`
+}
+
+type GenerateBarcodeResponse struct {
+	DataURL string `json:"dataUrl"`
+	Error   string `json:"error"`
+}
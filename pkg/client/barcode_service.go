@@ -0,0 +1,77 @@
+// Code generated by genservices. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+
+	"devtoolbox/pkg/client/barcode"
+)
+
+// BarcodeService calls the server's BarcodeService
+type BarcodeService struct {
+	c *Client
+}
+
+// GenerateBarcode calls /api/barcode-service/generate-barcode
+func (svc *BarcodeService) GenerateBarcode(ctx context.Context, req barcode.GenerateBarcodeRequest) (barcode.GenerateBarcodeResponse, error) {
+	var out barcode.GenerateBarcodeResponse
+	contentType := "application/json"
+	body, err := jsonBody(req)
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/barcode-service/generate-barcode", contentType, body, &out)
+	return out, err
+}
+
+// GetBarcodeStandards calls /api/barcode-service/get-barcode-standards
+func (svc *BarcodeService) GetBarcodeStandards(ctx context.Context) ([]map[string]string, error) {
+	var out []map[string]string
+	contentType := "application/json"
+	body, err := jsonBody(struct{}{})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/barcode-service/get-barcode-standards", contentType, body, &out)
+	return out, err
+}
+
+// GetQRErrorLevels calls /api/barcode-service/get-qr-error-levels
+func (svc *BarcodeService) GetQRErrorLevels(ctx context.Context) ([]map[string]string, error) {
+	var out []map[string]string
+	contentType := "application/json"
+	body, err := jsonBody(struct{}{})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/barcode-service/get-qr-error-levels", contentType, body, &out)
+	return out, err
+}
+
+// GetBarcodeSizes calls /api/barcode-service/get-barcode-sizes
+func (svc *BarcodeService) GetBarcodeSizes(ctx context.Context) ([]map[string]interface{}, error) {
+	var out []map[string]interface{}
+	contentType := "application/json"
+	body, err := jsonBody(struct{}{})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/barcode-service/get-barcode-sizes", contentType, body, &out)
+	return out, err
+}
+
+// ValidateContent calls /api/barcode-service/validate-content
+func (svc *BarcodeService) ValidateContent(ctx context.Context, content string, standard string) (map[string]interface{}, error) {
+	var out map[string]interface{}
+	contentType := "application/json"
+	body, err := jsonBody(map[string]interface{}{
+		"content":  content,
+		"standard": standard,
+	})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/barcode-service/validate-content", contentType, body, &out)
+	return out, err
+}
@@ -0,0 +1,128 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"devtoolbox/pkg/client"
+	"devtoolbox/pkg/client/datetimeconverter"
+	"devtoolbox/pkg/router"
+	"devtoolbox/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer serves what the app's HTTP server does, every tool and the
+// themes and recipes APIs, with token required
+func newTestServer(t *testing.T, token string) *httptest.Server {
+	t.Helper()
+	cfg := router.DefaultConfig()
+	cfg.Token = token
+	server := router.NewServerWithConfig(cfg)
+	server.SetParamNames(service.MethodParams)
+	server.SetMethodDocs(service.MethodDocs)
+	require.NoError(t, server.RegisterTools(service.NewToolRegistry(nil)))
+	require.NoError(t, server.Register(service.NewThemesService(nil, t.TempDir())))
+	require.NoError(t, server.Register(service.NewRecipeService(nil, t.TempDir())))
+
+	ts := httptest.NewServer(server.Engine())
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestClient_Calls(t *testing.T) {
+	ts := newTestServer(t, "secret")
+	c := client.New(ts.URL+"/", "secret")
+	ctx := context.Background()
+
+	sum, err := c.HashGeneratorService.Hash(ctx, "hello", "SHA-256", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", sum)
+
+	all, err := c.HashGeneratorService.HashAll(ctx, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, sum, all["SHA-256"])
+
+	converted, err := c.DateTimeService.Convert(ctx, datetimeconverter.ConvertRequest{Input: "0", Timezone: "UTC"})
+	require.NoError(t, err)
+	require.NotNil(t, converted.Result)
+	assert.Equal(t, int64(0), converted.Result.UnixSeconds)
+	assert.Equal(t, "timestamp", converted.DetectedType)
+}
+
+// TestClient_MethodsAreRouted checks that the server routes every method the
+// client calls, so services bound to the desktop app only stay out of it
+func TestClient_MethodsAreRouted(t *testing.T) {
+	ts := newTestServer(t, "")
+	resp, err := http.Get(ts.URL + "/api/openapi.json")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var doc router.OpenAPIDocument
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&doc))
+	routed := map[string]bool{}
+	for _, ops := range doc.Paths {
+		for _, op := range ops {
+			routed[op.OperationID] = true
+		}
+	}
+
+	clientType := reflect.TypeOf(client.Client{})
+	for i := 0; i < clientType.NumField(); i++ {
+		field := clientType.Field(i).Type
+		if field.Kind() != reflect.Pointer || field.Elem().PkgPath() != clientType.PkgPath() {
+			continue
+		}
+		for j := 0; j < field.NumMethod(); j++ {
+			name := field.Elem().Name() + "." + field.Method(j).Name
+			assert.True(t, routed[name], "%s is not routed", name)
+		}
+	}
+}
+
+func TestClient_Uploads(t *testing.T) {
+	ts := newTestServer(t, "")
+	c := client.New(ts.URL, "")
+	ctx := context.Background()
+
+	sum, err := c.HashGeneratorService.HashFile(ctx, strings.NewReader("hello"), "SHA-256", map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", sum)
+
+	encoded, err := c.EncoderService.EncodeFile(ctx, strings.NewReader("hello"), "Base64")
+	require.NoError(t, err)
+	defer encoded.Close()
+	data, err := io.ReadAll(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, "aGVsbG8=", string(data))
+}
+
+func TestClient_Errors(t *testing.T) {
+	ts := newTestServer(t, "secret")
+	ctx := context.Background()
+
+	_, err := client.New(ts.URL, "wrong").HashGeneratorService.HashAll(ctx, "hello")
+	var apiErr *client.Error
+	require.True(t, errors.As(err, &apiErr), "got %v", err)
+	assert.Equal(t, http.StatusUnauthorized, apiErr.Status)
+	assert.Equal(t, router.ErrCodeUnauthorized, apiErr.ErrorCode())
+
+	c := client.New(ts.URL, "secret")
+	_, err = c.EncoderService.Decode(ctx, "not base64!", "Base64")
+	require.True(t, errors.As(err, &apiErr), "got %v", err)
+	assert.NotEmpty(t, apiErr.Code)
+	assert.NotEmpty(t, apiErr.Message)
+
+	// In-band failures come back as the service's response
+	decoded, err := c.JWTService.Decode(ctx, "not-a-token")
+	require.NoError(t, err)
+	assert.False(t, decoded.Valid)
+	assert.NotEmpty(t, decoded.Error)
+}
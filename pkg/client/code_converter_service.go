@@ -0,0 +1,41 @@
+// Code generated by genservices. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+
+	"devtoolbox/pkg/client/devtoolbox"
+)
+
+// CodeConverterService calls the server's CodeConverterService
+type CodeConverterService struct {
+	c *Client
+}
+
+// Convert calls /api/code-converter-service/convert
+func (svc *CodeConverterService) Convert(ctx context.Context, input string, method string) (string, error) {
+	var out string
+	contentType := "application/json"
+	body, err := jsonBody(map[string]interface{}{
+		"input":  input,
+		"method": method,
+	})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/code-converter-service/convert", contentType, body, &out)
+	return out, err
+}
+
+// ListMethods calls /api/code-converter-service/list-methods
+func (svc *CodeConverterService) ListMethods(ctx context.Context) ([]devtoolbox.Method, error) {
+	var out []devtoolbox.Method
+	contentType := "application/json"
+	body, err := jsonBody(struct{}{})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/code-converter-service/list-methods", contentType, body, &out)
+	return out, err
+}
@@ -0,0 +1,26 @@
+// Code generated by genservices. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+
+	"devtoolbox/pkg/client/codeformatter"
+)
+
+// CodeFormatterService calls the server's CodeFormatterService
+type CodeFormatterService struct {
+	c *Client
+}
+
+// Format calls /api/code-formatter-service/format
+func (svc *CodeFormatterService) Format(ctx context.Context, req codeformatter.FormatRequest) (codeformatter.FormatResponse, error) {
+	var out codeformatter.FormatResponse
+	contentType := "application/json"
+	body, err := jsonBody(req)
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/code-formatter-service/format", contentType, body, &out)
+	return out, err
+}
@@ -0,0 +1,16 @@
+// Code generated by genservices. DO NOT EDIT.
+
+// Package codeformatter holds the server's codeformatter types as the API sends them
+package codeformatter
+
+type FormatRequest struct {
+	Input      string `json:"input"`
+	FormatType string `json:"formatType"`
+	Filter     string `json:"filter,omitempty"`
+	Minify     bool   `json:"minify"`
+}
+
+type FormatResponse struct {
+	Output string `json:"output"`
+	Error  string `json:"error,omitempty"`
+}
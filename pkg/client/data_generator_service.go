@@ -0,0 +1,50 @@
+// Code generated by genservices. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+
+	"devtoolbox/pkg/client/datagenerator"
+)
+
+// DataGeneratorService calls the server's DataGeneratorService
+type DataGeneratorService struct {
+	c *Client
+}
+
+// Generate calls /api/data-generator-service/generate
+func (svc *DataGeneratorService) Generate(ctx context.Context, req datagenerator.GenerateRequest) (datagenerator.GenerateResponse, error) {
+	var out datagenerator.GenerateResponse
+	contentType := "application/json"
+	body, err := jsonBody(req)
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/data-generator-service/generate", contentType, body, &out)
+	return out, err
+}
+
+// GetPresets calls /api/data-generator-service/get-presets
+func (svc *DataGeneratorService) GetPresets(ctx context.Context) (datagenerator.PresetsResponse, error) {
+	var out datagenerator.PresetsResponse
+	contentType := "application/json"
+	body, err := jsonBody(struct{}{})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/data-generator-service/get-presets", contentType, body, &out)
+	return out, err
+}
+
+// ValidateTemplate calls /api/data-generator-service/validate-template
+func (svc *DataGeneratorService) ValidateTemplate(ctx context.Context, template string) (datagenerator.ValidationResult, error) {
+	var out datagenerator.ValidationResult
+	contentType := "application/json"
+	body, err := jsonBody(map[string]interface{}{"value": template})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/data-generator-service/validate-template", contentType, body, &out)
+	return out, err
+}
@@ -0,0 +1,48 @@
+// Code generated by genservices. DO NOT EDIT.
+
+// Package datagenerator holds the server's datagenerator types as the API sends them
+package datagenerator
+
+type GenerateRequest struct {
+	Template     string                 `json:"template"`
+	Variables    map[string]interface{} `json:"variables"`
+	BatchCount   int                    `json:"batchCount"`
+	OutputFormat string                 `json:"outputFormat"`
+	Separator    string                 `json:"separator"`
+}
+
+type GenerateResponse struct {
+	Output   string `json:"output"`
+	Count    int    `json:"count"`
+	Error    string `json:"error,omitempty"`
+	Duration int64  `json:"durationMs"`
+}
+
+type PresetsResponse struct {
+	Presets []TemplatePreset `json:"presets"`
+	Error   string           `json:"error,omitempty"`
+}
+
+type TemplatePreset struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Template    string     `json:"template"`
+	Variables   []Variable `json:"variables"`
+}
+
+type ValidationResult struct {
+	Valid   bool   `json:"valid"`
+	Error   string `json:"error,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type Variable struct {
+	Name        string      `json:"name"`
+	Type        string      `json:"type"`
+	Default     interface{} `json:"default"`
+	Options     []string    `json:"options,omitempty"`
+	Min         int         `json:"min,omitempty"`
+	Max         int         `json:"max,omitempty"`
+	Description string      `json:"description,omitempty"`
+}
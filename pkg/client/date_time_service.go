@@ -0,0 +1,62 @@
+// Code generated by genservices. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+
+	"devtoolbox/pkg/client/datetimeconverter"
+)
+
+// DateTimeService calls the server's DateTimeService
+type DateTimeService struct {
+	c *Client
+}
+
+// Convert calls /api/date-time-service/convert
+func (svc *DateTimeService) Convert(ctx context.Context, req datetimeconverter.ConvertRequest) (datetimeconverter.ConvertResponse, error) {
+	var out datetimeconverter.ConvertResponse
+	contentType := "application/json"
+	body, err := jsonBody(req)
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/date-time-service/convert", contentType, body, &out)
+	return out, err
+}
+
+// GetPresets calls /api/date-time-service/get-presets
+func (svc *DateTimeService) GetPresets(ctx context.Context) (datetimeconverter.PresetsResponse, error) {
+	var out datetimeconverter.PresetsResponse
+	contentType := "application/json"
+	body, err := jsonBody(struct{}{})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/date-time-service/get-presets", contentType, body, &out)
+	return out, err
+}
+
+// CalculateDelta calls /api/date-time-service/calculate-delta
+func (svc *DateTimeService) CalculateDelta(ctx context.Context, req datetimeconverter.DeltaRequest) (datetimeconverter.DeltaResponse, error) {
+	var out datetimeconverter.DeltaResponse
+	contentType := "application/json"
+	body, err := jsonBody(req)
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/date-time-service/calculate-delta", contentType, body, &out)
+	return out, err
+}
+
+// GetAvailableTimezones calls /api/date-time-service/get-available-timezones
+func (svc *DateTimeService) GetAvailableTimezones(ctx context.Context) (datetimeconverter.AvailableTimezonesResponse, error) {
+	var out datetimeconverter.AvailableTimezonesResponse
+	contentType := "application/json"
+	body, err := jsonBody(struct{}{})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/date-time-service/get-available-timezones", contentType, body, &out)
+	return out, err
+}
@@ -0,0 +1,83 @@
+// Code generated by genservices. DO NOT EDIT.
+
+// Package datetimeconverter holds the server's datetimeconverter types as the API sends them
+package datetimeconverter
+
+type AvailableTimezonesResponse struct {
+	Timezones []TimezoneInfo `json:"timezones"`
+}
+
+type ConvertRequest struct {
+	Input        string `json:"input"`
+	Precision    string `json:"precision"`
+	Timezone     string `json:"timezone"`
+	OutputFormat string `json:"outputFormat"`
+	CustomFormat string `json:"customFormat"`
+}
+
+type ConvertResponse struct {
+	Result       *TimeResult `json:"result,omitempty"`
+	DetectedType string      `json:"detectedType"`
+	DetectedPrec string      `json:"detectedPrec"`
+	Error        string      `json:"error,omitempty"`
+}
+
+type DeltaRequest struct {
+	DateA string `json:"dateA"`
+	DateB string `json:"dateB"`
+}
+
+type DeltaResponse struct {
+	Delta *TimeDelta `json:"delta,omitempty"`
+	Error string     `json:"error,omitempty"`
+}
+
+type Preset struct {
+	ID          string `json:"id"`
+	Label       string `json:"label"`
+	Description string `json:"description"`
+	Timestamp   int64  `json:"timestamp"`
+}
+
+type PresetsResponse struct {
+	Presets []Preset `json:"presets"`
+}
+
+type RelativeBreakdown struct {
+	Days           int `json:"days"`
+	Hours          int `json:"hours"`
+	Minutes        int `json:"minutes"`
+	Seconds        int `json:"seconds"`
+	TotalHours     int `json:"totalHours"`
+	TotalMinutes   int `json:"totalMinutes"`
+	TotalSeconds   int `json:"totalSeconds"`
+	DaysSinceEpoch int `json:"daysSinceEpoch"`
+}
+
+type TimeDelta struct {
+	Days         int     `json:"days"`
+	Hours        int     `json:"hours"`
+	Minutes      int     `json:"minutes"`
+	Seconds      int     `json:"seconds"`
+	TotalHours   float64 `json:"totalHours"`
+	TotalMinutes float64 `json:"totalMinutes"`
+	TotalSeconds float64 `json:"totalSeconds"`
+	BusinessDays int     `json:"businessDays"`
+	IsFuture     bool    `json:"isFuture"`
+}
+
+type TimeResult struct {
+	UnixSeconds     int64             `json:"unixSeconds"`
+	UnixMillis      int64             `json:"unixMillis"`
+	UnixMicros      int64             `json:"unixMicros"`
+	UnixNanos       int64             `json:"unixNanos"`
+	UTC             string            `json:"utc"`
+	Local           string            `json:"local"`
+	Relative        string            `json:"relative"`
+	RelativeDetails RelativeBreakdown `json:"relativeDetails"`
+}
+
+type TimezoneInfo struct {
+	Label    string `json:"label"`
+	Timezone string `json:"timezone"`
+}
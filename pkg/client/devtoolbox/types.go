@@ -0,0 +1,61 @@
+// Code generated by genservices. DO NOT EDIT.
+
+// Package devtoolbox holds the server's devtoolbox types as the API sends them
+package devtoolbox
+
+type ConfigField struct {
+	Key         string    `json:"key"`
+	Type        FieldType `json:"type"`
+	Description string    `json:"description,omitempty"`
+	Required    bool      `json:"required,omitempty"`
+	Direction   Direction `json:"direction,omitempty"`
+}
+
+type Direction string
+
+const (
+	DirectionEncode   Direction = "Encode"
+	DirectionDecode   Direction = "Decode"
+	DirectionEncrypt  Direction = "Encrypt"
+	DirectionDecrypt  Direction = "Decrypt"
+	DirectionEscape   Direction = "Escape"
+	DirectionUnescape Direction = "Unescape"
+)
+
+type FieldType string
+
+const (
+	FieldString FieldType = "string"
+	FieldNumber FieldType = "number"
+	FieldBool   FieldType = "boolean"
+)
+
+type Method struct {
+	Category   string        `json:"category"`
+	Name       string        `json:"name"`
+	Aliases    []string      `json:"aliases,omitempty"`
+	Directions []Direction   `json:"directions,omitempty"`
+	Streams    bool          `json:"streams,omitempty"`
+	Config     []ConfigField `json:"config,omitempty"`
+}
+
+type Recipe struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Steps       []RecipeStep `json:"steps"`
+}
+
+type RecipeStep struct {
+	Category string                 `json:"category"`
+	Method   string                 `json:"method"`
+	Config   map[string]interface{} `json:"config,omitempty"`
+}
+
+type RecipeStepResult struct {
+	Category string `json:"category"`
+	Method   string `json:"method"`
+	Output   string `json:"output"`
+	Base64   bool   `json:"base64,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Duration int64  `json:"durationMs"`
+}
@@ -0,0 +1,109 @@
+// Code generated by genservices. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"io"
+
+	"devtoolbox/pkg/client/devtoolbox"
+)
+
+// EncoderService calls the server's EncoderService
+type EncoderService struct {
+	c *Client
+}
+
+// Encode calls /api/encoder-service/encode
+func (svc *EncoderService) Encode(ctx context.Context, input string, method string) (string, error) {
+	var out string
+	contentType := "application/json"
+	body, err := jsonBody(map[string]interface{}{
+		"input":  input,
+		"method": method,
+	})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/encoder-service/encode", contentType, body, &out)
+	return out, err
+}
+
+// Decode calls /api/encoder-service/decode
+func (svc *EncoderService) Decode(ctx context.Context, input string, method string) (string, error) {
+	var out string
+	contentType := "application/json"
+	body, err := jsonBody(map[string]interface{}{
+		"input":  input,
+		"method": method,
+	})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/encoder-service/decode", contentType, body, &out)
+	return out, err
+}
+
+// Escape calls /api/encoder-service/escape
+func (svc *EncoderService) Escape(ctx context.Context, input string, method string) (string, error) {
+	var out string
+	contentType := "application/json"
+	body, err := jsonBody(map[string]interface{}{
+		"input":  input,
+		"method": method,
+	})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/encoder-service/escape", contentType, body, &out)
+	return out, err
+}
+
+// Unescape calls /api/encoder-service/unescape
+func (svc *EncoderService) Unescape(ctx context.Context, input string, method string) (string, error) {
+	var out string
+	contentType := "application/json"
+	body, err := jsonBody(map[string]interface{}{
+		"input":  input,
+		"method": method,
+	})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/encoder-service/unescape", contentType, body, &out)
+	return out, err
+}
+
+// ListMethods calls /api/encoder-service/list-methods
+func (svc *EncoderService) ListMethods(ctx context.Context) ([]devtoolbox.Method, error) {
+	var out []devtoolbox.Method
+	contentType := "application/json"
+	body, err := jsonBody(struct{}{})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/encoder-service/list-methods", contentType, body, &out)
+	return out, err
+}
+
+// EncodeFile calls /api/encoder-service/encode-file
+func (svc *EncoderService) EncodeFile(ctx context.Context, input io.Reader, method string) (io.ReadCloser, error) {
+	body, contentType, err := uploadBody(map[string]interface{}{
+		"method": method,
+	}, "input", input)
+	if err != nil {
+		return nil, err
+	}
+	return svc.c.stream(ctx, "/api/encoder-service/encode-file", contentType, body)
+}
+
+// DecodeFile calls /api/encoder-service/decode-file
+func (svc *EncoderService) DecodeFile(ctx context.Context, input io.Reader, method string) (io.ReadCloser, error) {
+	body, contentType, err := uploadBody(map[string]interface{}{
+		"method": method,
+	}, "input", input)
+	if err != nil {
+		return nil, err
+	}
+	return svc.c.stream(ctx, "/api/encoder-service/decode-file", contentType, body)
+}
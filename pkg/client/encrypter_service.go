@@ -0,0 +1,60 @@
+// Code generated by genservices. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+
+	"devtoolbox/pkg/client/devtoolbox"
+)
+
+// EncrypterService calls the server's EncrypterService
+type EncrypterService struct {
+	c *Client
+}
+
+// Encrypt calls /api/encrypter-service/encrypt
+func (svc *EncrypterService) Encrypt(ctx context.Context, input string, method string, key string, iv string) (string, error) {
+	var out string
+	contentType := "application/json"
+	body, err := jsonBody(map[string]interface{}{
+		"input":  input,
+		"method": method,
+		"key":    key,
+		"iv":     iv,
+	})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/encrypter-service/encrypt", contentType, body, &out)
+	return out, err
+}
+
+// Decrypt calls /api/encrypter-service/decrypt
+func (svc *EncrypterService) Decrypt(ctx context.Context, input string, method string, key string, iv string) (string, error) {
+	var out string
+	contentType := "application/json"
+	body, err := jsonBody(map[string]interface{}{
+		"input":  input,
+		"method": method,
+		"key":    key,
+		"iv":     iv,
+	})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/encrypter-service/decrypt", contentType, body, &out)
+	return out, err
+}
+
+// ListMethods calls /api/encrypter-service/list-methods
+func (svc *EncrypterService) ListMethods(ctx context.Context) ([]devtoolbox.Method, error) {
+	var out []devtoolbox.Method
+	contentType := "application/json"
+	body, err := jsonBody(struct{}{})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/encrypter-service/list-methods", contentType, body, &out)
+	return out, err
+}
@@ -0,0 +1,69 @@
+// Code generated by genservices. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"io"
+
+	"devtoolbox/pkg/client/devtoolbox"
+)
+
+// HashGeneratorService calls the server's HashGeneratorService
+type HashGeneratorService struct {
+	c *Client
+}
+
+// Hash calls /api/hash-generator-service/hash
+func (svc *HashGeneratorService) Hash(ctx context.Context, input string, method string, config map[string]interface{}) (string, error) {
+	var out string
+	contentType := "application/json"
+	body, err := jsonBody(map[string]interface{}{
+		"input":  input,
+		"method": method,
+		"config": config,
+	})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/hash-generator-service/hash", contentType, body, &out)
+	return out, err
+}
+
+// HashAll calls /api/hash-generator-service/hash-all
+func (svc *HashGeneratorService) HashAll(ctx context.Context, input string) (map[string]string, error) {
+	var out map[string]string
+	contentType := "application/json"
+	body, err := jsonBody(map[string]interface{}{"value": input})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/hash-generator-service/hash-all", contentType, body, &out)
+	return out, err
+}
+
+// ListMethods calls /api/hash-generator-service/list-methods
+func (svc *HashGeneratorService) ListMethods(ctx context.Context) ([]devtoolbox.Method, error) {
+	var out []devtoolbox.Method
+	contentType := "application/json"
+	body, err := jsonBody(struct{}{})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/hash-generator-service/list-methods", contentType, body, &out)
+	return out, err
+}
+
+// HashFile calls /api/hash-generator-service/hash-file
+func (svc *HashGeneratorService) HashFile(ctx context.Context, input io.Reader, method string, config map[string]interface{}) (string, error) {
+	var out string
+	body, contentType, err := uploadBody(map[string]interface{}{
+		"method": method,
+		"config": config,
+	}, "input", input)
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/hash-generator-service/hash-file", contentType, body, &out)
+	return out, err
+}
@@ -0,0 +1,23 @@
+// Code generated by genservices. DO NOT EDIT.
+
+// Package jwt holds the server's jwt types as the API sends them
+package jwt
+
+type DecodeResponse struct {
+	Header    map[string]interface{} `json:"header"`
+	Payload   map[string]interface{} `json:"payload"`
+	Signature string                 `json:"signature"`
+	Valid     bool                   `json:"isValid"`
+	Error     string                 `json:"error"`
+}
+
+type EncodeResponse struct {
+	Token string `json:"token"`
+	Error string `json:"error"`
+}
+
+type VerifyResponse struct {
+	Valid   bool   `json:"isValid"`
+	Message string `json:"validationMessage"`
+	Error   string `json:"error"`
+}
@@ -0,0 +1,59 @@
+// Code generated by genservices. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+
+	"devtoolbox/pkg/client/jwt"
+)
+
+// JWTService calls the server's JWTService
+type JWTService struct {
+	c *Client
+}
+
+// Decode calls /api/jwt-service/decode
+func (svc *JWTService) Decode(ctx context.Context, token string) (jwt.DecodeResponse, error) {
+	var out jwt.DecodeResponse
+	contentType := "application/json"
+	body, err := jsonBody(map[string]interface{}{"value": token})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/jwt-service/decode", contentType, body, &out)
+	return out, err
+}
+
+// Verify calls /api/jwt-service/verify
+func (svc *JWTService) Verify(ctx context.Context, token string, secret string, encoding string) (jwt.VerifyResponse, error) {
+	var out jwt.VerifyResponse
+	contentType := "application/json"
+	body, err := jsonBody(map[string]interface{}{
+		"token":    token,
+		"secret":   secret,
+		"encoding": encoding,
+	})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/jwt-service/verify", contentType, body, &out)
+	return out, err
+}
+
+// Encode calls /api/jwt-service/encode
+func (svc *JWTService) Encode(ctx context.Context, headerJSON string, payloadJSON string, algorithm string, secret string) (jwt.EncodeResponse, error) {
+	var out jwt.EncodeResponse
+	contentType := "application/json"
+	body, err := jsonBody(map[string]interface{}{
+		"headerJSON":  headerJSON,
+		"payloadJSON": payloadJSON,
+		"algorithm":   algorithm,
+		"secret":      secret,
+	})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/jwt-service/encode", contentType, body, &out)
+	return out, err
+}
@@ -0,0 +1,26 @@
+// Code generated by genservices. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+
+	"devtoolbox/pkg/client/numberconverter"
+)
+
+// NumberConverterService calls the server's NumberConverterService
+type NumberConverterService struct {
+	c *Client
+}
+
+// Convert calls /api/number-converter-service/convert
+func (svc *NumberConverterService) Convert(ctx context.Context, req numberconverter.ConvertRequest) (numberconverter.ConvertResponse, error) {
+	var out numberconverter.ConvertResponse
+	contentType := "application/json"
+	body, err := jsonBody(req)
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/number-converter-service/convert", contentType, body, &out)
+	return out, err
+}
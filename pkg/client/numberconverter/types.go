@@ -0,0 +1,59 @@
+// Code generated by genservices. DO NOT EDIT.
+
+// Package numberconverter holds the server's numberconverter types as the API sends them
+package numberconverter
+
+type ASCIIView struct {
+	Char      string `json:"char"`
+	Code      int    `json:"code"`
+	Printable bool   `json:"printable"`
+}
+
+type ByteView struct {
+	BigEndian   []string `json:"bigEndian"`
+	Highlighted int      `json:"highlighted"`
+}
+
+type ColorView struct {
+	Hex   string `json:"hex"`
+	Valid bool   `json:"valid"`
+}
+
+type ConvertRequest struct {
+	Value string `json:"value"`
+	Base  string `json:"base"`
+}
+
+type ConvertResponse struct {
+	Binary     string        `json:"binary"`
+	Decimal    string        `json:"decimal"`
+	Hex        string        `json:"hex"`
+	Octal      string        `json:"octal"`
+	Bits       []int         `json:"bits"`
+	BitValues  []int         `json:"bitValues"`
+	Bytes      ByteView      `json:"bytes"`
+	ASCII      ASCIIView     `json:"ascii"`
+	Color      ColorView     `json:"color"`
+	IPv4       IPv4View      `json:"ipv4"`
+	FileSize   FileSizeView  `json:"fileSize"`
+	Timestamp  TimestampView `json:"timestamp"`
+	Percentage int           `json:"percentage"`
+	Error      string        `json:"error,omitempty"`
+}
+
+type FileSizeView struct {
+	Bytes int     `json:"bytes"`
+	KB    float64 `json:"kb"`
+	MB    float64 `json:"mb"`
+	Human string  `json:"human"`
+}
+
+type IPv4View struct {
+	Address string `json:"address"`
+	Type    string `json:"type"`
+}
+
+type TimestampView struct {
+	DateTime string `json:"datetime"`
+	Duration string `json:"duration"`
+}
@@ -0,0 +1,122 @@
+// Code generated by genservices. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"io"
+
+	"devtoolbox/pkg/client/devtoolbox"
+	"devtoolbox/pkg/client/recipe"
+)
+
+// RecipeService calls the server's RecipeService
+type RecipeService struct {
+	c *Client
+}
+
+// ListSteps calls /api/recipe-service/list-steps
+func (svc *RecipeService) ListSteps(ctx context.Context) ([]devtoolbox.Method, error) {
+	var out []devtoolbox.Method
+	contentType := "application/json"
+	body, err := jsonBody(struct{}{})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/recipe-service/list-steps", contentType, body, &out)
+	return out, err
+}
+
+// Run calls /api/recipe-service/run
+func (svc *RecipeService) Run(ctx context.Context, req recipe.RunRequest) (recipe.RunResponse, error) {
+	var out recipe.RunResponse
+	contentType := "application/json"
+	body, err := jsonBody(req)
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/recipe-service/run", contentType, body, &out)
+	return out, err
+}
+
+// RunSaved calls /api/recipe-service/run-saved
+func (svc *RecipeService) RunSaved(ctx context.Context, name string, input string) (recipe.RunResponse, error) {
+	var out recipe.RunResponse
+	contentType := "application/json"
+	body, err := jsonBody(map[string]interface{}{
+		"name":  name,
+		"input": input,
+	})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/recipe-service/run-saved", contentType, body, &out)
+	return out, err
+}
+
+// List calls /api/recipe-service/list
+func (svc *RecipeService) List(ctx context.Context) ([]devtoolbox.Recipe, error) {
+	var out []devtoolbox.Recipe
+	contentType := "application/json"
+	body, err := jsonBody(struct{}{})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/recipe-service/list", contentType, body, &out)
+	return out, err
+}
+
+// Get calls /api/recipe-service/get
+func (svc *RecipeService) Get(ctx context.Context, name string) (devtoolbox.Recipe, error) {
+	var out devtoolbox.Recipe
+	contentType := "application/json"
+	body, err := jsonBody(map[string]interface{}{"value": name})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/recipe-service/get", contentType, body, &out)
+	return out, err
+}
+
+// Save calls /api/recipe-service/save
+func (svc *RecipeService) Save(ctx context.Context, r devtoolbox.Recipe) (devtoolbox.Recipe, error) {
+	var out devtoolbox.Recipe
+	contentType := "application/json"
+	body, err := jsonBody(r)
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/recipe-service/save", contentType, body, &out)
+	return out, err
+}
+
+// Delete calls /api/recipe-service/delete
+func (svc *RecipeService) Delete(ctx context.Context, name string) error {
+	contentType := "application/json"
+	body, err := jsonBody(map[string]interface{}{"value": name})
+	if err != nil {
+		return err
+	}
+	return svc.c.call(ctx, "/api/recipe-service/delete", contentType, body, nil)
+}
+
+// Import calls /api/recipe-service/import
+func (svc *RecipeService) Import(ctx context.Context, file io.Reader) (devtoolbox.Recipe, error) {
+	var out devtoolbox.Recipe
+	body, contentType, err := uploadBody(map[string]interface{}{}, "file", file)
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/recipe-service/import", contentType, body, &out)
+	return out, err
+}
+
+// Export calls /api/recipe-service/export
+func (svc *RecipeService) Export(ctx context.Context, name string) (io.ReadCloser, error) {
+	contentType := "application/json"
+	body, err := jsonBody(map[string]interface{}{"value": name})
+	if err != nil {
+		return nil, err
+	}
+	return svc.c.stream(ctx, "/api/recipe-service/export", contentType, body)
+}
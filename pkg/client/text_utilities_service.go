@@ -0,0 +1,134 @@
+// Code generated by genservices. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+
+	"devtoolbox/pkg/client/devtoolbox"
+)
+
+// TextUtilitiesService calls the server's TextUtilitiesService
+type TextUtilitiesService struct {
+	c *Client
+}
+
+// Escape calls /api/text-utilities-service/escape
+func (svc *TextUtilitiesService) Escape(ctx context.Context, input string, method string) (string, error) {
+	var out string
+	contentType := "application/json"
+	body, err := jsonBody(map[string]interface{}{
+		"input":  input,
+		"method": method,
+	})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/text-utilities-service/escape", contentType, body, &out)
+	return out, err
+}
+
+// Unescape calls /api/text-utilities-service/unescape
+func (svc *TextUtilitiesService) Unescape(ctx context.Context, input string, method string) (string, error) {
+	var out string
+	contentType := "application/json"
+	body, err := jsonBody(map[string]interface{}{
+		"input":  input,
+		"method": method,
+	})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/text-utilities-service/unescape", contentType, body, &out)
+	return out, err
+}
+
+// ListMethods calls /api/text-utilities-service/list-methods
+func (svc *TextUtilitiesService) ListMethods(ctx context.Context) ([]devtoolbox.Method, error) {
+	var out []devtoolbox.Method
+	contentType := "application/json"
+	body, err := jsonBody(struct{}{})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/text-utilities-service/list-methods", contentType, body, &out)
+	return out, err
+}
+
+// SortLines calls /api/text-utilities-service/sort-lines
+func (svc *TextUtilitiesService) SortLines(ctx context.Context, input string, reverse bool) (string, error) {
+	var out string
+	contentType := "application/json"
+	body, err := jsonBody(map[string]interface{}{
+		"input":   input,
+		"reverse": reverse,
+	})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/text-utilities-service/sort-lines", contentType, body, &out)
+	return out, err
+}
+
+// RemoveDuplicates calls /api/text-utilities-service/remove-duplicates
+func (svc *TextUtilitiesService) RemoveDuplicates(ctx context.Context, input string) (string, error) {
+	var out string
+	contentType := "application/json"
+	body, err := jsonBody(map[string]interface{}{"value": input})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/text-utilities-service/remove-duplicates", contentType, body, &out)
+	return out, err
+}
+
+// TrimLines calls /api/text-utilities-service/trim-lines
+func (svc *TextUtilitiesService) TrimLines(ctx context.Context, input string) (string, error) {
+	var out string
+	contentType := "application/json"
+	body, err := jsonBody(map[string]interface{}{"value": input})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/text-utilities-service/trim-lines", contentType, body, &out)
+	return out, err
+}
+
+// RemoveEmptyLines calls /api/text-utilities-service/remove-empty-lines
+func (svc *TextUtilitiesService) RemoveEmptyLines(ctx context.Context, input string) (string, error) {
+	var out string
+	contentType := "application/json"
+	body, err := jsonBody(map[string]interface{}{"value": input})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/text-utilities-service/remove-empty-lines", contentType, body, &out)
+	return out, err
+}
+
+// ConvertCase calls /api/text-utilities-service/convert-case
+func (svc *TextUtilitiesService) ConvertCase(ctx context.Context, input string, targetCase string) (string, error) {
+	var out string
+	contentType := "application/json"
+	body, err := jsonBody(map[string]interface{}{
+		"input":      input,
+		"targetCase": targetCase,
+	})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/text-utilities-service/convert-case", contentType, body, &out)
+	return out, err
+}
+
+// GetStats calls /api/text-utilities-service/get-stats
+func (svc *TextUtilitiesService) GetStats(ctx context.Context, input string) (map[string]interface{}, error) {
+	var out map[string]interface{}
+	contentType := "application/json"
+	body, err := jsonBody(map[string]interface{}{"value": input})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/text-utilities-service/get-stats", contentType, body, &out)
+	return out, err
+}
@@ -0,0 +1,13 @@
+// Code generated by genservices. DO NOT EDIT.
+
+// Package themes holds the server's themes types as the API sends them
+package themes
+
+import (
+	"encoding/json"
+)
+
+type Theme struct {
+	Name string          `json:"name"`
+	Data json.RawMessage `json:"data"`
+}
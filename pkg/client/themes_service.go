@@ -0,0 +1,26 @@
+// Code generated by genservices. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+
+	"devtoolbox/pkg/client/themes"
+)
+
+// ThemesService calls the server's ThemesService
+type ThemesService struct {
+	c *Client
+}
+
+// List calls /api/themes-service/list
+func (svc *ThemesService) List(ctx context.Context) ([]themes.Theme, error) {
+	var out []themes.Theme
+	contentType := "application/json"
+	body, err := jsonBody(struct{}{})
+	if err != nil {
+		return out, err
+	}
+	err = svc.c.call(ctx, "/api/themes-service/list", contentType, body, &out)
+	return out, err
+}
@@ -0,0 +1,91 @@
+package devtoolbox
+
+import (
+	"context"
+	"errors"
+
+	"devtoolbox/internal/barcode"
+)
+
+// BarcodeStandard names a barcode symbology
+type BarcodeStandard string
+
+// Barcode standards
+const (
+	BarcodeQR      BarcodeStandard = "QR"
+	BarcodeEAN13   BarcodeStandard = "EAN-13"
+	BarcodeEAN8    BarcodeStandard = "EAN-8"
+	BarcodeCode128 BarcodeStandard = "Code128"
+	BarcodeCode39  BarcodeStandard = "Code39"
+)
+
+// Barcode is a generated PNG image
+type Barcode struct {
+	// DataURL embeds the image as a base64 data URL
+	DataURL string `json:"dataUrl"`
+}
+
+// ContentType returns the MIME type of the image, which is always PNG
+func (b Barcode) ContentType() string {
+	return barcode.GenerateBarcodeResponse{DataURL: b.DataURL}.ContentType()
+}
+
+// Bytes returns the image
+func (b Barcode) Bytes() []byte {
+	return barcode.GenerateBarcodeResponse{DataURL: b.DataURL}.Bytes()
+}
+
+// BarcodeOptions configures GenerateBarcode
+type BarcodeOptions struct {
+	// Standard is the symbology; QR by default
+	Standard BarcodeStandard
+	// Size is the width in pixels
+	Size int
+	// Level is the QR error correction level: L, M (the default), Q or H
+	Level string
+}
+
+var barcodes = barcode.NewBarcodeService()
+
+// GenerateBarcode encodes content as a barcode image
+func GenerateBarcode(ctx context.Context, content string, opts BarcodeOptions) (Barcode, error) {
+	if err := ctx.Err(); err != nil {
+		return Barcode{}, err
+	}
+	resp := barcodes.GenerateBarcode(barcode.GenerateBarcodeRequest{
+		Content:  content,
+		Standard: string(opts.Standard),
+		Size:     opts.Size,
+		Level:    opts.Level,
+	})
+	if resp.Error != "" {
+		return Barcode{}, errors.New(resp.Error)
+	}
+	return Barcode{DataURL: resp.DataURL}, nil
+}
+
+// ValidateBarcode checks that content can be encoded with standard,
+// including the check digit of EAN codes
+func ValidateBarcode(content string, standard BarcodeStandard) error {
+	result := barcodes.ValidateContent(content, string(standard))
+	if valid, _ := result["valid"].(bool); !valid {
+		message, _ := result["message"].(string)
+		return errors.New(message)
+	}
+	return nil
+}
+
+// BarcodeStandards describes the supported standards, as value and label
+func BarcodeStandards() []map[string]string {
+	return barcodes.GetBarcodeStandards()
+}
+
+// QRErrorLevels describes the QR error correction levels, as value and label
+func QRErrorLevels() []map[string]string {
+	return barcodes.GetQRErrorLevels()
+}
+
+// BarcodeSizes describes the suggested image sizes, as value and label
+func BarcodeSizes() []map[string]interface{} {
+	return barcodes.GetBarcodeSizes()
+}
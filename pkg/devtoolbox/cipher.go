@@ -0,0 +1,72 @@
+package devtoolbox
+
+import (
+	"context"
+	"strings"
+
+	"devtoolbox/internal/converter"
+)
+
+// Cipher names a symmetric cipher, or RSA
+type Cipher string
+
+// Ciphers. Ciphertext is base64 encoded.
+const (
+	CipherAES       Cipher = "AES"
+	CipherAESGCM    Cipher = "AES-GCM"
+	CipherDES       Cipher = "DES"
+	CipherTripleDES Cipher = "Triple DES"
+	CipherChaCha20  Cipher = "ChaCha20"
+	CipherSalsa20   Cipher = "Salsa20"
+	CipherXOR       Cipher = "XOR"
+	CipherRC4       Cipher = "RC4"
+	// CipherRSA encrypts with a public key and decrypts with a private key,
+	// each a base64 encoded PEM block
+	CipherRSA Cipher = "RSA"
+)
+
+// CipherOptions configures Encrypt and Decrypt
+type CipherOptions struct {
+	// Key is the secret key, or the RSA public key to encrypt with and the
+	// RSA private key to decrypt with
+	Key string
+	// IV is the initialization vector or nonce, for the ciphers that take one
+	IV string
+}
+
+var encryption = converter.NewEncryptionConverter()
+
+func cipherRequest(input string, cipher Cipher, subMode string, opts CipherOptions) converter.ConversionRequest {
+	config := map[string]interface{}{"subMode": subMode, "key": opts.Key, "iv": opts.IV}
+	if strings.EqualFold(string(cipher), string(CipherRSA)) {
+		if subMode == "Encrypt" {
+			config["publicKey"] = opts.Key
+		} else {
+			config["privateKey"] = opts.Key
+		}
+	}
+	return converter.ConversionRequest{
+		Input:    input,
+		Category: converter.CategoryEncrypt,
+		Method:   string(cipher),
+		Config:   config,
+	}
+}
+
+// Encrypt encrypts input with cipher
+func Encrypt(ctx context.Context, input string, cipher Cipher, opts CipherOptions) (string, error) {
+	return converter.ConvertContext(ctx, encryption, cipherRequest(input, cipher, "Encrypt", opts))
+}
+
+// Decrypt decrypts input, which Encrypt produced with the same cipher and
+// options
+func Decrypt(ctx context.Context, input string, cipher Cipher, opts CipherOptions) (string, error) {
+	return converter.ConvertContext(ctx, encryption, cipherRequest(input, cipher, "Decrypt", opts))
+}
+
+// CipherMethods describes the ciphers Encrypt and Decrypt accept. The key
+// and iv config fields are CipherOptions.Key and IV; RSA's publicKey and
+// privateKey are Key too.
+func CipherMethods() []Method {
+	return fromMethods(methods.Methods(converter.CategoryEncrypt))
+}
@@ -0,0 +1,45 @@
+package devtoolbox
+
+import (
+	"context"
+
+	"devtoolbox/internal/converter"
+)
+
+// Conversion names a pair of formats. Convert detects which of the two the
+// input is in and converts it to the other.
+type Conversion string
+
+// Conversions
+const (
+	ConvertJSONYAML       Conversion = "JSON ↔ YAML"
+	ConvertJSONXML        Conversion = "JSON ↔ XML"
+	ConvertJSONCSV        Conversion = "JSON ↔ CSV / TSV"
+	ConvertYAMLTOML       Conversion = "YAML ↔ TOML"
+	ConvertMarkdownHTML   Conversion = "Markdown ↔ HTML"
+	ConvertCSVTSV         Conversion = "CSV ↔ TSV"
+	ConvertQueryString    Conversion = "Key-Value ↔ Query String"
+	ConvertPropertiesJSON Conversion = "Properties ↔ JSON"
+	ConvertINIJSON        Conversion = "INI ↔ JSON"
+	ConvertCurlFetch      Conversion = "CURL ↔ Fetch"
+	ConvertCronText       Conversion = "Cron ↔ Text"
+	// ConvertCaseSwapping swaps the case of every letter
+	ConvertCaseSwapping Conversion = "Case Swapping"
+)
+
+var formatting = converter.NewFormattingConverter()
+
+// Convert converts input between the formats of conversion
+func Convert(ctx context.Context, input string, conversion Conversion) (string, error) {
+	return converter.ConvertContext(ctx, formatting, converter.ConversionRequest{
+		Input:    input,
+		Category: converter.CategoryConvert,
+		Method:   string(conversion),
+		Config:   map[string]interface{}{},
+	})
+}
+
+// ConversionMethods describes the conversions Convert accepts
+func ConversionMethods() []Method {
+	return fromMethods(methods.Methods(converter.CategoryConvert))
+}
@@ -0,0 +1,105 @@
+package devtoolbox
+
+import (
+	"context"
+	"time"
+
+	"devtoolbox/internal/datagenerator"
+)
+
+// DataPreset is a built-in template with the variables it takes
+type DataPreset struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Template    string             `json:"template"`
+	Variables   []TemplateVariable `json:"variables"`
+}
+
+// TemplateVariable is a value a template reads, set with
+// GenerateOptions.Variables
+type TemplateVariable struct {
+	Name string `json:"name"`
+	// Type is "string", "number", "select" or "boolean"
+	Type    string      `json:"type"`
+	Default interface{} `json:"default"`
+	// Options are the values a select variable takes
+	Options []string `json:"options,omitempty"`
+	// Min and Max bound a number variable
+	Min         int    `json:"min,omitempty"`
+	Max         int    `json:"max,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// GenerateOptions configures GenerateData
+type GenerateOptions struct {
+	// Variables are the values of the template's variables
+	Variables map[string]interface{}
+	// Count is the number of records to generate, from 1 to 1000
+	Count int
+	// Format of the output: "json", "xml", "csv", "tsv", "yaml" or "raw"
+	Format string
+	// Separator between raw records: "newline", "comma", "tab", "none" or a
+	// literal string; a blank line by default
+	Separator string
+}
+
+// GeneratedData is the output of GenerateData
+type GeneratedData struct {
+	Output string
+	// Count is the number of records generated
+	Count int
+	// Duration is how long generating the records took
+	Duration time.Duration
+}
+
+// GenerateData executes template, a Go text/template with fake data
+// functions, Count times and formats the records
+func GenerateData(ctx context.Context, template string, opts GenerateOptions) (GeneratedData, error) {
+	if err := ctx.Err(); err != nil {
+		return GeneratedData{}, err
+	}
+	resp, err := datagenerator.NewDataGeneratorService().Generate(ctx, datagenerator.GenerateRequest{
+		Template:     template,
+		Variables:    opts.Variables,
+		BatchCount:   opts.Count,
+		OutputFormat: opts.Format,
+		Separator:    opts.Separator,
+	})
+	if err != nil {
+		return GeneratedData{}, err
+	}
+	if err := resp.Err(); err != nil {
+		return GeneratedData{}, err
+	}
+	return GeneratedData{
+		Output:   resp.Output,
+		Count:    resp.Count,
+		Duration: time.Duration(resp.Duration) * time.Millisecond,
+	}, nil
+}
+
+// ValidateTemplate checks that template parses
+func ValidateTemplate(template string) error {
+	return datagenerator.NewEngine().Validate(template)
+}
+
+// DataPresets returns the built-in templates
+func DataPresets() []DataPreset {
+	presets := datagenerator.GetBuiltInPresets()
+	out := make([]DataPreset, len(presets))
+	for i, p := range presets {
+		vars := make([]TemplateVariable, len(p.Variables))
+		for j, v := range p.Variables {
+			vars[j] = TemplateVariable(v)
+		}
+		out[i] = DataPreset{
+			ID:          p.ID,
+			Name:        p.Name,
+			Description: p.Description,
+			Template:    p.Template,
+			Variables:   vars,
+		}
+	}
+	return out
+}
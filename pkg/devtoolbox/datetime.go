@@ -0,0 +1,166 @@
+package devtoolbox
+
+import (
+	"context"
+
+	"devtoolbox/internal/datetimeconverter"
+)
+
+// TimeResult is an instant in every representation ConvertTime produces
+type TimeResult struct {
+	UnixSeconds int64  `json:"unixSeconds"`
+	UnixMillis  int64  `json:"unixMillis"`
+	UnixMicros  int64  `json:"unixMicros"`
+	UnixNanos   int64  `json:"unixNanos"`
+	UTC         string `json:"utc"`
+	// Local is the instant in the requested time zone and output format
+	Local string `json:"local"`
+	// Relative describes the instant relative to now, such as "2 days ago"
+	Relative        string       `json:"relative"`
+	RelativeDetails RelativeTime `json:"relativeDetails"`
+}
+
+// RelativeTime breaks down the time between now and an instant
+type RelativeTime struct {
+	Days           int `json:"days"`
+	Hours          int `json:"hours"`
+	Minutes        int `json:"minutes"`
+	Seconds        int `json:"seconds"`
+	TotalHours     int `json:"totalHours"`
+	TotalMinutes   int `json:"totalMinutes"`
+	TotalSeconds   int `json:"totalSeconds"`
+	DaysSinceEpoch int `json:"daysSinceEpoch"`
+}
+
+// TimeDelta is the difference between two instants
+type TimeDelta struct {
+	Days         int     `json:"days"`
+	Hours        int     `json:"hours"`
+	Minutes      int     `json:"minutes"`
+	Seconds      int     `json:"seconds"`
+	TotalHours   float64 `json:"totalHours"`
+	TotalMinutes float64 `json:"totalMinutes"`
+	TotalSeconds float64 `json:"totalSeconds"`
+	BusinessDays int     `json:"businessDays"`
+	// IsFuture reports whether the second instant is after the first
+	IsFuture bool `json:"isFuture"`
+}
+
+// TimePreset is a quick pick of an instant, such as now
+type TimePreset struct {
+	ID          string `json:"id"`
+	Label       string `json:"label"`
+	Description string `json:"description"`
+	// Timestamp is the instant in Unix seconds
+	Timestamp int64 `json:"timestamp"`
+}
+
+// Timezone is a time zone with its display label
+type Timezone struct {
+	Label    string `json:"label"`
+	Timezone string `json:"timezone"`
+}
+
+// TimeOptions configures ConvertTime
+type TimeOptions struct {
+	// Timezone is "local" (the default), "UTC" or an IANA name such as
+	// "Asia/Ho_Chi_Minh"
+	Timezone string
+	// Precision of a timestamp input: "auto" to detect it from the number
+	// of digits, or "seconds" (the default), "millis", "micros" or "nanos"
+	Precision string
+	// OutputFormat of TimeResult.Local: "iso" (the default), "rfc2822",
+	// "rfc3339", "sql", "us", "eu", "compact" or "custom"
+	OutputFormat string
+	// CustomFormat is the pattern used when OutputFormat is "custom", with
+	// tokens such as YYYY, MM, DD, HH, mm and ss
+	CustomFormat string
+}
+
+// TimeConversion is the result of ConvertTime
+type TimeConversion struct {
+	Time *TimeResult
+	// DetectedType is what the input was read as: "timestamp", "iso" or
+	// "date"
+	DetectedType string
+	// DetectedPrecision is the precision of a timestamp input
+	DetectedPrecision string
+}
+
+var datetimes = datetimeconverter.NewService()
+
+// ConvertTime reads input, a Unix timestamp or a date in a common format,
+// and returns it in every representation
+func ConvertTime(ctx context.Context, input string, opts TimeOptions) (TimeConversion, error) {
+	if err := ctx.Err(); err != nil {
+		return TimeConversion{}, err
+	}
+	resp := datetimes.Convert(datetimeconverter.ConvertRequest{
+		Input:        input,
+		Precision:    opts.Precision,
+		Timezone:     opts.Timezone,
+		OutputFormat: opts.OutputFormat,
+		CustomFormat: opts.CustomFormat,
+	})
+	if err := resp.Err(); err != nil {
+		return TimeConversion{}, err
+	}
+	return TimeConversion{
+		Time:              fromTimeResult(resp.Result),
+		DetectedType:      resp.DetectedType,
+		DetectedPrecision: resp.DetectedPrec,
+	}, nil
+}
+
+// TimeBetween returns the time from a to b, which are timestamps or dates
+func TimeBetween(ctx context.Context, a, b string) (*TimeDelta, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	resp := datetimes.CalculateDelta(datetimeconverter.DeltaRequest{DateA: a, DateB: b})
+	if err := resp.Err(); err != nil {
+		return nil, err
+	}
+	if resp.Delta == nil {
+		return nil, nil
+	}
+	delta := TimeDelta(*resp.Delta)
+	return &delta, nil
+}
+
+// TimePresets returns the quick picks the app offers
+func TimePresets() []TimePreset {
+	presets := datetimes.GetPresets().Presets
+	out := make([]TimePreset, len(presets))
+	for i, p := range presets {
+		out[i] = TimePreset(p)
+	}
+	return out
+}
+
+// Timezones returns the time zones the app offers
+func Timezones() []Timezone {
+	zones := datetimes.GetAvailableTimezones().Timezones
+	out := make([]Timezone, len(zones))
+	for i, z := range zones {
+		out[i] = Timezone(z)
+	}
+	return out
+}
+
+// fromTimeResult converts the converter's result, which may be nil
+func fromTimeResult(r *datetimeconverter.TimeResult) *TimeResult {
+	if r == nil {
+		return nil
+	}
+	return &TimeResult{
+		UnixSeconds:     r.UnixSeconds,
+		UnixMillis:      r.UnixMillis,
+		UnixMicros:      r.UnixMicros,
+		UnixNanos:       r.UnixNanos,
+		UTC:             r.UTC,
+		Local:           r.Local,
+		Relative:        r.Relative,
+		RelativeDetails: RelativeTime(r.RelativeDetails),
+	}
+}
@@ -0,0 +1,301 @@
+package devtoolbox
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	sharedErrors "devtoolbox/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHash(t *testing.T) {
+	ctx := context.Background()
+
+	digest, err := Hash(ctx, "hello", HashSHA256, HashOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", digest)
+
+	streamed, err := HashReader(ctx, strings.NewReader("hello"), HashSHA256, HashOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, digest, streamed)
+
+	all, err := HashAll(ctx, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, digest, all["SHA-256"])
+
+	keyed, err := Hash(ctx, "hello", HashHMAC, HashOptions{Key: "secret"})
+	require.NoError(t, err)
+	assert.NotEqual(t, digest, keyed)
+}
+
+func TestHash_CancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Hash(ctx, "hello", HashSHA256, HashOptions{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestEncodeDecode(t *testing.T) {
+	ctx := context.Background()
+
+	encoded, err := Encode(ctx, "hello", EncodingBase64)
+	require.NoError(t, err)
+	assert.Equal(t, "aGVsbG8=", encoded)
+
+	decoded, err := Decode(ctx, encoded, EncodingBase64)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", decoded)
+
+	out, err := io.ReadAll(EncodeReader(ctx, strings.NewReader("hello"), EncodingHex))
+	require.NoError(t, err)
+	assert.Equal(t, "68656c6c6f", string(out))
+
+	escaped, err := Escape(ctx, "<b>", EscapeHTML)
+	require.NoError(t, err)
+	unescaped, err := Unescape(ctx, escaped, EscapeHTML)
+	require.NoError(t, err)
+	assert.Equal(t, "<b>", unescaped)
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	ctx := context.Background()
+	opts := CipherOptions{Key: strings.Repeat("k", 32), IV: strings.Repeat("i", 16)}
+
+	ciphertext, err := Encrypt(ctx, "secret message", CipherAES, opts)
+	require.NoError(t, err)
+	plaintext, err := Decrypt(ctx, ciphertext, CipherAES, opts)
+	require.NoError(t, err)
+	assert.Equal(t, "secret message", plaintext)
+
+	_, err = Encrypt(ctx, "x", CipherAES, CipherOptions{Key: "short"})
+	assert.Error(t, err)
+}
+
+func TestConvert(t *testing.T) {
+	out, err := Convert(context.Background(), `{"name":"devtoolbox"}`, ConvertJSONYAML)
+	require.NoError(t, err)
+	assert.Equal(t, "name: devtoolbox", strings.TrimSpace(out))
+}
+
+func TestFormat(t *testing.T) {
+	ctx := context.Background()
+
+	out, err := Format(ctx, `{"a":1}`, FormatJSON, FormatOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, out, "\n")
+
+	out, err = Format(ctx, `{"a":{"b":2}}`, FormatJSON, FormatOptions{Filter: ".a.b"})
+	require.NoError(t, err)
+	assert.Equal(t, "2", strings.TrimSpace(out))
+
+	_, err = Format(ctx, `{"a":`, FormatJSON, FormatOptions{})
+	assert.Error(t, err)
+}
+
+func TestJWT(t *testing.T) {
+	ctx := context.Background()
+
+	token, err := EncodeJWT(ctx, map[string]interface{}{"sub": "42"}, SignOptions{Algorithm: "HS256", Secret: "secret"})
+	require.NoError(t, err)
+
+	decoded, err := DecodeJWT(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, "42", decoded.Payload["sub"])
+	assert.Equal(t, "HS256", decoded.Header["alg"])
+
+	ok, err := VerifyJWT(ctx, token, VerifyOptions{Secret: "secret"})
+	require.NoError(t, err)
+	assert.True(t, ok.Valid)
+
+	bad, err := VerifyJWT(ctx, token, VerifyOptions{Secret: "wrong"})
+	require.NoError(t, err)
+	assert.False(t, bad.Valid)
+
+	// "c2VjcmV0" is "secret" in base64
+	ok, err = VerifyJWT(ctx, token, VerifyOptions{Secret: "c2VjcmV0", Base64Secret: true})
+	require.NoError(t, err)
+	assert.True(t, ok.Valid)
+	_, err = VerifyJWT(ctx, token, VerifyOptions{Secret: "not base64!", Base64Secret: true})
+	assert.Equal(t, "INVALID_SECRET", sharedErrors.CodeOf(err))
+
+	_, err = DecodeJWT(ctx, "not.a.token")
+	assert.Equal(t, "MALFORMED_TOKEN", sharedErrors.CodeOf(err))
+	_, err = VerifyJWT(ctx, token, VerifyOptions{})
+	assert.Equal(t, "INVALID_SECRET", sharedErrors.CodeOf(err))
+}
+
+func TestConvertTime(t *testing.T) {
+	ctx := context.Background()
+
+	conv, err := ConvertTime(ctx, "0", TimeOptions{Timezone: "UTC"})
+	require.NoError(t, err)
+	assert.Equal(t, "timestamp", conv.DetectedType)
+	assert.Equal(t, int64(0), conv.Time.UnixSeconds)
+
+	_, err = ConvertTime(ctx, "not a date", TimeOptions{})
+	assert.Error(t, err)
+
+	delta, err := TimeBetween(ctx, "2024-01-01", "2024-01-02")
+	require.NoError(t, err)
+	assert.Equal(t, float64(86400), delta.TotalSeconds)
+
+	assert.NotEmpty(t, TimePresets())
+	assert.NotEmpty(t, Timezones())
+}
+
+func TestText(t *testing.T) {
+	ctx := context.Background()
+
+	sorted, err := SortLines(ctx, "b\na", SortOptions{Reverse: true})
+	require.NoError(t, err)
+	assert.Equal(t, "b\na", sorted)
+
+	kebab, err := ConvertCase(ctx, "hello world", CaseKebab)
+	require.NoError(t, err)
+	assert.Equal(t, "hello-world", kebab)
+
+	stats, err := TextStatistics(ctx, "Hi there.")
+	require.NoError(t, err)
+	assert.Equal(t, TextStats{Chars: 9, Words: 2, Lines: 1, Bytes: 9, Sentences: 1}, stats)
+}
+
+func TestConvertNumber(t *testing.T) {
+	ctx := context.Background()
+
+	n, err := ConvertNumber(ctx, "ff", Hexadecimal)
+	require.NoError(t, err)
+	assert.Equal(t, "255", n.Decimal)
+
+	_, err = ConvertNumber(ctx, "12", Binary)
+	assert.Error(t, err)
+}
+
+func TestGenerateData(t *testing.T) {
+	ctx := context.Background()
+
+	data, err := GenerateData(ctx, `id-{{ .N }}`, GenerateOptions{
+		Variables: map[string]interface{}{"N": 7},
+		Count:     2,
+		Format:    "raw",
+		Separator: "newline",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, data.Count)
+	assert.Equal(t, "id-7\nid-7", data.Output)
+	assert.GreaterOrEqual(t, data.Duration, time.Duration(0))
+
+	_, err = GenerateData(ctx, "x", GenerateOptions{})
+	assert.Error(t, err)
+
+	assert.Error(t, ValidateTemplate("{{ .Broken "))
+	assert.NoError(t, ValidateTemplate("{{ .Fine }}"))
+	assert.NotEmpty(t, DataPresets())
+}
+
+func TestBarcode(t *testing.T) {
+	ctx := context.Background()
+
+	code, err := GenerateBarcode(ctx, "https://example.com", BarcodeOptions{})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(code.DataURL, "data:image/png;base64,"))
+	assert.NotEmpty(t, code.Bytes())
+
+	_, err = GenerateBarcode(ctx, "", BarcodeOptions{})
+	assert.Error(t, err)
+
+	assert.NoError(t, ValidateBarcode("590123412345", BarcodeEAN13))
+	assert.Error(t, ValidateBarcode("12ab", BarcodeEAN13))
+	assert.NotEmpty(t, BarcodeStandards())
+}
+
+func TestMethods(t *testing.T) {
+	has := func(methods []Method, name string) bool {
+		for _, m := range methods {
+			if m.Is(name) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, h := range []HashAlgorithm{HashMD5, HashSHA3, HashHMAC, HashXXHash, HashBcrypt} {
+		assert.True(t, has(HashMethods(), string(h)), h)
+	}
+	for _, e := range []Encoding{EncodingBase64, EncodingHex, EncodingHTML, EncodingProtobuf} {
+		assert.True(t, has(EncodingMethods(), string(e)), e)
+	}
+	for _, e := range []Escaping{EscapeStringLiteral, EscapeRegex} {
+		assert.True(t, has(EscapeMethods(), string(e)), e)
+	}
+	for _, c := range []Cipher{CipherAES, CipherTripleDES, CipherRSA} {
+		assert.True(t, has(CipherMethods(), string(c)), c)
+	}
+	for _, c := range []Conversion{ConvertJSONYAML, ConvertCronText, ConvertCaseSwapping} {
+		assert.True(t, has(ConversionMethods(), string(c)), c)
+	}
+
+	aes := CipherMethods()[0]
+	assert.Equal(t, "AES", aes.Name)
+	assert.Error(t, aes.Validate(map[string]interface{}{"subMode": "Encrypt"}))
+	dir, err := aes.Direction(map[string]interface{}{"subMode": "decrypt"})
+	require.NoError(t, err)
+	assert.Equal(t, DirectionDecrypt, dir)
+}
+
+// TestResultTypesArePublic guards against result types that alias or embed
+// internal types, which callers outside the module cannot name
+func TestResultTypesArePublic(t *testing.T) {
+	seen := map[reflect.Type]bool{}
+	var check func(typ reflect.Type)
+	check = func(typ reflect.Type) {
+		for typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Map {
+			typ = typ.Elem()
+		}
+		if seen[typ] {
+			return
+		}
+		seen[typ] = true
+		assert.NotContains(t, typ.PkgPath(), "/internal/", typ.String())
+		if typ.Kind() == reflect.Struct {
+			for i := 0; i < typ.NumField(); i++ {
+				check(typ.Field(i).Type)
+			}
+		}
+	}
+
+	for _, v := range []interface{}{
+		Barcode{}, DataPreset{}, GeneratedData{}, TimeConversion{}, TimeDelta{}, TimePreset{}, Timezone{},
+		Token{}, Verification{}, Method{}, NumberConversion{}, Recipe{}, RecipeRun{}, TextStats{},
+	} {
+		check(reflect.TypeOf(v))
+	}
+}
+
+func TestRunRecipe(t *testing.T) {
+	r := Recipe{Steps: []RecipeStep{
+		{Category: "Encode - Decode", Method: string(EncodingHex), Config: map[string]interface{}{"subMode": "Decode"}},
+		{Category: "Format", Method: string(FormatJSON), Config: map[string]interface{}{"filter": ".a"}},
+	}}
+	require.NoError(t, ValidateRecipe(r))
+
+	run, err := RunRecipe(context.Background(), r, "7b2261223a317d")
+	require.NoError(t, err)
+	require.Len(t, run.Steps, 2)
+	assert.Equal(t, `{"a":1}`, run.Steps[0].Output)
+	assert.Equal(t, "1", run.Output)
+
+	data, err := MarshalRecipe(r)
+	require.NoError(t, err)
+	parsed, err := ParseRecipe(data)
+	require.NoError(t, err)
+	assert.Equal(t, r, parsed)
+
+	assert.NotEmpty(t, RecipeSteps())
+}
@@ -0,0 +1,43 @@
+// Package devtoolbox is the public Go API of the DevToolbox tools: the same
+// hashing, encoding, encryption, conversion, formatting, JWT, date and time,
+// number, mock data, barcode and text functions the app, the HTTP API and
+// the CLI use, and the recipes that chain them, for other Go programs to
+// call directly.
+//
+// Every operation is a function taking a context and, where it has
+// settings, an options struct. Failures are returned as errors; those from
+// input validation carry a code that errors.CodeOf in devtoolbox/pkg/errors
+// reports, the same code the HTTP API puts in its error envelope.
+//
+//	sum, err := devtoolbox.Hash(ctx, "hello", devtoolbox.HashSHA256, devtoolbox.HashOptions{})
+//	out, err := devtoolbox.Format(ctx, body, devtoolbox.FormatJSON, devtoolbox.FormatOptions{Filter: ".items[]"})
+//	t, err := devtoolbox.ConvertTime(ctx, "1700000000", devtoolbox.TimeOptions{Timezone: "Asia/Ho_Chi_Minh"})
+//
+// # Compatibility
+//
+// This package follows semantic versioning together with the /api/v1 HTTP
+// namespace. Within a major version:
+//
+//   - Exported functions, types and constants are not removed or renamed,
+//     and function signatures do not change.
+//   - Options structs may gain fields. A new field's zero value keeps the
+//     previous behaviour, so construct options with field names.
+//   - Result types may gain fields but do not lose or retype any. Their
+//     JSON encoding is the one the /api/v1 routes return, which the API
+//     manifest pins.
+//   - The algorithm, encoding, cipher, conversion and language names below
+//     keep their meaning. New ones may be added.
+//
+// Output that is a presentation choice rather than a computed value, such
+// as the wording of relative times or the indentation of formatted code,
+// may change in any release. Catalog functions such as TimePresets,
+// Timezones, BarcodeStandards and HashMethods describe what the tools
+// support and grow as the tools do.
+//
+// The module path is devtoolbox rather than the repository URL, so the
+// package cannot be fetched with go get. Clone the repository and point a
+// replace directive at the checkout:
+//
+//	require devtoolbox v0.0.0
+//	replace devtoolbox => ../devtoolbox
+package devtoolbox
@@ -0,0 +1,122 @@
+package devtoolbox
+
+import (
+	"context"
+	"io"
+
+	"devtoolbox/internal/converter"
+)
+
+// Encoding names a reversible text or binary encoding
+type Encoding string
+
+// Encodings
+const (
+	EncodingBase64          Encoding = "Base64"
+	EncodingBase64URL       Encoding = "Base64URL"
+	EncodingBase32          Encoding = "Base32"
+	EncodingBase58          Encoding = "Base58"
+	EncodingBase85          Encoding = "Base85"
+	EncodingHex             Encoding = "Hex"
+	EncodingURL             Encoding = "URL"
+	EncodingHTML            Encoding = "HTML Entities"
+	EncodingBinary          Encoding = "Binary"
+	EncodingMorse           Encoding = "Morse Code"
+	EncodingROT13           Encoding = "ROT13"
+	EncodingROT47           Encoding = "ROT47"
+	EncodingQuotedPrintable Encoding = "Quoted-Printable"
+	EncodingPunycode        Encoding = "Punycode"
+	EncodingBencode         Encoding = "Bencode"
+	EncodingProtobuf        Encoding = "Protobuf"
+	// EncodingGzip compresses on Encode and decompresses on Decode. Its
+	// compressed form is binary.
+	EncodingGzip Encoding = "Gzip"
+)
+
+// Escaping names a way of escaping text for embedding in source or markup
+type Escaping string
+
+// Escapings
+const (
+	EscapeStringLiteral Escaping = "String Literal"
+	EscapeUnicode       Escaping = "Unicode/Hex"
+	EscapeHTML          Escaping = "HTML/XML"
+	EscapeURL           Escaping = "URL"
+	EscapeRegex         Escaping = "Regex"
+)
+
+var (
+	encoding = converter.NewEncodingConverter()
+	escaping = converter.NewEscapeConverter()
+)
+
+func encodingRequest(input string, method Encoding, subMode string) converter.ConversionRequest {
+	return converter.ConversionRequest{
+		Input:    input,
+		Category: converter.CategoryEncode,
+		Method:   string(method),
+		Config:   map[string]interface{}{"subMode": subMode},
+	}
+}
+
+// Encode encodes input with method
+func Encode(ctx context.Context, input string, method Encoding) (string, error) {
+	return converter.ConvertContext(ctx, encoding, encodingRequest(input, method, "Encode"))
+}
+
+// Decode decodes input, which is encoded with method
+func Decode(ctx context.Context, input string, method Encoding) (string, error) {
+	return converter.ConvertContext(ctx, encoding, encodingRequest(input, method, "Decode"))
+}
+
+// EncodeReader returns r encoded with method. The Base64, Base32 and hex
+// families stream; other methods read all of r first. Closing the returned
+// reader or cancelling ctx stops the encoding, and its errors surface from
+// Read.
+func EncodeReader(ctx context.Context, r io.Reader, method Encoding) io.ReadCloser {
+	return convertReader(ctx, r, encodingRequest("", method, "Encode"))
+}
+
+// DecodeReader returns r decoded with method, as EncodeReader does
+func DecodeReader(ctx context.Context, r io.Reader, method Encoding) io.ReadCloser {
+	return convertReader(ctx, r, encodingRequest("", method, "Decode"))
+}
+
+// convertReader runs the encoding converter in the background and pipes its
+// output
+func convertReader(ctx context.Context, r io.Reader, req converter.ConversionRequest) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(converter.ConvertStream(ctx, encoding, r, pw, req))
+	}()
+	return pr
+}
+
+func escapeRequest(input string, method Escaping, subMode string) converter.ConversionRequest {
+	return converter.ConversionRequest{
+		Input:    input,
+		Category: converter.CategoryEscape,
+		Method:   string(method),
+		Config:   map[string]interface{}{"subMode": subMode},
+	}
+}
+
+// Escape escapes input with method
+func Escape(ctx context.Context, input string, method Escaping) (string, error) {
+	return converter.ConvertContext(ctx, escaping, escapeRequest(input, method, "Escape"))
+}
+
+// Unescape reverses Escape
+func Unescape(ctx context.Context, input string, method Escaping) (string, error) {
+	return converter.ConvertContext(ctx, escaping, escapeRequest(input, method, "Unescape"))
+}
+
+// EncodingMethods describes the encodings Encode and Decode accept
+func EncodingMethods() []Method {
+	return fromMethods(methods.Methods(converter.CategoryEncode))
+}
+
+// EscapeMethods describes the escapings Escape and Unescape accept
+func EscapeMethods() []Method {
+	return fromMethods(methods.Methods(converter.CategoryEscape))
+}
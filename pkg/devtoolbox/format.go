@@ -0,0 +1,45 @@
+package devtoolbox
+
+import (
+	"context"
+	"errors"
+
+	"devtoolbox/internal/codeformatter"
+)
+
+// Language names a format Format can pretty-print
+type Language string
+
+// Languages
+const (
+	FormatJSON Language = "json"
+	FormatXML  Language = "xml"
+	FormatHTML Language = "html"
+	FormatCSS  Language = "css"
+)
+
+// FormatOptions configures Format
+type FormatOptions struct {
+	// Filter selects part of the document first: a jq filter for JSON, an
+	// XPath for XML or a CSS selector for HTML
+	Filter string
+	// Minify removes insignificant whitespace instead of indenting
+	Minify bool
+}
+
+var formatter = codeformatter.NewCodeFormatterService()
+
+// Format pretty-prints or minifies input, written in language. Blank input
+// formats to the empty string.
+func Format(ctx context.Context, input string, language Language, opts FormatOptions) (string, error) {
+	resp := formatter.Format(ctx, codeformatter.FormatRequest{
+		Input:      input,
+		FormatType: string(language),
+		Filter:     opts.Filter,
+		Minify:     opts.Minify,
+	})
+	if resp.Error != "" {
+		return "", errors.New(resp.Error)
+	}
+	return resp.Output, nil
+}
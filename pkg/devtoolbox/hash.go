@@ -0,0 +1,106 @@
+package devtoolbox
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"devtoolbox/internal/converter"
+)
+
+// HashAlgorithm names a hash or password hashing function
+type HashAlgorithm string
+
+// Hash algorithms. Digests are hex encoded, except bcrypt's, which is in its
+// own modular crypt format.
+const (
+	HashMD5       HashAlgorithm = "MD5"
+	HashSHA1      HashAlgorithm = "SHA-1"
+	HashSHA224    HashAlgorithm = "SHA-224"
+	HashSHA256    HashAlgorithm = "SHA-256"
+	HashSHA384    HashAlgorithm = "SHA-384"
+	HashSHA512    HashAlgorithm = "SHA-512"
+	HashSHA3      HashAlgorithm = "SHA-3"
+	HashBLAKE2b   HashAlgorithm = "BLAKE2b"
+	HashBLAKE3    HashAlgorithm = "BLAKE3"
+	HashRIPEMD160 HashAlgorithm = "RIPEMD-160"
+	HashCRC32     HashAlgorithm = "CRC32"
+	HashAdler32   HashAlgorithm = "Adler-32"
+	HashFNV1      HashAlgorithm = "FNV-1"
+	HashFNV1a     HashAlgorithm = "FNV-1a"
+	HashXXHash    HashAlgorithm = "xxHash"
+	HashMurmur3   HashAlgorithm = "MurmurHash3"
+	// HashHMAC is HMAC-SHA256 keyed with HashOptions.Key
+	HashHMAC HashAlgorithm = "HMAC"
+	// Password hashing functions, which are slow by design and cannot be
+	// interrupted once started
+	HashBcrypt HashAlgorithm = "bcrypt"
+	HashArgon2 HashAlgorithm = "Argon2"
+	HashScrypt HashAlgorithm = "scrypt"
+)
+
+// HashOptions configures Hash and HashReader
+type HashOptions struct {
+	// Key is the HMAC key
+	Key string
+}
+
+func (o HashOptions) config() map[string]interface{} {
+	config := map[string]interface{}{}
+	if o.Key != "" {
+		config["key"] = o.Key
+	}
+	return config
+}
+
+var hashing = converter.NewHashingConverter()
+
+// Hash hashes input with algorithm
+func Hash(ctx context.Context, input string, algorithm HashAlgorithm, opts HashOptions) (string, error) {
+	return converter.ConvertContext(ctx, hashing, converter.ConversionRequest{
+		Input:    input,
+		Category: converter.CategoryHash,
+		Method:   string(algorithm),
+		Config:   opts.config(),
+	})
+}
+
+// HashReader hashes everything read from r with algorithm. Streaming
+// algorithms never hold the input in memory.
+func HashReader(ctx context.Context, r io.Reader, algorithm HashAlgorithm, opts HashOptions) (string, error) {
+	var out strings.Builder
+	err := converter.ConvertStream(ctx, hashing, r, &out, converter.ConversionRequest{
+		Category: converter.CategoryHash,
+		Method:   string(algorithm),
+		Config:   opts.config(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// HashAll hashes input with every fast algorithm, keyed by algorithm name
+func HashAll(ctx context.Context, input string) (map[string]string, error) {
+	result, err := converter.ConvertContext(ctx, hashing, converter.ConversionRequest{
+		Input:    input,
+		Category: converter.CategoryHash,
+		Method:   "All",
+		Config:   map[string]interface{}{},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var results map[string]string
+	if err := json.Unmarshal([]byte(result), &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// HashMethods describes the algorithms Hash and HashReader accept, and the
+// All method that HashAll runs. The key config field is HashOptions.Key.
+func HashMethods() []Method {
+	return fromMethods(methods.Methods(converter.CategoryHash))
+}
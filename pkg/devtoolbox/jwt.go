@@ -0,0 +1,104 @@
+package devtoolbox
+
+import (
+	"context"
+	"maps"
+
+	"devtoolbox/internal/jwt"
+)
+
+// Token is a decoded JWT
+type Token struct {
+	// Raw is the token as given
+	Raw     string
+	Header  map[string]interface{}
+	Payload map[string]interface{}
+	// Signature is the signature as given, base64url encoded
+	Signature string
+	// Valid is false when the signature could not be checked while
+	// decoding
+	Valid bool
+}
+
+// Verification is the outcome of checking a JWT's signature. A signature
+// that does not match is reported with Valid false, not as an error.
+type Verification struct {
+	Valid bool
+	// Message explains the outcome, such as why the signature does not
+	// match
+	Message string
+}
+
+// VerifyOptions configures VerifyJWT
+type VerifyOptions struct {
+	// Secret is the HMAC secret
+	Secret string
+	// Base64Secret marks Secret as base64 encoded, in the standard or URL
+	// alphabet, with or without padding
+	Base64Secret bool
+}
+
+// SignOptions configures EncodeJWT
+type SignOptions struct {
+	// Algorithm is the signing algorithm: HS256, HS384 or HS512
+	Algorithm string
+	// Secret is the HMAC secret
+	Secret string
+	// Header holds extra header fields; alg and typ are set for you
+	Header map[string]interface{}
+}
+
+var jwts = jwt.NewJWTService(jwt.NewParser())
+
+// DecodeJWT decodes token's header and payload without verifying it. A
+// token that is not well formed returns an error with code MALFORMED_TOKEN.
+func DecodeJWT(ctx context.Context, token string) (*Token, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	result, err := jwts.Decode(token)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.Err(); err != nil {
+		return nil, err
+	}
+	return &Token{
+		Raw:       result.Raw,
+		Header:    result.Header,
+		Payload:   result.Payload,
+		Signature: result.Signature,
+		Valid:     result.Valid,
+	}, nil
+}
+
+// VerifyJWT checks token's signature. A malformed token or an invalid
+// secret returns an error with code MALFORMED_TOKEN or INVALID_SECRET; a
+// signature that does not match does not.
+func VerifyJWT(ctx context.Context, token string, opts VerifyOptions) (*Verification, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	encoding := "utf8"
+	if opts.Base64Secret {
+		encoding = "base64"
+	}
+	result, err := jwts.Verify(token, opts.Secret, encoding)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.Err(); err != nil {
+		return nil, err
+	}
+	return &Verification{Valid: result.Valid, Message: result.Message}, nil
+}
+
+// EncodeJWT signs payload into a token
+func EncodeJWT(ctx context.Context, payload map[string]interface{}, opts SignOptions) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	// Signing sets alg and typ in the header it is given
+	header := maps.Clone(opts.Header)
+	return jwts.Encode(header, payload, opts.Algorithm, opts.Secret)
+}
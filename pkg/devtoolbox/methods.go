@@ -0,0 +1,127 @@
+package devtoolbox
+
+import "devtoolbox/internal/converter"
+
+// Method describes a hash algorithm, encoding, escaping, cipher or
+// conversion: its name and aliases, the directions it runs in and the
+// config it reads. Validate checks a config map against it, as the HTTP API
+// does before converting.
+type Method struct {
+	Category string `json:"category"`
+	Name     string `json:"name"`
+	// Aliases are other names the method is found by, such as those older
+	// clients send
+	Aliases []string `json:"aliases,omitempty"`
+	// Directions are the values the "subMode" config key accepts, the
+	// default first. One-way methods have none and ignore subMode.
+	Directions []Direction `json:"directions,omitempty"`
+	// Streams reports whether the method converts a stream without holding
+	// it in memory
+	Streams bool          `json:"streams,omitempty"`
+	Config  []ConfigField `json:"config,omitempty"`
+}
+
+// ConfigField describes a config key a Method reads
+type ConfigField struct {
+	Key         string    `json:"key"`
+	Type        FieldType `json:"type"`
+	Description string    `json:"description,omitempty"`
+	// Required fields must be set and not empty
+	Required bool `json:"required,omitempty"`
+	// Direction, if set, is the only direction the field is read in
+	Direction Direction `json:"direction,omitempty"`
+}
+
+// Direction is the way a two-way Method runs, such as Encode or Decode
+type Direction string
+
+// Directions
+const (
+	DirectionEncode   Direction = "Encode"
+	DirectionDecode   Direction = "Decode"
+	DirectionEncrypt  Direction = "Encrypt"
+	DirectionDecrypt  Direction = "Decrypt"
+	DirectionEscape   Direction = "Escape"
+	DirectionUnescape Direction = "Unescape"
+)
+
+// FieldType is the type a ConfigField's value must have
+type FieldType string
+
+// Field types
+const (
+	FieldString FieldType = "string"
+	FieldNumber FieldType = "number"
+	FieldBool   FieldType = "boolean"
+)
+
+// Is reports whether name is the method's name or one of its aliases,
+// ignoring case
+func (m Method) Is(name string) bool {
+	return m.info().Is(name)
+}
+
+// Direction returns the direction config's subMode selects, or the default
+// when it is not set. One-way methods return the empty Direction.
+func (m Method) Direction(config map[string]interface{}) (Direction, error) {
+	dir, err := m.info().Direction(config)
+	return Direction(dir), err
+}
+
+// Validate checks config against the method's schema. Keys the method does
+// not read are ignored.
+func (m Method) Validate(config map[string]interface{}) error {
+	return m.info().Validate(config)
+}
+
+// info converts m back to the registry's description
+func (m Method) info() converter.MethodInfo {
+	info := converter.MethodInfo{
+		Category: m.Category,
+		Name:     m.Name,
+		Aliases:  m.Aliases,
+		Streams:  m.Streams,
+	}
+	for _, d := range m.Directions {
+		info.Directions = append(info.Directions, converter.Direction(d))
+	}
+	for _, f := range m.Config {
+		info.Config = append(info.Config, converter.ConfigField{
+			Key:         f.Key,
+			Type:        converter.FieldType(f.Type),
+			Description: f.Description,
+			Required:    f.Required,
+			Direction:   converter.Direction(f.Direction),
+		})
+	}
+	return info
+}
+
+// fromMethods converts the registry's descriptions
+func fromMethods(infos []converter.MethodInfo) []Method {
+	out := make([]Method, len(infos))
+	for i, info := range infos {
+		m := Method{
+			Category: info.Category,
+			Name:     info.Name,
+			Aliases:  info.Aliases,
+			Streams:  info.Streams,
+		}
+		for _, d := range info.Directions {
+			m.Directions = append(m.Directions, Direction(d))
+		}
+		for _, f := range info.Config {
+			m.Config = append(m.Config, ConfigField{
+				Key:         f.Key,
+				Type:        FieldType(f.Type),
+				Description: f.Description,
+				Required:    f.Required,
+				Direction:   Direction(f.Direction),
+			})
+		}
+		out[i] = m
+	}
+	return out
+}
+
+var methods = converter.NewRegistry()
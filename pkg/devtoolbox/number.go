@@ -0,0 +1,111 @@
+package devtoolbox
+
+import (
+	"context"
+	"errors"
+
+	"devtoolbox/internal/numberconverter"
+)
+
+// NumberBase names the base a number is written in
+type NumberBase string
+
+// Number bases
+const (
+	Binary      NumberBase = "binary"
+	Octal       NumberBase = "octal"
+	Decimal     NumberBase = "decimal"
+	Hexadecimal NumberBase = "hex"
+)
+
+// NumberConversion is a number in every base with its interpretations as
+// bytes, a character, a color, an IPv4 address, a file size and a
+// timestamp
+type NumberConversion struct {
+	Binary  string `json:"binary"`
+	Decimal string `json:"decimal"`
+	Hex     string `json:"hex"`
+	Octal   string `json:"octal"`
+	// Bits are the low 8 bits, most significant first, and BitValues the
+	// place value of each
+	Bits      []int           `json:"bits"`
+	BitValues []int           `json:"bitValues"`
+	Bytes     NumberBytes     `json:"bytes"`
+	ASCII     NumberChar      `json:"ascii"`
+	Color     NumberColor     `json:"color"`
+	IPv4      NumberIPv4      `json:"ipv4"`
+	FileSize  NumberFileSize  `json:"fileSize"`
+	Timestamp NumberTimestamp `json:"timestamp"`
+	// Percentage is the number as a percentage of 255
+	Percentage int `json:"percentage"`
+}
+
+// NumberBytes is a number as 4 big-endian bytes
+type NumberBytes struct {
+	// BigEndian holds the bytes in hex, such as ["00", "00", "00", "FF"]
+	BigEndian []string `json:"bigEndian"`
+	// Highlighted is the index of the least significant byte
+	Highlighted int `json:"highlighted"`
+}
+
+// NumberChar is a number read as a character code
+type NumberChar struct {
+	Char      string `json:"char"`
+	Code      int    `json:"code"`
+	Printable bool   `json:"printable"`
+}
+
+// NumberColor is a number read as an RGB color
+type NumberColor struct {
+	Hex   string `json:"hex"`
+	Valid bool   `json:"valid"`
+}
+
+// NumberIPv4 is a number read as an IPv4 address
+type NumberIPv4 struct {
+	Address string `json:"address"`
+	// Type is "normal", "broadcast", "multicast" or "private"
+	Type string `json:"type"`
+}
+
+// NumberFileSize is a number read as a size in bytes
+type NumberFileSize struct {
+	Bytes int     `json:"bytes"`
+	KB    float64 `json:"kb"`
+	MB    float64 `json:"mb"`
+	Human string  `json:"human"`
+}
+
+// NumberTimestamp is a number read as Unix seconds and as a duration
+type NumberTimestamp struct {
+	DateTime string `json:"datetime"`
+	Duration string `json:"duration"`
+}
+
+var numbers = numberconverter.NewNumberConverterService()
+
+// ConvertNumber reads value, written in base, as a 64-bit integer
+func ConvertNumber(ctx context.Context, value string, base NumberBase) (NumberConversion, error) {
+	if err := ctx.Err(); err != nil {
+		return NumberConversion{}, err
+	}
+	resp := numbers.Convert(numberconverter.ConvertRequest{Value: value, Base: string(base)})
+	if resp.Error != "" {
+		return NumberConversion{}, errors.New(resp.Error)
+	}
+	return NumberConversion{
+		Binary:     resp.Binary,
+		Decimal:    resp.Decimal,
+		Hex:        resp.Hex,
+		Octal:      resp.Octal,
+		Bits:       resp.Bits,
+		BitValues:  resp.BitValues,
+		Bytes:      NumberBytes(resp.Bytes),
+		ASCII:      NumberChar(resp.ASCII),
+		Color:      NumberColor(resp.Color),
+		IPv4:       NumberIPv4(resp.IPv4),
+		FileSize:   NumberFileSize(resp.FileSize),
+		Timestamp:  NumberTimestamp(resp.Timestamp),
+		Percentage: resp.Percentage,
+	}, nil
+}
@@ -0,0 +1,254 @@
+package devtoolbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"devtoolbox/internal/codeformatter"
+	"devtoolbox/internal/converter"
+	sharedErrors "devtoolbox/pkg/errors"
+)
+
+// Recipe error codes
+const (
+	// ErrCodeInvalidRecipe reports a recipe without steps, a step naming a
+	// method RecipeSteps does not describe or with config its schema
+	// rejects, and a file that is not a recipe
+	ErrCodeInvalidRecipe = "INVALID_RECIPE"
+	// ErrCodeStepFailed reports a step that failed to run
+	ErrCodeStepFailed = "STEP_FAILED"
+)
+
+var errNoSteps = sharedErrors.NewDomainError(ErrCodeInvalidRecipe, "recipe has no steps").WithKind(sharedErrors.KindInvalid)
+
+// categoryFormat is the category of the code formatter's steps
+const categoryFormat = "Format"
+
+// Recipe is an ordered list of steps, each run on the output of the one
+// before. Its JSON encoding is the recipe file format.
+//
+//	run, err := devtoolbox.RunRecipe(ctx, devtoolbox.Recipe{Steps: []devtoolbox.RecipeStep{
+//		{Category: "Encode - Decode", Method: "Base64", Config: map[string]interface{}{"subMode": "Decode"}},
+//		{Category: "Encode - Decode", Method: "Gzip", Config: map[string]interface{}{"subMode": "Decode"}},
+//		{Category: "Format", Method: "JSON", Config: map[string]interface{}{"filter": ".items[]"}},
+//	}}, input)
+type Recipe struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Steps       []RecipeStep `json:"steps"`
+}
+
+// RecipeStep runs one of the methods RecipeSteps describes, with its config
+type RecipeStep struct {
+	Category string                 `json:"category"`
+	Method   string                 `json:"method"`
+	Config   map[string]interface{} `json:"config,omitempty"`
+}
+
+// RecipeRun is the output of every step of a run, and of the last one.
+// Binary outputs are base64 encoded and flagged.
+type RecipeRun struct {
+	Steps  []RecipeStepResult `json:"steps"`
+	Output string             `json:"output"`
+}
+
+// RecipeStepResult is the output of one step of a run
+type RecipeStepResult struct {
+	Category string `json:"category"`
+	Method   string `json:"method"`
+	Output   string `json:"output"`
+	// Base64 is set when Output is binary and so reported base64 encoded.
+	// The next step still receives the raw bytes.
+	Base64 bool `json:"base64,omitempty"`
+	// Error is why the step failed
+	Error    string `json:"error,omitempty"`
+	Duration int64  `json:"durationMs"`
+}
+
+// recipeMethods are the methods steps run: the built-in converters and the
+// code formatter
+var recipeMethods = func() *converter.Registry {
+	registry := converter.NewRegistry()
+	registry.Register(categoryFormat, &formatConverter{})
+	return registry
+}()
+
+// RunRecipe runs r's steps on input. A step that fails stops the run; the
+// steps run so far, the failed one included, are returned with the error.
+func RunRecipe(ctx context.Context, r Recipe, input string) (RecipeRun, error) {
+	if err := ValidateRecipe(r); err != nil {
+		return RecipeRun{Steps: []RecipeStepResult{}}, err
+	}
+
+	run := RecipeRun{Steps: make([]RecipeStepResult, 0, len(r.Steps))}
+	out := input
+	for i, s := range r.Steps {
+		start := time.Now()
+		result, err := converter.ConvertContext(ctx, recipeMethods, s.request(out))
+		step := RecipeStepResult{
+			Category: s.Category,
+			Method:   s.Method,
+			Duration: time.Since(start).Milliseconds(),
+		}
+		if err != nil {
+			step.Error = err.Error()
+			run.Steps = append(run.Steps, step)
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return run, ctxErr
+			}
+			return run, stepError(ErrCodeStepFailed, i, s, err)
+		}
+		step.Output, step.Base64 = printable(result)
+		run.Steps = append(run.Steps, step)
+		out = result
+	}
+	run.Output, _ = printable(out)
+	return run, nil
+}
+
+// ValidateRecipe checks that r has steps and that each names a method
+// RecipeSteps describes, with a config that matches its schema
+func ValidateRecipe(r Recipe) error {
+	if len(r.Steps) == 0 {
+		return errNoSteps
+	}
+	for i, s := range r.Steps {
+		if err := recipeMethods.Validate(s.request("")); err != nil {
+			return stepError(ErrCodeInvalidRecipe, i, s, err)
+		}
+	}
+	return nil
+}
+
+// ParseRecipe reads a recipe file. Unknown fields are errors, so a file
+// that is not a recipe is not taken for an empty one.
+func ParseRecipe(data []byte) (Recipe, error) {
+	var r Recipe
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&r); err != nil {
+		return Recipe{}, sharedErrors.NewDomainError(ErrCodeInvalidRecipe, fmt.Sprintf("invalid recipe file: %v", err)).
+			WithKind(sharedErrors.KindInvalid)
+	}
+	return r, nil
+}
+
+// MarshalRecipe writes r as a recipe file
+func MarshalRecipe(r Recipe) ([]byte, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// RecipeSteps describes the methods a recipe step may run: every hash,
+// encoding, cipher, conversion and escaping, and the code formatter's
+// languages in the Format category
+func RecipeSteps() []Method {
+	return fromMethods(recipeMethods.Methods(""))
+}
+
+// request is the conversion request step s makes on input
+func (s RecipeStep) request(input string) converter.ConversionRequest {
+	config := s.Config
+	if config == nil {
+		config = map[string]interface{}{}
+	}
+	return converter.ConversionRequest{
+		Input:    input,
+		Category: s.Category,
+		Method:   s.Method,
+		Config:   config,
+	}
+}
+
+// stepError reports a problem with step i of a recipe. An INVALID_RECIPE
+// step is invalid input; a step that failed to run is not.
+func stepError(code string, i int, s RecipeStep, err error) error {
+	kind := sharedErrors.KindFailed
+	if code == ErrCodeInvalidRecipe {
+		kind = sharedErrors.KindInvalid
+	}
+	return sharedErrors.NewDomainError(code, fmt.Sprintf("step %d (%s): %v", i+1, s.Method, err)).
+		WithKind(kind).
+		WithDetails(map[string]interface{}{
+			"step":     i,
+			"category": s.Category,
+			"method":   s.Method,
+		})
+}
+
+// printable returns out as it is when it is text, and base64 encoded when
+// it is binary, which JSON cannot carry
+func printable(out string) (string, bool) {
+	if utf8.ValidString(out) {
+		return out, false
+	}
+	return base64.StdEncoding.EncodeToString([]byte(out)), true
+}
+
+// formatConverter runs the code formatter as recipe steps
+type formatConverter struct{}
+
+var formatMethods = []converter.MethodInfo{
+	{Name: "JSON", Config: []converter.ConfigField{
+		{Key: "filter", Type: converter.FieldString, Description: "jq filter applied first"},
+		{Key: "minify", Type: converter.FieldBool, Description: "Remove whitespace instead of indenting"},
+	}},
+	{Name: "XML", Config: []converter.ConfigField{
+		{Key: "filter", Type: converter.FieldString, Description: "XPath selecting the elements to keep"},
+		{Key: "minify", Type: converter.FieldBool, Description: "Remove whitespace instead of indenting"},
+	}},
+	{Name: "HTML", Config: []converter.ConfigField{
+		{Key: "filter", Type: converter.FieldString, Description: "CSS selector picking the elements to keep"},
+		{Key: "minify", Type: converter.FieldBool, Description: "Remove whitespace instead of indenting"},
+	}},
+	{Name: "CSS", Config: []converter.ConfigField{
+		{Key: "minify", Type: converter.FieldBool, Description: "Remove whitespace instead of indenting"},
+	}},
+}
+
+func (c *formatConverter) Methods() []converter.MethodInfo {
+	return formatMethods
+}
+
+func (c *formatConverter) Convert(req converter.ConversionRequest) (string, error) {
+	return c.ConvertContext(context.Background(), req)
+}
+
+// ConvertContext formats req.Input in the language req.Method names
+func (c *formatConverter) ConvertContext(ctx context.Context, req converter.ConversionRequest) (string, error) {
+	var method *converter.MethodInfo
+	for i := range formatMethods {
+		if formatMethods[i].Is(req.Method) {
+			method = &formatMethods[i]
+		}
+	}
+	if method == nil {
+		return "", fmt.Errorf("format method %s not supported", req.Method)
+	}
+	if err := method.Validate(req.Config); err != nil {
+		return "", err
+	}
+
+	filter, _ := req.Config["filter"].(string)
+	minify, _ := req.Config["minify"].(bool)
+	resp := formatter.Format(ctx, codeformatter.FormatRequest{
+		Input:      req.Input,
+		FormatType: strings.ToLower(method.Name),
+		Filter:     filter,
+		Minify:     minify,
+	})
+	if resp.Error != "" {
+		return "", errors.New(resp.Error)
+	}
+	return resp.Output, nil
+}
@@ -0,0 +1,131 @@
+package devtoolbox
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"net/url"
+	"testing"
+
+	"devtoolbox/internal/converter"
+	sharedErrors "devtoolbox/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeStep(category, method string) RecipeStep {
+	return RecipeStep{Category: category, Method: method, Config: map[string]interface{}{"subMode": "Decode"}}
+}
+
+func TestRunRecipe_BinarySteps(t *testing.T) {
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	_, err := zw.Write([]byte(`{"items":[{"id":1},{"id":2}]}`))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	input := url.QueryEscape(base64.StdEncoding.EncodeToString(compressed.Bytes()))
+
+	r := Recipe{Name: "Unpack", Steps: []RecipeStep{
+		decodeStep(converter.CategoryEncode, "URL"),
+		decodeStep(converter.CategoryEncode, "Base64"),
+		decodeStep(converter.CategoryEncode, "Gzip"),
+		{Category: categoryFormat, Method: "JSON"},
+		{Category: categoryFormat, Method: "json", Config: map[string]interface{}{"filter": ".items[].id", "minify": true}},
+	}}
+
+	run, err := RunRecipe(context.Background(), r, input)
+	require.NoError(t, err)
+	require.Len(t, run.Steps, 5)
+
+	assert.Equal(t, base64.StdEncoding.EncodeToString(compressed.Bytes()), run.Steps[0].Output)
+	assert.False(t, run.Steps[0].Base64)
+	// The gzip stream is binary, so it is reported base64 encoded
+	assert.True(t, run.Steps[1].Base64)
+	assert.Equal(t, run.Steps[0].Output, run.Steps[1].Output)
+	assert.Equal(t, `{"items":[{"id":1},{"id":2}]}`, run.Steps[2].Output)
+	assert.Contains(t, run.Steps[3].Output, "\n  \"items\"")
+	assert.Equal(t, run.Steps[4].Output, run.Output)
+	assert.Equal(t, "[1,2]", run.Output)
+}
+
+func TestRunRecipe_StopsAtFailedStep(t *testing.T) {
+	r := Recipe{Steps: []RecipeStep{
+		decodeStep(converter.CategoryEncode, "Base64"),
+		{Category: categoryFormat, Method: "JSON"},
+		{Category: converter.CategoryHash, Method: "MD5"},
+	}}
+
+	run, err := RunRecipe(context.Background(), r, base64.StdEncoding.EncodeToString([]byte("not json")))
+	require.Error(t, err)
+	assert.Equal(t, ErrCodeStepFailed, sharedErrors.CodeOf(err))
+	assert.Equal(t, 1, sharedErrors.DetailsOf(err)["step"])
+
+	require.Len(t, run.Steps, 2)
+	assert.Equal(t, "not json", run.Steps[0].Output)
+	assert.NotEmpty(t, run.Steps[1].Error)
+	assert.Empty(t, run.Output)
+}
+
+func TestValidateRecipe(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []RecipeStep
+		step  interface{}
+	}{
+		{"no steps", nil, nil},
+		{"unknown method", []RecipeStep{{Category: converter.CategoryHash, Method: "SHA-257"}}, 0},
+		{"unknown category", []RecipeStep{{Category: "Compress", Method: "Gzip"}}, 0},
+		{"missing required key", []RecipeStep{
+			{Category: converter.CategoryHash, Method: "MD5"},
+			{Category: converter.CategoryEncrypt, Method: "XOR"},
+		}, 1},
+		{"mistyped config", []RecipeStep{{Category: categoryFormat, Method: "JSON", Config: map[string]interface{}{"minify": "yes"}}}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRecipe(Recipe{Steps: tt.steps})
+			require.Error(t, err)
+			assert.Equal(t, ErrCodeInvalidRecipe, sharedErrors.CodeOf(err))
+			if tt.step != nil {
+				assert.Equal(t, tt.step, sharedErrors.DetailsOf(err)["step"])
+			}
+		})
+	}
+}
+
+func TestRecipeSteps(t *testing.T) {
+	var categories []string
+	for _, m := range RecipeSteps() {
+		if len(categories) == 0 || categories[len(categories)-1] != m.Category {
+			categories = append(categories, m.Category)
+		}
+	}
+	assert.Equal(t, []string{
+		converter.CategoryEncode, converter.CategoryEncrypt, converter.CategoryHash,
+		converter.CategoryConvert, converter.CategoryEscape, categoryFormat,
+	}, categories)
+}
+
+func TestParseRecipe(t *testing.T) {
+	r := Recipe{
+		Name:        "Pretty JWT payload",
+		Description: "Decode a JWT and pretty-print it",
+		Steps: []RecipeStep{
+			{Category: converter.CategoryEncode, Method: "JWT Decode"},
+			{Category: categoryFormat, Method: "JSON", Config: map[string]interface{}{"filter": ".payload"}},
+		},
+	}
+
+	data, err := MarshalRecipe(r)
+	require.NoError(t, err)
+	parsed, err := ParseRecipe(data)
+	require.NoError(t, err)
+	assert.Equal(t, r, parsed)
+
+	_, err = ParseRecipe([]byte(`{"name": "theme", "colors": {}}`))
+	require.Error(t, err)
+	assert.Equal(t, ErrCodeInvalidRecipe, sharedErrors.CodeOf(err))
+}
@@ -0,0 +1,87 @@
+package devtoolbox
+
+import (
+	"context"
+
+	"devtoolbox/internal/textutilities"
+)
+
+// TextCase names a letter case ConvertCase produces
+type TextCase string
+
+// Text cases
+const (
+	CaseUpper    TextCase = "upper"
+	CaseLower    TextCase = "lower"
+	CaseCamel    TextCase = "camel"
+	CasePascal   TextCase = "pascal"
+	CaseSnake    TextCase = "snake"
+	CaseKebab    TextCase = "kebab"
+	CaseSentence TextCase = "sentence"
+)
+
+// TextStats are the counts TextStatistics reports. Chars counts bytes, as
+// the app does.
+type TextStats struct {
+	Chars     int `json:"chars"`
+	Words     int `json:"words"`
+	Lines     int `json:"lines"`
+	Bytes     int `json:"bytes"`
+	Sentences int `json:"sentences"`
+}
+
+// SortOptions configures SortLines
+type SortOptions struct {
+	// Reverse sorts in descending order
+	Reverse bool
+}
+
+// SortLines sorts the lines of input
+func SortLines(ctx context.Context, input string, opts SortOptions) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return textutilities.SortLines(input, opts.Reverse), nil
+}
+
+// RemoveDuplicateLines keeps the first of each set of identical lines
+func RemoveDuplicateLines(ctx context.Context, input string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return textutilities.RemoveDuplicates(input), nil
+}
+
+// TrimLines removes leading and trailing whitespace from every line
+func TrimLines(ctx context.Context, input string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return textutilities.TrimLines(input), nil
+}
+
+// RemoveEmptyLines removes lines that are empty or only whitespace
+func RemoveEmptyLines(ctx context.Context, input string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return textutilities.RemoveEmptyLines(input), nil
+}
+
+// ConvertCase converts input to textCase. An unknown case leaves it
+// unchanged.
+func ConvertCase(ctx context.Context, input string, textCase TextCase) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return textutilities.ConvertCase(input, string(textCase)), nil
+}
+
+// TextStatistics counts the characters, words, lines, bytes and sentences of
+// input
+func TextStatistics(ctx context.Context, input string) (TextStats, error) {
+	if err := ctx.Err(); err != nil {
+		return TextStats{}, err
+	}
+	return TextStats(textutilities.GetStats(input)), nil
+}
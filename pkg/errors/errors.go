@@ -1,17 +1,73 @@
 package errors
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+// Kind classifies an error by what went wrong, so transports such as the
+// HTTP router can report a status without knowing every code
+type Kind int
+
+// Kinds. The zero Kind is a well-formed request the tool could not process.
+const (
+	KindFailed Kind = iota
+	// KindInvalid is a malformed or out-of-range input
+	KindInvalid
+	// KindNotFound is a request for something that does not exist
+	KindNotFound
+	// KindConflict is a request that clashes with something that exists
+	KindConflict
+)
 
 // DomainError represents a domain-level error with a code and message
 type DomainError struct {
 	Code    string
 	Message string
+	Details map[string]interface{}
+	Kind    Kind
 }
 
 func (e *DomainError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Code, e.Message)
 }
 
+// ErrorCode returns the machine-readable error code
+func (e *DomainError) ErrorCode() string {
+	return e.Code
+}
+
+// ErrorKind returns the error's Kind
+func (e *DomainError) ErrorKind() Kind {
+	return e.Kind
+}
+
+// WithDetails returns a copy of the error carrying structured details
+func (e *DomainError) WithDetails(details map[string]interface{}) *DomainError {
+	return &DomainError{
+		Code:    e.Code,
+		Message: e.Message,
+		Details: details,
+		Kind:    e.Kind,
+	}
+}
+
+// WithKind returns a copy of the error of the given Kind
+func (e *DomainError) WithKind(kind Kind) *DomainError {
+	return &DomainError{
+		Code:    e.Code,
+		Message: e.Message,
+		Details: e.Details,
+		Kind:    kind,
+	}
+}
+
+// Is reports whether target is a DomainError with the same code
+func (e *DomainError) Is(target error) bool {
+	t, ok := target.(*DomainError)
+	return ok && t.Code == e.Code
+}
+
 // NewDomainError creates a new domain error
 func NewDomainError(code, message string) *DomainError {
 	return &DomainError{
@@ -20,6 +76,64 @@ func NewDomainError(code, message string) *DomainError {
 	}
 }
 
+// Coded is implemented by errors that carry a machine-readable code
+type Coded interface {
+	error
+	ErrorCode() string
+}
+
+// CodeOf returns the code of the first coded error in err's chain, or ""
+func CodeOf(err error) string {
+	var coded Coded
+	if errors.As(err, &coded) {
+		return coded.ErrorCode()
+	}
+	return ""
+}
+
+// Kinded is implemented by errors that carry a Kind
+type Kinded interface {
+	error
+	ErrorKind() Kind
+}
+
+// KindOf returns the Kind of the first kinded error in err's chain, or
+// KindFailed
+func KindOf(err error) Kind {
+	var kinded Kinded
+	if errors.As(err, &kinded) {
+		return kinded.ErrorKind()
+	}
+	return KindFailed
+}
+
+// MessageOf returns the message of the first DomainError in err's chain,
+// without the code it leads with, for reports that carry the code
+// separately. Other errors return their full text.
+func MessageOf(err error) string {
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr.Message
+	}
+	return err.Error()
+}
+
+// DetailsOf returns the details of the first DomainError in err's chain
+func DetailsOf(err error) map[string]interface{} {
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr.Details
+	}
+	return nil
+}
+
+// Failed is implemented by response DTOs that report errors in an Error
+// field for the desktop bindings. Err exposes the typed error so the HTTP
+// router can map it to a status code.
+type Failed interface {
+	Err() error
+}
+
 // Common error codes
 const (
 	ErrCodeInvalidInput  = "INVALID_INPUT"
@@ -27,6 +141,7 @@ const (
 	ErrCodeInvalidSecret = "INVALID_SECRET"
 	ErrCodeParseFailed   = "PARSE_FAILED"
 	ErrCodeVerifyFailed  = "VERIFY_FAILED"
+	ErrCodeInternal      = "INTERNAL"
 )
 
 // Common domain errors
@@ -0,0 +1,36 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOf(t *testing.T) {
+	domainErr := NewDomainError(ErrCodeInvalidInput, "bad input").
+		WithKind(KindInvalid).
+		WithDetails(map[string]interface{}{"field": "input"})
+
+	tests := []struct {
+		name    string
+		err     error
+		code    string
+		message string
+		kind    Kind
+		details map[string]interface{}
+	}{
+		{"domain", domainErr, ErrCodeInvalidInput, "bad input", KindInvalid, map[string]interface{}{"field": "input"}},
+		{"wrapped", fmt.Errorf("decoding: %w", domainErr), ErrCodeInvalidInput, "bad input", KindInvalid, map[string]interface{}{"field": "input"}},
+		{"plain", errors.New("boom"), "", "boom", KindFailed, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.code, CodeOf(tt.err))
+			assert.Equal(t, tt.message, MessageOf(tt.err))
+			assert.Equal(t, tt.kind, KindOf(tt.err))
+			assert.Equal(t, tt.details, DetailsOf(tt.err))
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Application event names
+const (
+	SettingsChanged = "settings:changed"
+	SpotlightOpened = "spotlight:opened"
+)
+
+// subscriberBuffer is how many events a slow subscriber may fall behind
+// before it starts missing them
+const subscriberBuffer = 64
+
+// Event is an application event published by a backend service
+type Event struct {
+	ID   uint64      `json:"id"`
+	Name string      `json:"name"`
+	Data interface{} `json:"data"`
+	Time time.Time   `json:"time"`
+}
+
+// Sink receives every published event synchronously, e.g. to forward it to Wails
+type Sink func(Event)
+
+// Bus fans events out from backend services to sinks and subscribers.
+// The zero value is not usable; create one with NewBus.
+type Bus struct {
+	mu          sync.RWMutex
+	nextID      uint64
+	sinks       []Sink
+	subscribers map[chan Event]struct{}
+}
+
+// NewBus creates an event bus
+func NewBus() *Bus {
+	return &Bus{subscribers: map[chan Event]struct{}{}}
+}
+
+// AddSink registers a sink for all future events
+func (b *Bus) AddSink(sink Sink) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sinks = append(b.sinks, sink)
+}
+
+// Subscribe returns a channel of future events and a function that
+// unsubscribes and closes it. Events are dropped for subscribers that fall
+// more than a small buffer behind, so a stuck client cannot block publishers.
+func (b *Bus) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// Publish sends an event to every sink and subscriber. It is safe to call on
+// a nil Bus, which drops the event.
+func (b *Bus) Publish(name string, data interface{}) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	b.nextID++
+	event := Event{ID: b.nextID, Name: name, Data: data, Time: time.Now()}
+	sinks := b.sinks
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+	b.mu.Unlock()
+
+	for _, sink := range sinks {
+		sink(event)
+	}
+}
@@ -0,0 +1,72 @@
+package events
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBus_PublishFansOut(t *testing.T) {
+	bus := NewBus()
+
+	var sunk []Event
+	bus.AddSink(func(e Event) { sunk = append(sunk, e) })
+
+	first, unsubFirst := bus.Subscribe()
+	defer unsubFirst()
+	second, unsubSecond := bus.Subscribe()
+	defer unsubSecond()
+
+	bus.Publish(SettingsChanged, map[string]interface{}{"setting": "closeMinimizesToTray", "value": true})
+	bus.Publish(SpotlightOpened, nil)
+
+	for _, ch := range []<-chan Event{first, second} {
+		e := <-ch
+		assert.Equal(t, SettingsChanged, e.Name)
+		assert.Equal(t, uint64(1), e.ID)
+		e = <-ch
+		assert.Equal(t, SpotlightOpened, e.Name)
+		assert.Equal(t, uint64(2), e.ID)
+	}
+
+	require.Len(t, sunk, 2)
+	assert.Equal(t, SettingsChanged, sunk[0].Name)
+	assert.False(t, sunk[0].Time.IsZero())
+}
+
+func TestBus_Unsubscribe(t *testing.T) {
+	bus := NewBus()
+	ch, unsubscribe := bus.Subscribe()
+
+	unsubscribe()
+	unsubscribe() // idempotent
+
+	_, open := <-ch
+	assert.False(t, open)
+	bus.Publish(SpotlightOpened, nil) // must not panic on the closed channel
+}
+
+func TestBus_SlowSubscriberDoesNotBlock(t *testing.T) {
+	bus := NewBus()
+	ch, unsubscribe := bus.Subscribe()
+	defer unsubscribe()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < subscriberBuffer*2; i++ {
+			bus.Publish(SettingsChanged, i)
+		}
+	}()
+	wg.Wait()
+
+	assert.Len(t, ch, subscriberBuffer)
+}
+
+func TestBus_NilPublish(t *testing.T) {
+	var bus *Bus
+	assert.NotPanics(t, func() { bus.Publish(SpotlightOpened, nil) })
+}
@@ -0,0 +1,132 @@
+// Package instance keeps a single copy of the app running. The first
+// instance listens on a Unix socket that only the current user can reach;
+// later invocations find it there and hand their request over instead of
+// starting another app and HTTP server.
+package instance
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ErrRunning means another instance holds the lock
+var ErrRunning = errors.New("another instance is running")
+
+// ErrNotRunning means no instance is listening for hand-offs
+var ErrNotRunning = errors.New("no instance is running")
+
+// maxRequestSize bounds a hand-off, which carries a tool's input
+const maxRequestSize = 16 << 20
+
+// dialTimeout bounds connecting to and hearing back from the running instance
+const dialTimeout = 5 * time.Second
+
+// Request is what an invocation hands to the running instance
+type Request struct {
+	// Tool is the ID of the tool to open; empty just brings the app forward
+	Tool string `json:"tool,omitempty"`
+	// Input prefills the tool's input
+	Input string `json:"input,omitempty"`
+}
+
+// reply is the running instance's answer to a Request
+type reply struct {
+	Error string `json:"error,omitempty"`
+}
+
+// Lock is the single-instance lock, held by the running instance
+type Lock struct {
+	ln net.Listener
+}
+
+// Acquire takes the lock at path. It returns ErrRunning when another
+// instance holds it; a socket left behind by one that exited is replaced.
+func Acquire(path string) (*Lock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		if _, statErr := os.Stat(path); statErr != nil {
+			return nil, err
+		}
+		if conn, err := net.DialTimeout("unix", path, dialTimeout); err == nil {
+			conn.Close()
+			return nil, ErrRunning
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("remove stale socket: %w", err)
+		}
+		if ln, err = net.Listen("unix", path); err != nil {
+			return nil, err
+		}
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		ln.Close()
+		return nil, err
+	}
+	return &Lock{ln: ln}, nil
+}
+
+// Serve calls handle with each request handed over, one at a time, until the
+// lock is closed. An error from handle is reported to the sender.
+func (l *Lock) Serve(handle func(Request) error) error {
+	for {
+		conn, err := l.ln.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		serveConn(conn, handle)
+	}
+}
+
+func serveConn(conn net.Conn, handle func(Request) error) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(dialTimeout))
+
+	var req Request
+	var resp reply
+	if err := json.NewDecoder(io.LimitReader(conn, maxRequestSize)).Decode(&req); err != nil {
+		resp.Error = fmt.Sprintf("invalid request: %v", err)
+	} else if err := handle(req); err != nil {
+		resp.Error = err.Error()
+	}
+	json.NewEncoder(conn).Encode(resp)
+}
+
+// Close releases the lock and removes the socket
+func (l *Lock) Close() error {
+	return l.ln.Close()
+}
+
+// Send hands req to the instance holding the lock at path and waits for it
+// to be handled. It returns ErrNotRunning when there is none.
+func Send(path string, req Request) error {
+	conn, err := net.DialTimeout("unix", path, dialTimeout)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNotRunning, err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(dialTimeout))
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return err
+	}
+	var resp reply
+	if err := json.NewDecoder(bufio.NewReader(conn)).Decode(&resp); err != nil {
+		return fmt.Errorf("read reply: %w", err)
+	}
+	if resp.Error != "" {
+		return errors.New(resp.Error)
+	}
+	return nil
+}
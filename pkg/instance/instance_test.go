@@ -0,0 +1,59 @@
+package instance
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLock_HandOff(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "instance.sock")
+	lock, err := Acquire(path)
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	received := make(chan Request, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- lock.Serve(func(req Request) error {
+			if req.Tool == "missing" {
+				return errors.New("unknown tool")
+			}
+			received <- req
+			return nil
+		})
+	}()
+
+	_, err = Acquire(path)
+	assert.ErrorIs(t, err, ErrRunning)
+
+	require.NoError(t, Send(path, Request{Tool: "jwt", Input: "token"}))
+	assert.Equal(t, Request{Tool: "jwt", Input: "token"}, <-received)
+	assert.EqualError(t, Send(path, Request{Tool: "missing"}), "unknown tool")
+
+	require.NoError(t, lock.Close())
+	require.NoError(t, <-done)
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "the socket is removed on close")
+}
+
+func TestSend_NotRunning(t *testing.T) {
+	err := Send(filepath.Join(t.TempDir(), "instance.sock"), Request{})
+	assert.ErrorIs(t, err, ErrNotRunning)
+}
+
+func TestAcquire_ReplacesStaleSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "instance.sock")
+	require.NoError(t, os.WriteFile(path, nil, 0600))
+
+	lock, err := Acquire(path)
+	require.NoError(t, err)
+	defer lock.Close()
+}
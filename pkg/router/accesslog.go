@@ -0,0 +1,49 @@
+package router
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errorCodeKey is the gin context key where failed responses record their
+// error code for the access log
+const errorCodeKey = "devtoolbox.errorCode"
+
+// accessLog writes one record per request with its method, route, status,
+// duration and error code. Bodies and query strings are never logged: they
+// carry tool input and may carry the API token.
+func accessLog(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("route", route),
+			slog.Int("status", c.Writer.Status()),
+			slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+		}
+		if code := c.GetString(errorCodeKey); code != "" {
+			attrs = append(attrs, slog.String("error", code))
+		}
+		logger.LogAttrs(c.Request.Context(), statusLevel(c.Writer.Status()), "request", attrs...)
+	}
+}
+
+// statusLevel logs server errors as errors and client errors as warnings, so
+// a warn log level keeps only failed requests
+func statusLevel(status int) slog.Level {
+	switch {
+	case status >= 500:
+		return slog.LevelError
+	case status >= 400:
+		return slog.LevelWarn
+	}
+	return slog.LevelInfo
+}
@@ -0,0 +1,49 @@
+package router
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	sharedErrors "devtoolbox/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrCodeUnauthorized is returned when /api/*, /rpc or /metrics is called without the bearer token
+const ErrCodeUnauthorized = "UNAUTHORIZED"
+
+// tokenQueryParam carries the token for clients that cannot set headers,
+// such as EventSource and links opened in a browser
+const tokenQueryParam = "access_token"
+
+// requireToken rejects /api/*, /rpc and /metrics requests that do not
+// present token. The explorer page is static and stays public so it can
+// prompt for the token. The Unix socket is protected by its file permissions
+// instead.
+func requireToken(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		protected := path == "/rpc" || path == "/metrics" ||
+			strings.HasPrefix(path, "/api/") && path != "/api/docs"
+		if !protected || fromSocket(c.Request) {
+			c.Next()
+			return
+		}
+
+		if subtle.ConstantTimeCompare([]byte(requestToken(c)), []byte(token)) != 1 {
+			c.Header("WWW-Authenticate", `Bearer realm="devtoolbox"`)
+			respondError(c, sharedErrors.NewDomainError(ErrCodeUnauthorized, "missing or invalid API token"), nil)
+			return
+		}
+		c.Next()
+	}
+}
+
+// requestToken returns the bearer token from the Authorization header or query
+func requestToken(c *gin.Context) string {
+	scheme, token, ok := strings.Cut(c.GetHeader("Authorization"), " ")
+	if ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+	return c.Query(tokenQueryParam)
+}
@@ -0,0 +1,103 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_RequireToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := DefaultConfig()
+	cfg.Token = "secret-token"
+	server := NewServerWithConfig(cfg)
+	assert.NoError(t, server.Register(&testServiceForServer{}))
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		header     string
+		expectCode int
+	}{
+		{"no token", "POST", "/api/test-service-for-server/process", "", http.StatusUnauthorized},
+		{"wrong token", "POST", "/api/test-service-for-server/process", "Bearer nope", http.StatusUnauthorized},
+		{"wrong scheme", "POST", "/api/test-service-for-server/process", "Basic secret-token", http.StatusUnauthorized},
+		{"bearer token", "POST", "/api/test-service-for-server/process", "Bearer secret-token", http.StatusOK},
+		{"query token", "POST", "/api/test-service-for-server/process?access_token=secret-token", "", http.StatusOK},
+		{"spec needs token", "GET", "/api/openapi.json", "", http.StatusUnauthorized},
+		{"metrics need token", "GET", "/metrics", "", http.StatusUnauthorized},
+		{"metrics with token", "GET", "/metrics", "Bearer secret-token", http.StatusOK},
+		{"explorer is public", "GET", "/api/docs", "", http.StatusOK},
+		{"health is public", "GET", "/health", "", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, strings.NewReader(`{"input":"hi"}`))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			server.Engine().ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectCode, w.Code)
+			if tt.expectCode == http.StatusUnauthorized {
+				errBody := decodeEnvelope(t, w.Body.Bytes(), nil)
+				if assert.NotNil(t, errBody) {
+					assert.Equal(t, ErrCodeUnauthorized, errBody.Code)
+				}
+				assert.Contains(t, w.Header().Get("WWW-Authenticate"), "Bearer")
+			}
+		})
+	}
+}
+
+func TestServer_RequireTokenAllowsPreflight(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := DefaultConfig()
+	cfg.Token = "secret-token"
+	server := NewServerWithConfig(cfg)
+	assert.NoError(t, server.Register(&testServiceForServer{}))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("OPTIONS", "/api/test-service-for-server/process", nil)
+	req.Header.Set("Origin", "wails://wails")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	req.Header.Set("Access-Control-Request-Headers", "Authorization")
+	server.Engine().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "wails://wails", w.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestServer_Config(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("defaults to loopback", func(t *testing.T) {
+		assert.Equal(t, "127.0.0.1:8081", NewServer().Addr(8081))
+	})
+
+	t.Run("empty host binds every interface", func(t *testing.T) {
+		server := NewServerWithConfig(Config{AllowOrigins: DefaultAllowOrigins})
+		assert.Equal(t, ":8081", server.Addr(8081))
+	})
+
+	t.Run("wildcard origin", func(t *testing.T) {
+		server := NewServerWithConfig(Config{Host: "::1", AllowOrigins: []string{"*"}})
+		assert.Equal(t, "[::1]:8081", server.Addr(8081))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("OPTIONS", "/health", nil)
+		req.Header.Set("Origin", "http://example.com")
+		req.Header.Set("Access-Control-Request-Method", "POST")
+		server.Engine().ServeHTTP(w, req)
+
+		assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
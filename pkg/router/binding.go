@@ -5,8 +5,17 @@ type RequestWrapper struct {
 	Args []interface{} `json:"args"`
 }
 
-// ResponseWrapper standardizes API responses
+// ResponseWrapper standardizes API responses. Exactly one of Data and Error
+// is set, except for in-band failures, which return the service's response
+// as Data alongside the Error.
 type ResponseWrapper struct {
-	Data  interface{} `json:"data,omitempty"`
-	Error string      `json:"error,omitempty"`
+	Data  interface{} `json:"data"`
+	Error *ErrorBody  `json:"error"`
+}
+
+// ErrorBody describes why a call failed
+type ErrorBody struct {
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Details map[string]interface{} `json:"details,omitempty"`
 }
@@ -0,0 +1,170 @@
+package router
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+
+	"devtoolbox/pkg/tools"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ToolInfo describes a tool in GET /api/tools: its registry metadata, its
+// frontend route and the operations its service exposes
+type ToolInfo struct {
+	tools.Tool
+	Path       string          `json:"path"`
+	Operations []OperationInfo `json:"operations"`
+}
+
+// OperationInfo describes one service method of a tool
+type OperationInfo struct {
+	// Name is "Service.Method", also the JSON-RPC method
+	Name string `json:"name"`
+	// Path is the REST route
+	Path string `json:"path"`
+	// MCPTool is the name of the method's MCP tool
+	MCPTool string `json:"mcpTool"`
+	// Input describes the request payload, with named structs under $defs
+	Input *Schema `json:"input"`
+	// Output describes the result, or is nil for methods without one
+	Output *Schema `json:"output,omitempty"`
+}
+
+// RegisterTools registers the service of every tool in the registry, with
+// its stream operations, and keeps the registry to describe them at
+// GET /api/tools
+func (r *Router) RegisterTools(registry *tools.Registry) error {
+	for _, tool := range registry.All() {
+		if tool.Service == nil {
+			continue
+		}
+		if err := r.Register(tool.Service); err != nil {
+			return err
+		}
+		if tool.Streams != nil {
+			r.register(tool.ServiceName(), tool.Streams)
+		}
+	}
+	r.tools = registry
+	return nil
+}
+
+// Catalog describes the registered tools in display order
+func (r *Router) Catalog() []ToolInfo {
+	if r.tools == nil {
+		return []ToolInfo{}
+	}
+
+	all := r.tools.All()
+	catalog := make([]ToolInfo, 0, len(all))
+	for _, tool := range all {
+		info := ToolInfo{Tool: tool, Path: tool.Path(), Operations: []OperationInfo{}}
+		if service := tool.ServiceName(); service != "" {
+			for _, ep := range r.endpoints {
+				if ep.service != service {
+					continue
+				}
+				info.Operations = append(info.Operations, OperationInfo{
+					Name:    ep.name(),
+					Path:    ep.path,
+					MCPTool: ep.toolName(),
+					Input:   ep.inputSchema(),
+					Output:  ep.outputSchema(),
+				})
+			}
+		}
+		catalog = append(catalog, info)
+	}
+	return catalog
+}
+
+// toolFor returns the registry tool whose service is named service
+func (r *Router) toolFor(service string) (tools.Tool, bool) {
+	if r.tools == nil {
+		return tools.Tool{}, false
+	}
+	for _, tool := range r.tools.All() {
+		if tool.ServiceName() == service {
+			return tool, true
+		}
+	}
+	return tools.Tool{}, false
+}
+
+// serveCatalog answers GET /api/tools
+func (r *Router) serveCatalog(c *gin.Context) {
+	c.JSON(http.StatusOK, ResponseWrapper{Data: r.Catalog()})
+}
+
+// argumentName returns the request field holding p. Unnamed single
+// parameters use the "value" convention.
+func (ep endpoint) argumentName(p param) string {
+	if len(ep.params) == 1 && p.name == "" {
+		return "value"
+	}
+	return p.label()
+}
+
+// inputSchema describes the arguments as a standalone schema: a struct
+// parameter's fields, or one property per parameter
+func (ep endpoint) inputSchema() *Schema {
+	defs := map[string]*Schema{}
+	var schema *Schema
+
+	if len(ep.params) == 1 && ep.params[0].argType.Kind() == reflect.Struct {
+		schema = structSchema(ep.params[0].argType, defs)
+	} else {
+		schema = &Schema{Type: "object", Properties: map[string]*Schema{}}
+		for _, p := range ep.params {
+			key := ep.argumentName(p)
+			schema.Properties[key] = schemaFor(p.argType, defs)
+			if p.required() {
+				schema.Required = append(schema.Required, key)
+			}
+		}
+	}
+	return standalone(schema, defs)
+}
+
+// outputSchema describes the result as a standalone schema, or returns nil
+// for methods without one
+func (ep endpoint) outputSchema() *Schema {
+	out := firstResult(ep.fn.Type())
+	switch {
+	case out == nil:
+		return nil
+	case out == readerType:
+		return binarySchema()
+	}
+	defs := map[string]*Schema{}
+	return standalone(schemaFor(out, defs), defs)
+}
+
+// standalone moves the named structs schema refers to under its own $defs
+func standalone(schema *Schema, defs map[string]*Schema) *Schema {
+	rebaseRefs(schema)
+	for _, def := range defs {
+		rebaseRefs(def)
+	}
+	if len(defs) > 0 {
+		schema.Defs = defs
+	}
+	return schema
+}
+
+// rebaseRefs points component references at the schema's own $defs
+func rebaseRefs(s *Schema) {
+	if s == nil {
+		return
+	}
+	if name, ok := strings.CutPrefix(s.Ref, componentRefPrefix); ok {
+		s.Ref = "#/$defs/" + name
+	}
+	rebaseRefs(s.Items)
+	rebaseRefs(s.AdditionalProperties)
+	for _, prop := range s.Properties {
+		rebaseRefs(prop)
+	}
+}
@@ -0,0 +1,85 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"devtoolbox/pkg/tools"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_ToolCatalog(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	server := NewServer()
+	server.SetParamNames(map[string][]string{"MultiParamService.Combine": {"a", "b", "c"}})
+
+	registry := tools.NewRegistry(
+		tools.Tool{ID: "echo", Name: "Echo", Keywords: []string{"repeat"}, Categories: []string{"Text"}, Service: &TestService{}},
+		tools.Tool{ID: "combine", Name: "Combine", Categories: []string{"Text"}, Service: &MultiParamService{}},
+		tools.Tool{ID: "diff", Name: "Text Diff", Categories: []string{"Text"}},
+	)
+	require.NoError(t, server.RegisterTools(registry))
+
+	// The registry's services are routed
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/multi-param-service/combine", strings.NewReader(`{"a":"x","b":"y","c":"z"}`))
+	req.Header.Set("Content-Type", "application/json")
+	server.Engine().ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/tools", nil)
+	server.Engine().ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Data []struct {
+			ID         string   `json:"id"`
+			Name       string   `json:"name"`
+			Keywords   []string `json:"keywords"`
+			Categories []string `json:"categories"`
+			Path       string   `json:"path"`
+			Operations []struct {
+				Name    string                 `json:"name"`
+				Path    string                 `json:"path"`
+				MCPTool string                 `json:"mcpTool"`
+				Input   map[string]interface{} `json:"input"`
+				Output  map[string]interface{} `json:"output"`
+			} `json:"operations"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Data, 3)
+
+	echo := resp.Data[0]
+	assert.Equal(t, "echo", echo.ID)
+	assert.Equal(t, "/tool/echo", echo.Path)
+	assert.Equal(t, []string{"repeat"}, echo.Keywords)
+	require.Len(t, echo.Operations, 1)
+	assert.Equal(t, "TestService.Echo", echo.Operations[0].Name)
+	assert.Equal(t, "/api/test-service/echo", echo.Operations[0].Path)
+	assert.Equal(t, "TestService_Echo", echo.Operations[0].MCPTool)
+	assert.Contains(t, echo.Operations[0].Input["properties"], "message")
+	assert.Equal(t, "#/$defs/router.EchoResponse", echo.Operations[0].Output["$ref"])
+	assert.Contains(t, echo.Operations[0].Output["$defs"], "router.EchoResponse")
+
+	combine := resp.Data[1].Operations[0]
+	assert.Equal(t, []interface{}{"a", "b", "c"}, combine.Input["required"])
+	assert.Equal(t, "string", combine.Output["type"])
+
+	// Frontend-only tools are listed without operations
+	assert.Equal(t, "diff", resp.Data[2].ID)
+	assert.Empty(t, resp.Data[2].Operations)
+}
+
+func TestRouter_CatalogWithoutRegistry(t *testing.T) {
+	router := New(gin.New())
+	require.NoError(t, router.Register(&TestService{}))
+	assert.Empty(t, router.Catalog())
+}
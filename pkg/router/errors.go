@@ -0,0 +1,132 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	sharedErrors "devtoolbox/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Router-level error codes
+const (
+	ErrCodeInvalidRequest   = "INVALID_REQUEST"
+	ErrCodeMissingParameter = "MISSING_PARAMETER"
+	ErrCodeOperationFailed  = "OPERATION_FAILED"
+	ErrCodeRequestTooLarge  = "REQUEST_TOO_LARGE"
+	ErrCodeTimeout          = "TIMEOUT"
+	ErrCodeCanceled         = "CANCELED"
+	ErrCodeUnsupportedType  = "UNSUPPORTED_MEDIA_TYPE"
+)
+
+// statusClientClosedRequest is the de facto status for requests the client
+// abandoned; nobody reads it, but access logs can tell them apart
+const statusClientClosedRequest = 499
+
+// codeStatus maps the router's and pkg/errors' codes to HTTP statuses.
+// Other codes take their status from the error's Kind.
+var codeStatus = map[string]int{
+	// Malformed requests
+	ErrCodeInvalidRequest:   http.StatusBadRequest,
+	ErrCodeMissingParameter: http.StatusBadRequest,
+	ErrCodeRequestTooLarge:  http.StatusRequestEntityTooLarge,
+	ErrCodeTimeout:          http.StatusGatewayTimeout,
+	ErrCodeCanceled:         statusClientClosedRequest,
+	ErrCodeUnauthorized:     http.StatusUnauthorized,
+	ErrCodeUnsupportedType:  http.StatusUnsupportedMediaType,
+
+	// pkg/errors
+	sharedErrors.ErrCodeInvalidInput:  http.StatusBadRequest,
+	sharedErrors.ErrCodeInvalidToken:  http.StatusBadRequest,
+	sharedErrors.ErrCodeInvalidSecret: http.StatusBadRequest,
+	sharedErrors.ErrCodeInternal:      http.StatusInternalServerError,
+}
+
+// kindStatus maps error kinds to HTTP statuses. KindFailed, and errors
+// without a kind, are reported as 422: the request was well-formed but the
+// tool could not process it.
+var kindStatus = map[sharedErrors.Kind]int{
+	sharedErrors.KindInvalid:  http.StatusBadRequest,
+	sharedErrors.KindNotFound: http.StatusNotFound,
+	sharedErrors.KindConflict: http.StatusConflict,
+}
+
+// statusFor returns the HTTP status for err, whose code is code
+func statusFor(err error, code string) int {
+	if status, ok := codeStatus[code]; ok {
+		return status
+	}
+	if status, ok := kindStatus[sharedErrors.KindOf(err)]; ok {
+		return status
+	}
+	return http.StatusUnprocessableEntity
+}
+
+// newErrorBody describes err for the response envelope
+func newErrorBody(err error) *ErrorBody {
+	code := errorCode(err)
+	return &ErrorBody{
+		Code:    code,
+		Message: sharedErrors.MessageOf(err),
+		Details: sharedErrors.DetailsOf(err),
+	}
+}
+
+// errorCode returns err's code. Uncoded errors are OPERATION_FAILED unless
+// they come from a body limit or an ended context.
+func errorCode(err error) string {
+	if code := sharedErrors.CodeOf(err); code != "" {
+		return code
+	}
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		return ErrCodeRequestTooLarge
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrCodeTimeout
+	case errors.Is(err, context.Canceled):
+		return ErrCodeCanceled
+	}
+	return ErrCodeOperationFailed
+}
+
+// respondData writes a successful envelope, or the bare result when the
+// client asks for text or YAML
+func respondData(c *gin.Context, data interface{}) {
+	switch format := responseFormat(c); format {
+	case mimeText:
+		respondFormatted(c, http.StatusOK, format, func() ([]byte, error) { return plainText(data) })
+	case mimeYAML:
+		respondFormatted(c, http.StatusOK, format, func() ([]byte, error) { return marshalYAML(data) })
+	default:
+		c.JSON(http.StatusOK, ResponseWrapper{Data: data})
+	}
+}
+
+// respondError writes a failed envelope. data is the service's response for
+// in-band failures and nil otherwise. Text clients get "CODE: message".
+func respondError(c *gin.Context, err error, data interface{}) {
+	body := newErrorBody(err)
+	c.Set(errorCodeKey, body.Code)
+	status := statusFor(err, body.Code)
+
+	switch format := responseFormat(c); format {
+	case mimeText:
+		respondFormatted(c, status, format, func() ([]byte, error) {
+			return []byte(body.Code + ": " + body.Message + "\n"), nil
+		})
+	case mimeYAML:
+		respondFormatted(c, status, format, func() ([]byte, error) {
+			return marshalYAML(ResponseWrapper{Data: data, Error: body})
+		})
+	default:
+		c.AbortWithStatusJSON(status, ResponseWrapper{Data: data, Error: body})
+	}
+}
+
+// recoverPanic reports a panicking service method as an INTERNAL error
+func recoverPanic(c *gin.Context, recovered interface{}) {
+	respondError(c, sharedErrors.NewDomainError(sharedErrors.ErrCodeInternal, "internal error"), nil)
+}
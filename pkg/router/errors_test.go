@@ -0,0 +1,139 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	sharedErrors "devtoolbox/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type codedError struct {
+	code string
+	kind sharedErrors.Kind
+}
+
+func (e *codedError) Error() string                { return "coded failure" }
+func (e *codedError) ErrorCode() string            { return e.code }
+func (e *codedError) ErrorKind() sharedErrors.Kind { return e.kind }
+
+type failedResponse struct {
+	Output string `json:"output"`
+	Error  string `json:"error,omitempty"`
+	err    error
+}
+
+func (r failedResponse) Err() error { return r.err }
+
+type erroringService struct{}
+
+func (s *erroringService) Domain() (string, error) {
+	return "", sharedErrors.NewDomainError(sharedErrors.ErrCodeInvalidInput, "bad input").
+		WithDetails(map[string]interface{}{"field": "input"})
+}
+
+func (s *erroringService) Coded() (string, error) {
+	return "", &codedError{code: "MALFORMED_TOKEN", kind: sharedErrors.KindInvalid}
+}
+
+func (s *erroringService) Missing() (string, error) {
+	return "", fmt.Errorf("loading: %w", sharedErrors.NewDomainError("RECIPE_NOT_FOUND", "recipe not found").
+		WithKind(sharedErrors.KindNotFound))
+}
+
+func (s *erroringService) Conflict() (string, error) {
+	return "", sharedErrors.NewDomainError("RECIPE_EXISTS", "name taken").WithKind(sharedErrors.KindConflict)
+}
+
+func (s *erroringService) Untyped() (string, error) {
+	return "", errors.New("boom")
+}
+
+func (s *erroringService) InBand() failedResponse {
+	err := sharedErrors.NewDomainError("INVALID_TEMPLATE", "invalid template syntax").WithKind(sharedErrors.KindInvalid)
+	return failedResponse{Error: err.Error(), err: err}
+}
+
+func (s *erroringService) Panics() string {
+	panic("unexpected")
+}
+
+func TestRouter_ErrorEnvelope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	server := NewServer()
+	assert.NoError(t, server.Register(&erroringService{}))
+
+	tests := []struct {
+		method         string
+		expectedStatus int
+		expectedCode   string
+		expectedData   bool
+	}{
+		{"domain", http.StatusBadRequest, sharedErrors.ErrCodeInvalidInput, false},
+		{"coded", http.StatusBadRequest, "MALFORMED_TOKEN", false},
+		{"missing", http.StatusNotFound, "RECIPE_NOT_FOUND", false},
+		{"conflict", http.StatusConflict, "RECIPE_EXISTS", false},
+		{"untyped", http.StatusUnprocessableEntity, ErrCodeOperationFailed, false},
+		{"in-band", http.StatusBadRequest, "INVALID_TEMPLATE", true},
+		{"panics", http.StatusInternalServerError, sharedErrors.ErrCodeInternal, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/erroring-service/"+tt.method, nil)
+			server.Engine().ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var data *failedResponse
+			errBody := decodeEnvelope(t, w.Body.Bytes(), &data)
+			if assert.NotNil(t, errBody) {
+				assert.Equal(t, tt.expectedCode, errBody.Code)
+				assert.NotEmpty(t, errBody.Message)
+			}
+			assert.Equal(t, tt.expectedData, data != nil)
+		})
+	}
+}
+
+func TestRouter_ErrorDetails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	server := NewServer()
+	assert.NoError(t, server.Register(&erroringService{}))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/erroring-service/domain", nil)
+	server.Engine().ServeHTTP(w, req)
+
+	errBody := decodeEnvelope(t, w.Body.Bytes(), nil)
+	if assert.NotNil(t, errBody) {
+		assert.Equal(t, "bad input", errBody.Message)
+		assert.Equal(t, "input", errBody.Details["field"])
+	}
+}
+
+func TestRouter_MissingParameterDetails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	router := New(r)
+	assert.NoError(t, router.Register(&MultiParamService{}))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/multi-param-service/combine", strings.NewReader(`{"arg0": "a"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	errBody := decodeEnvelope(t, w.Body.Bytes(), nil)
+	if assert.NotNil(t, errBody) {
+		assert.Equal(t, ErrCodeMissingParameter, errBody.Code)
+		assert.Equal(t, "arg1", errBody.Details["parameter"])
+	}
+}
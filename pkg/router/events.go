@@ -0,0 +1,83 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"devtoolbox/pkg/events"
+
+	"github.com/gin-gonic/gin"
+)
+
+// eventKeepAlive is how often an idle event stream sends a comment so proxies
+// and clients keep the connection open
+const eventKeepAlive = 15 * time.Second
+
+// SetEventBus streams bus events to HTTP clients at GET /api/events
+func (s *Server) SetEventBus(bus *events.Bus) {
+	s.engine.GET("/api/events", eventStream(bus, s.closingChan))
+}
+
+// eventStream serves bus events as Server-Sent Events. Each message's event
+// field is the event name and its data is the JSON-encoded events.Event.
+// Clients may limit the stream with ?name=settings:changed,spotlight:opened.
+// Streams end when the channel closing returns is closed, so they do not
+// hold up shutdown.
+func eventStream(bus *events.Bus, closing func() <-chan struct{}) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		done := closing()
+		names := map[string]bool{}
+		for _, value := range c.QueryArray("name") {
+			for _, name := range strings.Split(value, ",") {
+				if name = strings.TrimSpace(name); name != "" {
+					names[name] = true
+				}
+			}
+		}
+
+		ch, unsubscribe := bus.Subscribe()
+		defer unsubscribe()
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		c.Header("X-Accel-Buffering", "no")
+		c.Status(http.StatusOK)
+
+		// A comment tells the client the stream is open before any event arrives
+		io.WriteString(c.Writer, ": connected\n\n")
+		c.Writer.Flush()
+
+		ticker := time.NewTicker(eventKeepAlive)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-c.Request.Context().Done():
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+				io.WriteString(c.Writer, ": ping\n\n")
+				c.Writer.Flush()
+			case event, ok := <-ch:
+				if !ok {
+					return
+				}
+				if len(names) > 0 && !names[event.Name] {
+					continue
+				}
+				data, err := json.Marshal(event)
+				if err != nil {
+					continue
+				}
+				fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Name, data)
+				c.Writer.Flush()
+			}
+		}
+	}
+}
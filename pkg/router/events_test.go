@@ -0,0 +1,86 @@
+package router
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"devtoolbox/pkg/events"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readSSEMessage reads lines up to the next blank line, skipping comments
+func readSSEMessage(t *testing.T, r *bufio.Reader) map[string]string {
+	t.Helper()
+	msg := map[string]string{}
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimRight(line, "\n")
+		if line == "" {
+			if len(msg) > 0 {
+				return msg
+			}
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			msg["comment"] = strings.TrimSpace(line[1:])
+			continue
+		}
+		field, value, _ := strings.Cut(line, ": ")
+		msg[field] = value
+	}
+}
+
+func TestServer_EventStream(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	bus := events.NewBus()
+	server := NewServer()
+	server.SetEventBus(bus)
+
+	ts := httptest.NewServer(server.Engine())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/events?name=" + events.SettingsChanged)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	assert.Equal(t, "connected", readSSEMessage(t, reader)["comment"])
+
+	// Filtered out, then delivered
+	bus.Publish(events.SpotlightOpened, nil)
+	bus.Publish(events.SettingsChanged, map[string]interface{}{"setting": "closeMinimizesToTray", "value": false})
+
+	msg := readSSEMessage(t, reader)
+	assert.Equal(t, events.SettingsChanged, msg["event"])
+	assert.Equal(t, "2", msg["id"])
+
+	var event events.Event
+	require.NoError(t, json.Unmarshal([]byte(msg["data"]), &event))
+	assert.Equal(t, events.SettingsChanged, event.Name)
+	assert.Equal(t, map[string]interface{}{"setting": "closeMinimizesToTray", "value": false}, event.Data)
+}
+
+func TestServer_EventStreamRequiresToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := DefaultConfig()
+	cfg.Token = "secret-token"
+	server := NewServerWithConfig(cfg)
+	server.SetEventBus(events.NewBus())
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/events", nil)
+	server.Engine().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,162 @@
+package router
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Cache policies for the frontend. Vite fingerprints everything under
+// assets/, so those files never change; pages and the other files are
+// revalidated so a new build is picked up on reload.
+const (
+	cacheImmutable  = "public, max-age=31536000, immutable"
+	cacheRevalidate = "no-cache"
+)
+
+// frontendPages maps the SPA entry points to their HTML file; any other
+// extensionless path falls back to the main page for client-side routing
+var frontendPages = map[string]string{
+	"spotlight": "spotlight.html",
+}
+
+// frontendAPIScript points the frontend at the server that served it
+var frontendAPIScript = []byte(`<script>window.__DEVTOOLBOX_API__ = { baseURL: window.location.origin };</script>`)
+
+// frontend serves a built single-page app
+type frontend struct {
+	files fs.FS
+	pages map[string][]byte
+}
+
+// ServeFrontend serves the built frontend in files (the contents of
+// frontend/dist) on every GET route the API does not handle. Unknown
+// extensionless paths get index.html, so client-side routes survive a reload.
+func (s *Server) ServeFrontend(files fs.FS) error {
+	f := &frontend{files: files, pages: map[string][]byte{}}
+	index, err := fs.ReadFile(files, "index.html")
+	if err != nil {
+		return fmt.Errorf("frontend: %w", err)
+	}
+	f.pages["index.html"] = injectScript(index, frontendAPIScript)
+
+	// Builds without an entry point fall back to index.html for its route
+	for _, name := range frontendPages {
+		page, err := fs.ReadFile(files, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("frontend: %w", err)
+		}
+		f.pages[name] = injectScript(page, frontendAPIScript)
+	}
+
+	s.engine.NoRoute(f.serve)
+	return nil
+}
+
+// serve answers a request no route matched. API paths keep Gin's plain 404.
+func (f *frontend) serve(c *gin.Context) {
+	method := c.Request.Method
+	if method != http.MethodGet && method != http.MethodHead || isAPIPath(c.Request.URL.Path) {
+		return
+	}
+
+	name := strings.TrimPrefix(path.Clean(c.Request.URL.Path), "/")
+	if name != "" && name != "index.html" {
+		if info, err := fs.Stat(f.files, name); err == nil && !info.IsDir() {
+			f.serveFile(c, name)
+			return
+		}
+		// A missing file is a 404, not the app
+		if path.Ext(name) != "" {
+			return
+		}
+	}
+
+	page := "index.html"
+	if file, ok := frontendPages[strings.SplitN(name, "/", 2)[0]]; ok && f.pages[file] != nil {
+		page = file
+	}
+	c.Header("Cache-Control", cacheRevalidate)
+	c.Data(http.StatusOK, "text/html; charset=utf-8", f.pages[page])
+}
+
+// serveFile serves a static file with its cache policy
+func (f *frontend) serveFile(c *gin.Context, name string) {
+	if page, ok := f.pages[name]; ok {
+		c.Header("Cache-Control", cacheRevalidate)
+		c.Data(http.StatusOK, "text/html; charset=utf-8", page)
+		return
+	}
+
+	if strings.HasPrefix(name, "assets/") {
+		c.Header("Cache-Control", cacheImmutable)
+	} else {
+		c.Header("Cache-Control", cacheRevalidate)
+	}
+	c.FileFromFS(name, http.FS(f.files))
+}
+
+// isAPIPath reports whether p belongs to the API rather than the frontend
+func isAPIPath(p string) bool {
+	return p == "/rpc" || p == "/metrics" || p == "/health" || p == "/api" ||
+		strings.HasPrefix(p, "/api/")
+}
+
+// InjectScript returns middleware that inserts script at the start of the
+// <head> of the HTML pages next serves, so it runs before the frontend's
+// own scripts. Other responses pass through unchanged.
+func InjectScript(script []byte) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Pages are extensionless SPA routes or .html files
+			if ext := path.Ext(r.URL.Path); ext != "" && ext != ".html" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			buf := &bufferedResponse{header: http.Header{}, status: http.StatusOK}
+			next.ServeHTTP(buf, r)
+
+			body := buf.body.Bytes()
+			if strings.HasPrefix(buf.header.Get("Content-Type"), "text/html") {
+				body = injectScript(body, script)
+				buf.header.Del("Content-Length")
+			}
+
+			for key, values := range buf.header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(buf.status)
+			w.Write(body)
+		})
+	}
+}
+
+// bufferedResponse captures a response so it can be rewritten
+type bufferedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bufferedResponse) Header() http.Header         { return b.header }
+func (b *bufferedResponse) WriteHeader(status int)      { b.status = status }
+func (b *bufferedResponse) Write(p []byte) (int, error) { return b.body.Write(p) }
+
+// injectScript inserts script at the start of page's <head>
+func injectScript(page, script []byte) []byte {
+	i := bytes.Index(page, []byte("<head>"))
+	if i < 0 {
+		return page
+	}
+	i += len("<head>")
+	return append(page[:i:i], append(script, page[i:]...)...)
+}
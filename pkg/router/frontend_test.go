@@ -0,0 +1,118 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFrontendServer(t *testing.T) *Server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	server := NewServer()
+	require.NoError(t, server.Register(&TestService{}))
+	require.NoError(t, server.ServeFrontend(fstest.MapFS{
+		"index.html":           {Data: []byte("<html><head><title>main</title></head></html>")},
+		"spotlight.html":       {Data: []byte("<html><head><title>spotlight</title></head></html>")},
+		"assets/main-abc12.js": {Data: []byte("console.log(1)")},
+		"favicon.svg":          {Data: []byte("<svg/>")},
+	}))
+	return server
+}
+
+func TestServer_Frontend(t *testing.T) {
+	server := newFrontendServer(t)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		status   int
+		contains string
+		cache    string
+	}{
+		{"root", "GET", "/", http.StatusOK, "<title>main</title>", cacheRevalidate},
+		{"client route", "GET", "/tool/jwt", http.StatusOK, "<title>main</title>", cacheRevalidate},
+		{"index file", "GET", "/index.html", http.StatusOK, "<title>main</title>", cacheRevalidate},
+		{"spotlight", "GET", "/spotlight", http.StatusOK, "<title>spotlight</title>", cacheRevalidate},
+		{"spotlight file", "GET", "/spotlight.html", http.StatusOK, "<title>spotlight</title>", cacheRevalidate},
+		{"fingerprinted asset", "GET", "/assets/main-abc12.js", http.StatusOK, "console.log(1)", cacheImmutable},
+		{"other file", "GET", "/favicon.svg", http.StatusOK, "<svg/>", cacheRevalidate},
+		{"missing asset", "GET", "/assets/gone.js", http.StatusNotFound, "", ""},
+		{"unknown API route", "GET", "/api/nope", http.StatusNotFound, "", ""},
+		{"non-GET", "POST", "/tool/jwt", http.StatusNotFound, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			server.Engine().ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Contains(t, w.Body.String(), tt.contains)
+			assert.Equal(t, tt.cache, w.Header().Get("Cache-Control"))
+		})
+	}
+}
+
+func TestServer_FrontendInjectsAPIBase(t *testing.T) {
+	server := newFrontendServer(t)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	server.Engine().ServeHTTP(w, req)
+
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "<head>"+string(frontendAPIScript)+"<title>")
+
+	// API routes still win
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/health", nil)
+	server.Engine().ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `"status":"ok"`)
+}
+
+func TestServer_FrontendPages(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	server := NewServer()
+	assert.Error(t, server.ServeFrontend(fstest.MapFS{"spotlight.html": {Data: []byte("<html></html>")}}))
+
+	// Without spotlight.html its route gets the main page
+	require.NoError(t, server.ServeFrontend(fstest.MapFS{"index.html": {Data: []byte("<html><head></head>main</html>")}}))
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/spotlight", nil)
+	server.Engine().ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "main")
+}
+
+func TestInjectScript(t *testing.T) {
+	script := []byte("<script>injected</script>")
+	handler := InjectScript(script)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/app.js" {
+			w.Header().Set("Content-Type", "text/javascript")
+			w.Write([]byte("<head>"))
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Content-Length", "42")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("<html><head><title>page</title></head></html>"))
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/tool/jwt", nil))
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Length"))
+	assert.Equal(t, "<html><head><script>injected</script><title>page</title></head></html>", w.Body.String())
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/app.js", nil))
+	assert.Equal(t, "<head>", w.Body.String())
+}
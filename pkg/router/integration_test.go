@@ -123,8 +123,8 @@ func TestIntegration_JWTDecode(t *testing.T) {
 			assert.Equal(t, http.StatusOK, w.Code)
 
 			var resp mockDecodeResponse
-			err := json.Unmarshal(w.Body.Bytes(), &resp)
-			assert.NoError(t, err)
+			errBody := decodeEnvelope(t, w.Body.Bytes(), &resp)
+			assert.Nil(t, errBody)
 			assert.Equal(t, tt.expectValid, resp.Valid)
 		})
 	}
@@ -136,16 +136,31 @@ func TestIntegration_CORSHeaders(t *testing.T) {
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("OPTIONS", "/health", nil)
-	req.Header.Set("Origin", "http://localhost:3000")
+	req.Header.Set("Origin", "wails://wails")
 	req.Header.Set("Access-Control-Request-Method", "POST")
 
 	server.Engine().ServeHTTP(w, req)
 
-	// Check CORS headers are present
-	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	// Check CORS headers are present for the desktop webview
+	assert.Equal(t, "wails://wails", w.Header().Get("Access-Control-Allow-Origin"))
 	assert.Contains(t, w.Header().Get("Access-Control-Allow-Methods"), "POST")
 }
 
+func TestIntegration_CORSRejectsUnknownOrigin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	server := NewServer()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("OPTIONS", "/health", nil)
+	req.Header.Set("Origin", "http://localhost:3000")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+
+	server.Engine().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+}
+
 func TestIntegration_InvalidJSON(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	server := NewServer()
@@ -159,4 +174,8 @@ func TestIntegration_InvalidJSON(t *testing.T) {
 	server.Engine().ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
+	errBody := decodeEnvelope(t, w.Body.Bytes(), nil)
+	if assert.NotNil(t, errBody) {
+		assert.Equal(t, ErrCodeInvalidRequest, errBody.Code)
+	}
 }
@@ -0,0 +1,88 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+
+	sharedErrors "devtoolbox/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// Limits bounds the work a single call can cause. Zero values mean no limit.
+type Limits struct {
+	// MaxBodyBytes caps JSON request bodies, including /rpc
+	MaxBodyBytes int64
+	// MaxUploadBytes caps raw and multipart uploads
+	MaxUploadBytes int64
+	// Timeout is the deadline for each call
+	Timeout time.Duration
+	// RouteTimeouts overrides Timeout per "Service.Method"; zero disables it
+	RouteTimeouts map[string]time.Duration
+	// MaxNotifications caps the JSON-RPC notifications running in the
+	// background at once; further ones wait for a slot before the call that
+	// sent them returns. Zero disables the cap.
+	MaxNotifications int
+}
+
+// DefaultLimits allows 8 MiB JSON bodies, 1 GiB uploads, 30 seconds per
+// call and 16 notifications at once
+func DefaultLimits() Limits {
+	return Limits{
+		MaxBodyBytes:     8 << 20,
+		MaxUploadBytes:   1 << 30,
+		Timeout:          30 * time.Second,
+		MaxNotifications: 16,
+	}
+}
+
+// SetLimits sets the body-size limits, deadlines and notification cap
+// applied to every call
+func (r *Router) SetLimits(limits Limits) {
+	r.limits = limits
+	r.notifications = nil
+	if limits.MaxNotifications > 0 {
+		r.notifications = make(chan struct{}, limits.MaxNotifications)
+	}
+}
+
+// timeout returns the deadline for calls to ep, or 0 for none
+func (r *Router) timeout(ep endpoint) time.Duration {
+	if d, ok := r.limits.RouteTimeouts[ep.name()]; ok {
+		return d
+	}
+	return r.limits.Timeout
+}
+
+// callContext derives the context passed to ep's method, carrying ep's deadline
+func (r *Router) callContext(parent context.Context, ep endpoint) (context.Context, context.CancelFunc) {
+	if d := r.timeout(ep); d > 0 {
+		return context.WithTimeout(parent, d)
+	}
+	return context.WithCancel(parent)
+}
+
+// limitBody caps the request body, allowing uploads up to MaxUploadBytes
+func (r *Router) limitBody(c *gin.Context, upload bool) {
+	limit := r.limits.MaxBodyBytes
+	if upload {
+		limit = r.limits.MaxUploadBytes
+	}
+	if limit > 0 && c.Request.Body != nil {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+	}
+}
+
+// contextError reports a call whose context ended before it returned
+func (r *Router) contextError(ep endpoint, err error) error {
+	if err == context.DeadlineExceeded {
+		return sharedErrors.NewDomainError(ErrCodeTimeout,
+			fmt.Sprintf("%s did not finish within %s", ep.name(), r.timeout(ep)))
+	}
+	return sharedErrors.NewDomainError(ErrCodeCanceled, "request cancelled")
+}
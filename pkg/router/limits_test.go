@@ -0,0 +1,146 @@
+package router
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type slowService struct{}
+
+// Wait blocks until ctx is done or ms milliseconds pass
+func (s *slowService) Wait(ctx context.Context, ms int) (string, error) {
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case <-time.After(time.Duration(ms) * time.Millisecond):
+		return "done", nil
+	}
+}
+
+// Sleep ignores cancellation
+func (s *slowService) Sleep(ms int) string {
+	time.Sleep(time.Duration(ms) * time.Millisecond)
+	return "slept"
+}
+
+func (s *slowService) Count(ctx context.Context, input io.Reader) (int, error) {
+	data, err := io.ReadAll(input)
+	return len(data), err
+}
+
+func newLimitedEngine(t *testing.T, limits Limits) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := New(engine)
+	router.SetParamNames(map[string][]string{
+		"slowService.Wait":  {"ms"},
+		"slowService.Sleep": {"ms"},
+		"slowService.Count": {"input"},
+	})
+	router.SetLimits(limits)
+	require.NoError(t, router.Register(&slowService{}))
+	engine.POST("/rpc", router.handleRPC)
+	return engine
+}
+
+func post(engine *gin.Engine, path, contentType, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", path, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+func TestRouter_ContextParameter(t *testing.T) {
+	engine := newLimitedEngine(t, Limits{})
+
+	w := post(engine, "/api/slow-service/wait", "application/json", `{"ms": 1}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var result string
+	assert.Nil(t, decodeEnvelope(t, w.Body.Bytes(), &result))
+	assert.Equal(t, "done", result)
+
+	// The context is not part of the request schema
+	router := New(gin.New())
+	router.SetParamNames(map[string][]string{"slowService.Wait": {"ms"}})
+	require.NoError(t, router.Register(&slowService{}))
+	op := router.OpenAPI().Paths["/api/slow-service/wait"]["post"]
+	assert.Equal(t, []string{"ms"}, op.RequestBody.Content["application/json"].Schema.Required)
+}
+
+func TestRouter_Deadlines(t *testing.T) {
+	engine := newLimitedEngine(t, Limits{
+		Timeout:       20 * time.Millisecond,
+		RouteTimeouts: map[string]time.Duration{"slowService.Sleep": 0},
+	})
+
+	t.Run("cancels context-aware methods", func(t *testing.T) {
+		start := time.Now()
+		w := post(engine, "/api/slow-service/wait", "application/json", `{"ms": 5000}`)
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+		errBody := decodeEnvelope(t, w.Body.Bytes(), nil)
+		if assert.NotNil(t, errBody) {
+			assert.Equal(t, ErrCodeTimeout, errBody.Code)
+			assert.Contains(t, errBody.Message, "slowService.Wait")
+		}
+	})
+
+	t.Run("route override disables the deadline", func(t *testing.T) {
+		w := post(engine, "/api/slow-service/sleep", "application/json", `{"ms": 40}`)
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+
+	t.Run("json-rpc", func(t *testing.T) {
+		w := post(engine, "/rpc", "application/json", `{"jsonrpc":"2.0","method":"slowService.Wait","params":[5000],"id":1}`)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"code":-32000`)
+		assert.Contains(t, w.Body.String(), `"code":"`+ErrCodeTimeout+`"`)
+	})
+}
+
+func TestRouter_BodyLimits(t *testing.T) {
+	engine := newLimitedEngine(t, Limits{MaxBodyBytes: 32, MaxUploadBytes: 64})
+
+	tests := []struct {
+		name        string
+		path        string
+		contentType string
+		body        string
+		expectCode  int
+	}{
+		{"small json", "/api/slow-service/wait", "application/json", `{"ms": 1}`, http.StatusOK},
+		{"large json", "/api/slow-service/wait", "application/json", `{"ms": 1, "pad": "` + strings.Repeat("x", 64) + `"}`, http.StatusRequestEntityTooLarge},
+		{"upload over json limit", "/api/slow-service/count", "application/octet-stream", strings.Repeat("x", 48), http.StatusOK},
+		{"large upload", "/api/slow-service/count", "application/octet-stream", strings.Repeat("x", 128), http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := post(engine, tt.path, tt.contentType, tt.body)
+			assert.Equal(t, tt.expectCode, w.Code, w.Body.String())
+			if tt.expectCode == http.StatusRequestEntityTooLarge {
+				errBody := decodeEnvelope(t, w.Body.Bytes(), nil)
+				if assert.NotNil(t, errBody) {
+					assert.Equal(t, ErrCodeRequestTooLarge, errBody.Code)
+				}
+			}
+		})
+	}
+
+	t.Run("json-rpc", func(t *testing.T) {
+		w := post(engine, "/rpc", "application/json", `{"jsonrpc":"2.0","method":"slowService.Wait","params":[1],"id":"`+strings.Repeat("x", 64)+`"}`)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Contains(t, w.Body.String(), ErrCodeRequestTooLarge)
+	})
+}
@@ -0,0 +1,202 @@
+package router
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Manifest pins the routes of a stable API version. Each route maps a fixed
+// path under /api/<version>/ to a service method and records the method's
+// contract, so a Go rename or a payload change cannot silently break callers.
+type Manifest struct {
+	// Version is the namespace, such as "v1"
+	Version string          `json:"version"`
+	Routes  []ManifestRoute `json:"routes"`
+}
+
+// ManifestRoute is one pinned route
+type ManifestRoute struct {
+	// Path is the route below the version, such as "jwt-service/decode"
+	Path string `json:"path"`
+	// Method is the "Service.Method" the route calls
+	Method string `json:"method"`
+	// Contract is the method's fingerprint from Router.Contract
+	Contract string `json:"contract"`
+	// Deprecated, when set, marks the route as slated for removal
+	Deprecated *Deprecation `json:"deprecated,omitempty"`
+}
+
+// Deprecation announces the removal of a route. Responses carry it in the
+// Deprecation (RFC 9745), Sunset (RFC 8594) and Link headers.
+type Deprecation struct {
+	// Since is the date, YYYY-MM-DD, the route was deprecated
+	Since string `json:"since"`
+	// Sunset is the date, YYYY-MM-DD, after which the route may be removed
+	Sunset string `json:"sunset,omitempty"`
+	// Successor is the path of the route replacing it, such as
+	// "v2/jwt-service/decode"
+	Successor string `json:"successor,omitempty"`
+}
+
+var (
+	manifestVersion = regexp.MustCompile(`^v[1-9][0-9]*$`)
+	manifestPath    = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*(/[a-z0-9]+(-[a-z0-9]+)*)*$`)
+)
+
+// ParseManifest decodes a JSON manifest. Unknown fields are an error.
+func ParseManifest(data []byte) (Manifest, error) {
+	var m Manifest
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&m); err != nil {
+		return m, fmt.Errorf("manifest: %w", err)
+	}
+	return m, nil
+}
+
+// Contract fingerprints the request and response schemas of a registered
+// "Service.Method". Renaming a parameter or changing a field, type or result
+// changes it; reordering methods or renaming the Go types does not.
+func (r *Router) Contract(name string) (string, bool) {
+	i, ok := r.byName[name]
+	if !ok {
+		return "", false
+	}
+	return r.endpoints[i].contract(), true
+}
+
+// contract hashes the endpoint's input and output schemas, with named
+// structs inlined so only the JSON shape counts
+func (ep endpoint) contract() string {
+	input, output := ep.inputSchema(), ep.outputSchema()
+	if output != nil {
+		output = inlineDefs(output, output.Defs, map[string]bool{})
+	}
+	// Maps marshal with sorted keys, so the encoding is stable
+	data, _ := json.Marshal(struct {
+		Input  *Schema `json:"input"`
+		Output *Schema `json:"output"`
+	}{inlineDefs(input, input.Defs, map[string]bool{}), output})
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// inlineDefs copies s with its references to defs replaced by the schemas
+// they name. A reference back into a struct being inlined becomes "#".
+func inlineDefs(s *Schema, defs map[string]*Schema, inlining map[string]bool) *Schema {
+	if s == nil {
+		return nil
+	}
+	if name, ok := strings.CutPrefix(s.Ref, "#/$defs/"); ok {
+		if inlining[name] {
+			return &Schema{Ref: "#"}
+		}
+		inlining[name] = true
+		defer delete(inlining, name)
+		return inlineDefs(defs[name], defs, inlining)
+	}
+
+	out := *s
+	out.Defs = nil
+	out.Items = inlineDefs(s.Items, defs, inlining)
+	out.AdditionalProperties = inlineDefs(s.AdditionalProperties, defs, inlining)
+	if s.Properties != nil {
+		out.Properties = make(map[string]*Schema, len(s.Properties))
+		for key, prop := range s.Properties {
+			out.Properties[key] = inlineDefs(prop, defs, inlining)
+		}
+	}
+	return &out
+}
+
+// RegisterManifest serves the manifest's routes under /api/<version>/ with
+// the same handlers as the unversioned routes. Call it after registering the
+// services. Routes whose method is not registered, such as those of a
+// disabled tool, are skipped; a route whose method's contract no longer
+// matches is an error, since its callers would break.
+func (r *Router) RegisterManifest(m Manifest) error {
+	if !manifestVersion.MatchString(m.Version) {
+		return fmt.Errorf("manifest: invalid version %q, want v1, v2, ...", m.Version)
+	}
+
+	seen := map[string]bool{}
+	for _, route := range m.Routes {
+		if !manifestPath.MatchString(route.Path) {
+			return fmt.Errorf("manifest %s: invalid path %q", m.Version, route.Path)
+		}
+		if seen[route.Path] {
+			return fmt.Errorf("manifest %s: duplicate path %q", m.Version, route.Path)
+		}
+		seen[route.Path] = true
+
+		headers, err := route.Deprecated.headers()
+		if err != nil {
+			return fmt.Errorf("manifest %s: %s: %w", m.Version, route.Path, err)
+		}
+
+		i, ok := r.byName[route.Method]
+		if !ok {
+			continue
+		}
+		ep := r.endpoints[i]
+		if contract := ep.contract(); contract != route.Contract {
+			return fmt.Errorf("manifest %s: %s: the contract of %s changed from %s to %s; serve the new contract under a new API version",
+				m.Version, route.Path, route.Method, route.Contract, contract)
+		}
+
+		path := "/api/" + m.Version + "/" + route.Path
+		if headers != nil {
+			r.engine.POST(path, setHeaders(headers), r.createHandler(ep))
+		} else {
+			r.engine.POST(path, r.createHandler(ep))
+		}
+	}
+	return nil
+}
+
+// headers returns the response headers announcing the deprecation, or nil
+// when the route is not deprecated
+func (d *Deprecation) headers() (http.Header, error) {
+	if d == nil {
+		return nil, nil
+	}
+	h := http.Header{}
+
+	since, err := time.Parse(time.DateOnly, d.Since)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deprecation date %q: %w", d.Since, err)
+	}
+	h.Set("Deprecation", "@"+strconv.FormatInt(since.Unix(), 10))
+
+	if d.Sunset != "" {
+		sunset, err := time.Parse(time.DateOnly, d.Sunset)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sunset date %q: %w", d.Sunset, err)
+		}
+		h.Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+	}
+	if d.Successor != "" {
+		h.Set("Link", fmt.Sprintf(`</api/%s>; rel="successor-version"`, d.Successor))
+	}
+	return h, nil
+}
+
+// setHeaders adds headers to every response, including errors
+func setHeaders(headers http.Header) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for key, values := range headers {
+			c.Writer.Header()[key] = values
+		}
+		c.Next()
+	}
+}
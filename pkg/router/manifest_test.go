@@ -0,0 +1,151 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Services with the same contract as TestService.Echo under other names,
+// and one whose response changed
+type RenamedService struct{}
+
+type renamedRequest struct {
+	Message string `json:"message"`
+}
+
+type renamedResponse struct {
+	Message string `json:"message"`
+}
+
+func (s *RenamedService) Echo(req renamedRequest) renamedResponse {
+	return renamedResponse(req)
+}
+
+type ChangedService struct{}
+
+type changedResponse struct {
+	Text string `json:"text"`
+}
+
+func (s *ChangedService) Echo(req EchoRequest) changedResponse {
+	return changedResponse{Text: req.Message}
+}
+
+func TestRouter_Contract(t *testing.T) {
+	router := New(gin.New())
+	require.NoError(t, router.Register(&TestService{}))
+	require.NoError(t, router.Register(&RenamedService{}))
+	require.NoError(t, router.Register(&ChangedService{}))
+
+	echo, ok := router.Contract("TestService.Echo")
+	require.True(t, ok)
+	assert.True(t, strings.HasPrefix(echo, "sha256:"))
+
+	renamed, _ := router.Contract("RenamedService.Echo")
+	assert.Equal(t, echo, renamed, "Go type names are not part of the contract")
+	changed, _ := router.Contract("ChangedService.Echo")
+	assert.NotEqual(t, echo, changed)
+
+	_, ok = router.Contract("TestService.Missing")
+	assert.False(t, ok)
+}
+
+func newManifestServer(t *testing.T, m Manifest) *Server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	server := NewServer()
+	require.NoError(t, server.Register(&TestService{}))
+	require.NoError(t, server.RegisterManifest(m))
+	return server
+}
+
+func echoContract(t *testing.T) string {
+	t.Helper()
+	router := New(gin.New())
+	require.NoError(t, router.Register(&TestService{}))
+	contract, _ := router.Contract("TestService.Echo")
+	return contract
+}
+
+func TestServer_Manifest(t *testing.T) {
+	contract := echoContract(t)
+	server := newManifestServer(t, Manifest{Version: "v1", Routes: []ManifestRoute{
+		{Path: "echo", Method: "TestService.Echo", Contract: contract},
+		{Path: "legacy/echo", Method: "TestService.Echo", Contract: contract, Deprecated: &Deprecation{
+			Since: "2026-01-01", Sunset: "2026-12-31", Successor: "v1/echo",
+		}},
+		{Path: "gone", Method: "DisabledService.Run", Contract: "sha256:0"},
+	}})
+
+	call := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", path, strings.NewReader(`{"message":"hi"}`))
+		req.Header.Set("Content-Type", "application/json")
+		server.Engine().ServeHTTP(w, req)
+		return w
+	}
+
+	w := call("/api/v1/echo")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"data":{"message":"hi"},"error":null}`, w.Body.String())
+	assert.Empty(t, w.Header().Get("Deprecation"))
+
+	w = call("/api/v1/legacy/echo")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "@1767225600", w.Header().Get("Deprecation"))
+	assert.Equal(t, "Thu, 31 Dec 2026 00:00:00 GMT", w.Header().Get("Sunset"))
+	assert.Equal(t, `</api/v1/echo>; rel="successor-version"`, w.Header().Get("Link"))
+
+	// Unregistered methods are skipped; the unversioned route still works
+	assert.Equal(t, http.StatusNotFound, call("/api/v1/gone").Code)
+	assert.Equal(t, http.StatusOK, call("/api/test-service/echo").Code)
+}
+
+func TestServer_ManifestErrors(t *testing.T) {
+	contract := echoContract(t)
+	tests := []struct {
+		name     string
+		manifest Manifest
+		err      string
+	}{
+		{"bad version", Manifest{Version: "1"}, "invalid version"},
+		{"bad path", Manifest{Version: "v1", Routes: []ManifestRoute{
+			{Path: "/echo", Method: "TestService.Echo", Contract: contract},
+		}}, "invalid path"},
+		{"duplicate path", Manifest{Version: "v1", Routes: []ManifestRoute{
+			{Path: "echo", Method: "TestService.Echo", Contract: contract},
+			{Path: "echo", Method: "TestService.Echo", Contract: contract},
+		}}, "duplicate path"},
+		{"bad date", Manifest{Version: "v1", Routes: []ManifestRoute{
+			{Path: "echo", Method: "TestService.Echo", Contract: contract, Deprecated: &Deprecation{Since: "soon"}},
+		}}, "invalid deprecation date"},
+		{"changed contract", Manifest{Version: "v1", Routes: []ManifestRoute{
+			{Path: "echo", Method: "TestService.Echo", Contract: "sha256:0"},
+		}}, "contract of TestService.Echo changed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := NewServer()
+			require.NoError(t, server.Register(&TestService{}))
+			assert.ErrorContains(t, server.RegisterManifest(tt.manifest), tt.err)
+		})
+	}
+}
+
+func TestParseManifest(t *testing.T) {
+	m, err := ParseManifest([]byte(`{"version":"v1","routes":[{"path":"echo","method":"TestService.Echo","contract":"sha256:0"}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, Manifest{Version: "v1", Routes: []ManifestRoute{
+		{Path: "echo", Method: "TestService.Echo", Contract: "sha256:0"},
+	}}, m)
+
+	_, err = ParseManifest([]byte(`{"version":"v1","route":[]}`))
+	assert.ErrorContains(t, err, "route")
+}
@@ -0,0 +1,398 @@
+package router
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	sharedErrors "devtoolbox/pkg/errors"
+)
+
+// mcpProtocolVersions are the MCP revisions the server speaks, newest first
+var mcpProtocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// mcpInstructions tells the client what the tools are for
+const mcpInstructions = "DevToolbox developer utilities: JWT decoding, hashing, encoding, " +
+	"jq formatting, timestamp conversion and more. Every tool runs locally; " +
+	"no input leaves this machine."
+
+// mcpInitializeParams is the part of the initialize request the server reads
+type mcpInitializeParams struct {
+	ProtocolVersion string `json:"protocolVersion"`
+}
+
+// mcpInitializeResult answers initialize
+type mcpInitializeResult struct {
+	ProtocolVersion string                 `json:"protocolVersion"`
+	Capabilities    map[string]interface{} `json:"capabilities"`
+	ServerInfo      mcpServerInfo          `json:"serverInfo"`
+	Instructions    string                 `json:"instructions,omitempty"`
+}
+
+type mcpServerInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// mcpTool describes a tool in tools/list
+type mcpTool struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	InputSchema *Schema `json:"inputSchema"`
+}
+
+// mcpCallParams is a tools/call request
+type mcpCallParams struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments"`
+}
+
+// mcpContent is one item of a tool result
+type mcpContent struct {
+	Type     string `json:"type"`
+	Text     string `json:"text,omitempty"`
+	Data     string `json:"data,omitempty"`
+	MimeType string `json:"mimeType,omitempty"`
+}
+
+// mcpToolResult answers tools/call. Failures of the tool itself are results
+// with IsError set, so the model can see them; bad requests are JSON-RPC errors.
+type mcpToolResult struct {
+	Content           []mcpContent    `json:"content"`
+	StructuredContent json.RawMessage `json:"structuredContent,omitempty"`
+	IsError           bool            `json:"isError,omitempty"`
+}
+
+// mcpCancelledParams is a notifications/cancelled notification
+type mcpCancelledParams struct {
+	RequestID json.RawMessage `json:"requestId"`
+}
+
+// mcpSession is one MCP client connection
+type mcpSession struct {
+	router *Router
+
+	writeMu sync.Mutex
+	out     io.Writer
+
+	callsMu sync.Mutex
+	calls   map[string]context.CancelFunc // in-flight tools/call by request id
+
+	wg sync.WaitGroup
+}
+
+// ServeMCP serves the registered methods as Model Context Protocol tools,
+// reading newline-delimited JSON-RPC messages from in and writing responses
+// to out (the stdio transport). Tool calls run concurrently with their
+// method's deadline and stop when the client cancels them. It returns when
+// in is exhausted and the calls in flight have answered; ctx bounds the calls
+// but cannot interrupt a blocked read.
+func (r *Router) ServeMCP(ctx context.Context, in io.Reader, out io.Writer) error {
+	s := &mcpSession{router: r, out: out, calls: map[string]context.CancelFunc{}}
+	defer s.wg.Wait()
+
+	reader := bufio.NewReader(in)
+	for {
+		line, tooLarge, err := readMessage(reader, r.limits.MaxBodyBytes)
+		switch {
+		case tooLarge:
+			s.send(rpcFailure(json.RawMessage("null"), rpcInvalidRequest,
+				fmt.Sprintf("message exceeds %d bytes", r.limits.MaxBodyBytes), nil))
+		case len(strings.TrimSpace(string(line))) > 0:
+			s.handle(ctx, line)
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// readMessage reads one newline-terminated message. A message longer than
+// limit is skipped and reported as tooLarge.
+func readMessage(r *bufio.Reader, limit int64) (line []byte, tooLarge bool, err error) {
+	for {
+		chunk, err := r.ReadSlice('\n')
+		if !tooLarge {
+			line = append(line, chunk...)
+			if limit > 0 && int64(len(line)) > limit+1 {
+				line, tooLarge = nil, true
+			}
+		}
+		if !errors.Is(err, bufio.ErrBufferFull) {
+			return line, tooLarge, err
+		}
+	}
+}
+
+// handle dispatches one message. tools/call runs in the background; the
+// other requests are answered in order.
+func (s *mcpSession) handle(ctx context.Context, line []byte) {
+	var req rpcRequest
+	if err := json.Unmarshal(line, &req); err != nil {
+		s.send(rpcFailure(json.RawMessage("null"), rpcParseError, "parse error", nil))
+		return
+	}
+	if req.Method == "" {
+		// A response; the server sends no requests of its own
+		return
+	}
+	if req.JSONRPC != rpcVersion || !validRPCID(req.ID) {
+		if req.ID != nil {
+			s.send(rpcFailure(json.RawMessage("null"), rpcInvalidRequest, "invalid request", nil))
+		}
+		return
+	}
+	if req.ID == nil {
+		s.notification(req)
+		return
+	}
+
+	if req.Method != "tools/call" {
+		resp := s.respond(req)
+		resp.ID = req.ID
+		s.send(resp)
+		return
+	}
+
+	callCtx, cancel := context.WithCancel(ctx)
+	id := string(req.ID)
+	s.callsMu.Lock()
+	s.calls[id] = cancel
+	s.callsMu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer cancel()
+		resp := s.router.mcpCall(callCtx, req.Params)
+		resp.ID = req.ID
+
+		// Cancelled requests get no response
+		s.callsMu.Lock()
+		_, pending := s.calls[id]
+		delete(s.calls, id)
+		s.callsMu.Unlock()
+		if pending {
+			s.send(resp)
+		}
+	}()
+}
+
+// notification handles a message that expects no response
+func (s *mcpSession) notification(req rpcRequest) {
+	if req.Method != "notifications/cancelled" {
+		return
+	}
+	var params mcpCancelledParams
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		return
+	}
+	id := string(params.RequestID)
+	s.callsMu.Lock()
+	cancel, ok := s.calls[id]
+	delete(s.calls, id)
+	s.callsMu.Unlock()
+	if ok {
+		cancel()
+	}
+}
+
+// respond answers every request except tools/call
+func (s *mcpSession) respond(req rpcRequest) *rpcResponse {
+	switch req.Method {
+	case "initialize":
+		var params mcpInitializeParams
+		json.Unmarshal(req.Params, &params)
+		return rpcResult(mcpInitializeResult{
+			ProtocolVersion: negotiateMCPVersion(params.ProtocolVersion),
+			Capabilities:    map[string]interface{}{"tools": map[string]interface{}{}},
+			ServerInfo:      mcpServerInfo{Name: "devtoolbox", Version: APIVersion},
+			Instructions:    mcpInstructions,
+		})
+	case "ping":
+		return rpcResult(struct{}{})
+	case "tools/list":
+		return rpcResult(map[string]interface{}{"tools": s.router.mcpTools()})
+	}
+	return rpcFailure(nil, rpcMethodNotFound, fmt.Sprintf("method %q not found", req.Method), nil)
+}
+
+// send writes a response as one line
+func (s *mcpSession) send(resp *rpcResponse) {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		data, _ = json.Marshal(rpcFailure(resp.ID, rpcInternalError, err.Error(), nil))
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	s.out.Write(append(data, '\n'))
+}
+
+// negotiateMCPVersion returns the client's protocol version when the server
+// speaks it, and the newest one otherwise
+func negotiateMCPVersion(requested string) string {
+	for _, version := range mcpProtocolVersions {
+		if version == requested {
+			return version
+		}
+	}
+	return mcpProtocolVersions[0]
+}
+
+// rpcResult builds a successful response
+func rpcResult(v interface{}) *rpcResponse {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return rpcFailure(nil, rpcInternalError, err.Error(), &rpcErrorData{Code: sharedErrors.ErrCodeInternal})
+	}
+	return &rpcResponse{JSONRPC: rpcVersion, Result: data}
+}
+
+// mcpTools describes every registered method as an MCP tool
+func (r *Router) mcpTools() []mcpTool {
+	tools := make([]mcpTool, 0, len(r.endpoints))
+	for _, ep := range r.endpoints {
+		tools = append(tools, mcpTool{
+			Name:        ep.toolName(),
+			Description: r.describe(ep),
+			InputSchema: ep.inputSchema(),
+		})
+	}
+	return tools
+}
+
+// toolName returns the endpoint's MCP tool name, e.g. "JWTService_Decode".
+// Clients only allow letters, digits, "_" and "-".
+func (ep endpoint) toolName() string {
+	return ep.service + "_" + ep.method.Name
+}
+
+// describe tells a client what the endpoint's tool does: the method's doc
+// summary, the registry tool it belongs to and its signature
+func (r *Router) describe(ep endpoint) string {
+	var parts []string
+	if ep.doc != "" {
+		parts = append(parts, ep.doc+".")
+	}
+	if tool, ok := r.toolFor(ep.service); ok {
+		part := "Part of the " + tool.Name + " tool"
+		if len(tool.Keywords) > 0 {
+			part += " (" + strings.Join(tool.Keywords, ", ") + ")"
+		}
+		parts = append(parts, part+".")
+	}
+
+	params := make([]string, len(ep.params))
+	for i, p := range ep.params {
+		params[i] = ep.argumentName(p) + " " + p.argType.String()
+	}
+	signature := fmt.Sprintf("Calls %s(%s)", ep.name(), strings.Join(params, ", "))
+	if out := firstResult(ep.fn.Type()); out != nil {
+		signature += " and returns " + out.String()
+	}
+	return strings.Join(append(parts, signature+". Runs locally."), " ")
+}
+
+// tool returns the endpoint for an MCP tool name
+func (r *Router) tool(name string) (endpoint, bool) {
+	for _, ep := range r.endpoints {
+		if ep.toolName() == name {
+			return ep, true
+		}
+	}
+	return endpoint{}, false
+}
+
+// mcpCall runs a tools/call request
+func (r *Router) mcpCall(ctx context.Context, rawParams json.RawMessage) (resp *rpcResponse) {
+	var params mcpCallParams
+	if err := json.Unmarshal(rawParams, &params); err != nil || params.Name == "" {
+		return rpcFailure(nil, rpcInvalidParams, "invalid params: want a tool name and arguments", nil)
+	}
+	ep, ok := r.tool(params.Name)
+	if !ok {
+		return rpcFailure(nil, rpcInvalidParams, fmt.Sprintf("unknown tool %q", params.Name), nil)
+	}
+
+	start := time.Now()
+	code := ""
+	defer func() {
+		r.metrics.observe(ep.name(), transportMCP, code, time.Since(start))
+	}()
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			code = sharedErrors.ErrCodeInternal
+			resp = rpcResult(mcpFailure(sharedErrors.NewDomainError(code, "internal error")))
+		}
+	}()
+
+	args, err := bindRPCParams(ep, params.Arguments)
+	if err != nil {
+		data := rpcData(err, nil)
+		code = data.Code
+		return rpcFailure(nil, rpcInvalidParams, sharedErrors.MessageOf(err), data)
+	}
+
+	callCtx, cancel := r.callContext(ctx, ep)
+	defer cancel()
+
+	result, err := ep.call(callCtx, args)
+	if reader, ok := result.(io.Reader); ok && err == nil {
+		result, err = r.readAllResult(reader)
+	}
+	if ctxErr := callCtx.Err(); ctxErr != nil {
+		err = r.contextError(ep, ctxErr)
+	}
+	if err != nil {
+		code = errorCode(err)
+		return rpcResult(mcpFailure(err))
+	}
+	return rpcResult(mcpSuccess(result))
+}
+
+// mcpSuccess renders a result as tool content. Images become image content;
+// other results are text, with JSON objects also given as structured content.
+func mcpSuccess(result interface{}) *mcpToolResult {
+	if b, ok := result.(Binary); ok && strings.HasPrefix(b.ContentType(), "image/") && len(b.Bytes()) > 0 {
+		return &mcpToolResult{Content: []mcpContent{{
+			Type:     "image",
+			Data:     base64.StdEncoding.EncodeToString(b.Bytes()),
+			MimeType: b.ContentType(),
+		}}}
+	}
+
+	// Drained streams may not be text
+	if data, ok := result.([]byte); ok && !utf8.Valid(data) {
+		return &mcpToolResult{Content: []mcpContent{{Type: "text", Text: base64.StdEncoding.EncodeToString(data)}}}
+	}
+
+	text, err := plainText(result)
+	if err != nil {
+		return mcpFailure(err)
+	}
+	res := &mcpToolResult{Content: []mcpContent{{Type: "text", Text: string(text)}}}
+	if data, err := json.Marshal(result); err == nil && len(data) > 0 && data[0] == '{' {
+		res.StructuredContent = data
+	}
+	return res
+}
+
+// mcpFailure reports a tool error as "CODE: message"
+func mcpFailure(err error) *mcpToolResult {
+	body := newErrorBody(err)
+	return &mcpToolResult{
+		Content: []mcpContent{{Type: "text", Text: body.Code + ": " + body.Message}},
+		IsError: true,
+	}
+}
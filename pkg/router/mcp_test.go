@@ -0,0 +1,296 @@
+package router
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	sharedErrors "devtoolbox/pkg/errors"
+	"devtoolbox/pkg/tools"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mcpTestResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result"`
+	Error   *rpcError       `json:"error"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// mcpClient drives ServeMCP over pipes
+type mcpClient struct {
+	t    *testing.T
+	in   *io.PipeWriter
+	out  *bufio.Scanner
+	done chan error
+}
+
+func newMCPClient(t *testing.T, limits Limits, services ...interface{}) *mcpClient {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := New(gin.New())
+	router.SetParamNames(map[string][]string{
+		"MultiParamService.Combine": {"a", "b", "c"},
+		"slowService.Wait":          {"ms"},
+	})
+	router.SetLimits(limits)
+	for _, svc := range services {
+		require.NoError(t, router.Register(svc))
+	}
+
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	c := &mcpClient{t: t, in: inW, out: bufio.NewScanner(outR), done: make(chan error, 1)}
+	go func() {
+		c.done <- router.ServeMCP(context.Background(), inR, outW)
+		outW.Close()
+	}()
+	t.Cleanup(func() { inW.Close() })
+	return c
+}
+
+func (c *mcpClient) send(message string) {
+	c.t.Helper()
+	_, err := io.WriteString(c.in, message+"\n")
+	require.NoError(c.t, err)
+}
+
+func (c *mcpClient) receive() mcpTestResponse {
+	c.t.Helper()
+	require.True(c.t, c.out.Scan(), "expected a response")
+	var resp mcpTestResponse
+	require.NoError(c.t, json.Unmarshal(c.out.Bytes(), &resp), c.out.Text())
+	assert.Equal(c.t, "2.0", resp.JSONRPC)
+	return resp
+}
+
+func (c *mcpClient) call(message string) mcpTestResponse {
+	c.t.Helper()
+	c.send(message)
+	return c.receive()
+}
+
+func TestMCP_Initialize(t *testing.T) {
+	c := newMCPClient(t, Limits{}, &TestService{})
+
+	resp := c.call(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`)
+	require.Nil(t, resp.Error)
+	var result mcpInitializeResult
+	require.NoError(t, json.Unmarshal(resp.Result, &result))
+	assert.Equal(t, "2025-03-26", result.ProtocolVersion)
+	assert.Contains(t, result.Capabilities, "tools")
+	assert.Equal(t, "devtoolbox", result.ServerInfo.Name)
+
+	// Unknown versions get the newest one
+	resp = c.call(`{"jsonrpc":"2.0","id":2,"method":"initialize","params":{"protocolVersion":"1999-01-01"}}`)
+	require.NoError(t, json.Unmarshal(resp.Result, &result))
+	assert.Equal(t, mcpProtocolVersions[0], result.ProtocolVersion)
+
+	// Notifications get no response; the next line answers the ping
+	c.send(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)
+	resp = c.call(`{"jsonrpc":"2.0","id":"p","method":"ping"}`)
+	assert.Equal(t, `"p"`, string(resp.ID))
+	assert.JSONEq(t, `{}`, string(resp.Result))
+
+	resp = c.call(`{"jsonrpc":"2.0","id":3,"method":"resources/list"}`)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, rpcMethodNotFound, resp.Error.Code)
+
+	resp = c.call(`{"jsonrpc":`)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, rpcParseError, resp.Error.Code)
+}
+
+func TestMCP_ToolsList(t *testing.T) {
+	c := newMCPClient(t, Limits{}, &TestService{}, &MultiParamService{}, &PrimitiveService{})
+
+	resp := c.call(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+	require.Nil(t, resp.Error)
+	var result struct {
+		Tools []struct {
+			Name        string                 `json:"name"`
+			Description string                 `json:"description"`
+			InputSchema map[string]interface{} `json:"inputSchema"`
+		} `json:"tools"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Result, &result))
+
+	tools := map[string]int{}
+	for i, tool := range result.Tools {
+		tools[tool.Name] = i
+		assert.Regexp(t, `^[a-zA-Z0-9_-]{1,64}$`, tool.Name)
+		assert.Equal(t, "object", tool.InputSchema["type"], tool.Name)
+	}
+
+	require.Contains(t, tools, "MultiParamService_Combine")
+	combine := result.Tools[tools["MultiParamService_Combine"]]
+	assert.Equal(t, "Calls MultiParamService.Combine(a string, b string, c string) and returns string. Runs locally.", combine.Description)
+	assert.Equal(t, []interface{}{"a", "b", "c"}, combine.InputSchema["required"])
+
+	require.Contains(t, tools, "PrimitiveService_Process")
+	process := result.Tools[tools["PrimitiveService_Process"]]
+	assert.Contains(t, process.InputSchema["properties"], "value")
+
+	// A struct parameter's fields are the arguments
+	require.Contains(t, tools, "TestService_Echo")
+	echo := result.Tools[tools["TestService_Echo"]]
+	assert.Contains(t, echo.InputSchema["properties"], "message")
+}
+
+func TestMCP_ToolDescriptions(t *testing.T) {
+	router := New(gin.New())
+	router.SetParamNames(map[string][]string{"MultiParamService.Combine": {"a", "b", "c"}})
+	router.SetMethodDocs(map[string]string{"MultiParamService.Combine": "Joins a, b and c with dashes"})
+	require.NoError(t, router.RegisterTools(tools.NewRegistry(
+		tools.Tool{ID: "combine", Name: "Combine", Keywords: []string{"join", "concat"}, Service: &MultiParamService{}},
+	)))
+	require.NoError(t, router.Register(&TestService{}))
+
+	descriptions := map[string]string{}
+	for _, tool := range router.mcpTools() {
+		descriptions[tool.Name] = tool.Description
+	}
+	assert.Equal(t, "Joins a, b and c with dashes. Part of the Combine tool (join, concat). "+
+		"Calls MultiParamService.Combine(a string, b string, c string) and returns string. Runs locally.",
+		descriptions["MultiParamService_Combine"])
+	// Services outside the registry without docs keep the bare signature
+	assert.Equal(t, "Calls TestService.Echo(value router.EchoRequest) and returns router.EchoResponse. Runs locally.",
+		descriptions["TestService_Echo"])
+}
+
+func TestMCP_InputSchemaDefs(t *testing.T) {
+	router := New(gin.New())
+	require.NoError(t, router.Register(&openAPIService{}))
+
+	for _, tool := range router.mcpTools() {
+		data, err := json.Marshal(tool.InputSchema)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "#/components/", tool.Name)
+		if strings.Contains(string(data), `"$ref"`) {
+			assert.Contains(t, string(data), `"$defs"`, tool.Name)
+		}
+	}
+}
+
+func TestMCP_ToolsCall(t *testing.T) {
+	c := newMCPClient(t, Limits{}, &TestService{}, &MultiParamService{}, &erroringService{}, &uploadService{})
+
+	t.Run("text result", func(t *testing.T) {
+		resp := c.call(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"MultiParamService_Combine","arguments":{"a":"x","b":"y","c":"z"}}}`)
+		require.Nil(t, resp.Error)
+		assert.JSONEq(t, `{"content":[{"type":"text","text":"x-y-z"}]}`, string(resp.Result))
+	})
+
+	t.Run("structured result", func(t *testing.T) {
+		resp := c.call(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"TestService_Echo","arguments":{"message":"hi"}}}`)
+		require.Nil(t, resp.Error)
+		var result mcpToolResult
+		require.NoError(t, json.Unmarshal(resp.Result, &result))
+		assert.False(t, result.IsError)
+		assert.JSONEq(t, `{"message":"hi"}`, string(result.StructuredContent))
+		assert.JSONEq(t, `{"message":"hi"}`, result.Content[0].Text)
+	})
+
+	t.Run("image result", func(t *testing.T) {
+		resp := c.call(`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"uploadService_Image"}}`)
+		require.Nil(t, resp.Error)
+		var result mcpToolResult
+		require.NoError(t, json.Unmarshal(resp.Result, &result))
+		assert.Equal(t, "image", result.Content[0].Type)
+		assert.Equal(t, "image/png", result.Content[0].MimeType)
+	})
+
+	t.Run("tool error", func(t *testing.T) {
+		resp := c.call(`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"erroringService_Domain","arguments":{}}}`)
+		require.Nil(t, resp.Error)
+		var result mcpToolResult
+		require.NoError(t, json.Unmarshal(resp.Result, &result))
+		assert.True(t, result.IsError)
+		assert.Equal(t, sharedErrors.ErrCodeInvalidInput+": bad input", result.Content[0].Text)
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		resp := c.call(`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"erroringService_Panics"}}`)
+		require.Nil(t, resp.Error)
+		var result mcpToolResult
+		require.NoError(t, json.Unmarshal(resp.Result, &result))
+		assert.True(t, result.IsError)
+		assert.Contains(t, result.Content[0].Text, sharedErrors.ErrCodeInternal)
+	})
+
+	t.Run("unknown tool", func(t *testing.T) {
+		resp := c.call(`{"jsonrpc":"2.0","id":6,"method":"tools/call","params":{"name":"Nope_Nothing"}}`)
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, rpcInvalidParams, resp.Error.Code)
+	})
+
+	t.Run("missing argument", func(t *testing.T) {
+		resp := c.call(`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"MultiParamService_Combine","arguments":{"a":"x"}}}`)
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, rpcInvalidParams, resp.Error.Code)
+		assert.Equal(t, ErrCodeMissingParameter, resp.Error.Data.Code)
+	})
+}
+
+func TestMCP_Cancellation(t *testing.T) {
+	c := newMCPClient(t, Limits{}, &slowService{})
+
+	c.send(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"slowService_Wait","arguments":{"ms":5000}}}`)
+	c.send(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":1}}`)
+
+	// The cancelled call is not answered, so the ping's response comes next
+	start := time.Now()
+	resp := c.call(`{"jsonrpc":"2.0","id":2,"method":"ping"}`)
+	assert.Equal(t, "2", string(resp.ID))
+
+	c.in.Close()
+	select {
+	case err := <-c.done:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("ServeMCP did not return")
+	}
+	assert.Less(t, time.Since(start), 2*time.Second)
+	assert.False(t, c.out.Scan(), "cancelled call must not be answered")
+}
+
+func TestMCP_Deadline(t *testing.T) {
+	c := newMCPClient(t, Limits{Timeout: 20 * time.Millisecond}, &slowService{})
+
+	resp := c.call(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"slowService_Wait","arguments":{"ms":5000}}}`)
+	require.Nil(t, resp.Error)
+	var result mcpToolResult
+	require.NoError(t, json.Unmarshal(resp.Result, &result))
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, ErrCodeTimeout)
+}
+
+func TestMCP_MessageLimit(t *testing.T) {
+	c := newMCPClient(t, Limits{MaxBodyBytes: 64}, &TestService{})
+
+	resp := c.call(`{"jsonrpc":"2.0","id":1,"method":"ping","params":{"pad":"` + strings.Repeat("x", 128) + `"}}`)
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, rpcInvalidRequest, resp.Error.Code)
+
+	// The connection survives
+	resp = c.call(`{"jsonrpc":"2.0","id":2,"method":"ping"}`)
+	assert.Nil(t, resp.Error)
+}
+
+func TestMCP_ResultLimit(t *testing.T) {
+	c := newMCPClient(t, Limits{MaxBodyBytes: 128}, &streamService{})
+
+	resp := c.call(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"streamService_Repeat","arguments":{"arg0":129}}}`)
+	require.Nil(t, resp.Error)
+	var result mcpToolResult
+	require.NoError(t, json.Unmarshal(resp.Result, &result))
+	assert.True(t, result.IsError)
+	assert.Equal(t, ErrCodeRequestTooLarge+": result exceeds 128 bytes", result.Content[0].Text)
+}
@@ -0,0 +1,161 @@
+package router
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Transports recorded in the tool metrics
+const (
+	transportHTTP = "http"
+	transportRPC  = "rpc"
+	transportMCP  = "mcp"
+)
+
+// metricsContentType is the Prometheus text exposition format
+const metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// durationBuckets are the latency histogram's upper bounds in seconds
+var durationBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30}
+
+// Metrics counts calls, errors and latency per tool ("Service.Method") and
+// transport. The router records every call, so services need no changes.
+type Metrics struct {
+	mu    sync.Mutex
+	tools map[toolKey]*toolMetrics
+}
+
+type toolKey struct {
+	tool      string
+	transport string
+}
+
+type toolMetrics struct {
+	calls   uint64
+	errors  map[string]uint64 // by error code
+	buckets []uint64          // cumulative counts, one per durationBuckets entry
+	sum     float64
+}
+
+// NewMetrics creates an empty metrics registry
+func NewMetrics() *Metrics {
+	return &Metrics{tools: map[toolKey]*toolMetrics{}}
+}
+
+// observe records a call; code is the error code, or empty on success
+func (m *Metrics) observe(tool, transport, code string, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := toolKey{tool, transport}
+	t, ok := m.tools[key]
+	if !ok {
+		t = &toolMetrics{errors: map[string]uint64{}, buckets: make([]uint64, len(durationBuckets))}
+		m.tools[key] = t
+	}
+
+	t.calls++
+	if code != "" {
+		t.errors[code]++
+	}
+	seconds := d.Seconds()
+	t.sum += seconds
+	for i, bound := range durationBuckets {
+		if seconds <= bound {
+			t.buckets[i]++
+		}
+	}
+}
+
+// WriteTo writes the metrics in the Prometheus text format
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keys := make([]toolKey, 0, len(m.tools))
+	for key := range m.tools {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].tool != keys[j].tool {
+			return keys[i].tool < keys[j].tool
+		}
+		return keys[i].transport < keys[j].transport
+	})
+
+	var b strings.Builder
+
+	b.WriteString("# HELP devtoolbox_tool_calls_total Calls to each tool.\n")
+	b.WriteString("# TYPE devtoolbox_tool_calls_total counter\n")
+	for _, key := range keys {
+		fmt.Fprintf(&b, "devtoolbox_tool_calls_total{%s} %d\n", key.labels(), m.tools[key].calls)
+	}
+
+	b.WriteString("# HELP devtoolbox_tool_errors_total Failed tool calls by error code.\n")
+	b.WriteString("# TYPE devtoolbox_tool_errors_total counter\n")
+	for _, key := range keys {
+		t := m.tools[key]
+		codes := make([]string, 0, len(t.errors))
+		for code := range t.errors {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+		for _, code := range codes {
+			fmt.Fprintf(&b, "devtoolbox_tool_errors_total{code=%q,%s} %d\n", labelValue(code), key.labels(), t.errors[code])
+		}
+	}
+
+	b.WriteString("# HELP devtoolbox_tool_duration_seconds Tool call latency in seconds.\n")
+	b.WriteString("# TYPE devtoolbox_tool_duration_seconds histogram\n")
+	for _, key := range keys {
+		t := m.tools[key]
+		for i, bound := range durationBuckets {
+			fmt.Fprintf(&b, "devtoolbox_tool_duration_seconds_bucket{%s,le=\"%s\"} %d\n",
+				key.labels(), strconv.FormatFloat(bound, 'g', -1, 64), t.buckets[i])
+		}
+		fmt.Fprintf(&b, "devtoolbox_tool_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", key.labels(), t.calls)
+		fmt.Fprintf(&b, "devtoolbox_tool_duration_seconds_sum{%s} %s\n", key.labels(), strconv.FormatFloat(t.sum, 'g', -1, 64))
+		fmt.Fprintf(&b, "devtoolbox_tool_duration_seconds_count{%s} %d\n", key.labels(), t.calls)
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// labels formats the key as Prometheus labels
+func (k toolKey) labels() string {
+	return fmt.Sprintf("tool=%q,transport=%q", labelValue(k.tool), labelValue(k.transport))
+}
+
+// labelValue strips characters that %q would escape differently from the
+// exposition format; tool names and codes never contain them
+func labelValue(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '"' || r == '\\' || r < ' ' || r > '~' {
+			return '_'
+		}
+		return r
+	}, s)
+}
+
+// Metrics returns the router's call metrics
+func (r *Router) Metrics() *Metrics {
+	return r.metrics
+}
+
+// serveMetrics writes the metrics for a Prometheus scrape
+func serveMetrics(m *Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", metricsContentType)
+		c.Status(http.StatusOK)
+		m.WriteTo(c.Writer)
+	}
+}
@@ -0,0 +1,111 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	sharedErrors "devtoolbox/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics_WriteTo(t *testing.T) {
+	m := NewMetrics()
+	m.observe("JWTService.Decode", transportHTTP, "", 3*time.Millisecond)
+	m.observe("JWTService.Decode", transportHTTP, "INVALID_TOKEN", 200*time.Millisecond)
+	m.observe("JWTService.Decode", transportRPC, "", time.Minute)
+
+	var buf bytes.Buffer
+	_, err := m.WriteTo(&buf)
+	require.NoError(t, err)
+	out := buf.String()
+
+	assert.Contains(t, out, "# TYPE devtoolbox_tool_calls_total counter\n")
+	assert.Contains(t, out, `devtoolbox_tool_calls_total{tool="JWTService.Decode",transport="http"} 2`)
+	assert.Contains(t, out, `devtoolbox_tool_calls_total{tool="JWTService.Decode",transport="rpc"} 1`)
+	assert.Contains(t, out, `devtoolbox_tool_errors_total{code="INVALID_TOKEN",tool="JWTService.Decode",transport="http"} 1`)
+	assert.Contains(t, out, "# TYPE devtoolbox_tool_duration_seconds histogram\n")
+	assert.Contains(t, out, `devtoolbox_tool_duration_seconds_bucket{tool="JWTService.Decode",transport="http",le="0.005"} 1`)
+	assert.Contains(t, out, `devtoolbox_tool_duration_seconds_bucket{tool="JWTService.Decode",transport="http",le="0.25"} 2`)
+	assert.Contains(t, out, `devtoolbox_tool_duration_seconds_bucket{tool="JWTService.Decode",transport="rpc",le="30"} 0`)
+	assert.Contains(t, out, `devtoolbox_tool_duration_seconds_bucket{tool="JWTService.Decode",transport="rpc",le="+Inf"} 1`)
+	assert.Contains(t, out, `devtoolbox_tool_duration_seconds_sum{tool="JWTService.Decode",transport="rpc"} 60`)
+	assert.Contains(t, out, `devtoolbox_tool_duration_seconds_count{tool="JWTService.Decode",transport="http"} 2`)
+}
+
+func TestServer_MetricsEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	server := NewServer()
+	require.NoError(t, server.Register(&erroringService{}))
+	require.NoError(t, server.Register(&TestService{}))
+
+	for _, path := range []string{"/api/test-service/echo", "/api/erroring-service/domain", "/api/erroring-service/panics"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", path, strings.NewReader(`{"message":"hi"}`))
+		server.Engine().ServeHTTP(w, req)
+	}
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/rpc", strings.NewReader(`{"jsonrpc":"2.0","method":"TestService.Echo","params":{},"id":1}`))
+	server.Engine().ServeHTTP(w, req)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/metrics", nil)
+	server.Engine().ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, metricsContentType, w.Header().Get("Content-Type"))
+	out := w.Body.String()
+	assert.Contains(t, out, `devtoolbox_tool_calls_total{tool="TestService.Echo",transport="http"} 1`)
+	assert.Contains(t, out, `devtoolbox_tool_calls_total{tool="TestService.Echo",transport="rpc"} 1`)
+	assert.Contains(t, out, `devtoolbox_tool_errors_total{code="`+sharedErrors.ErrCodeInvalidInput+`",tool="erroringService.Domain",transport="http"} 1`)
+	assert.Contains(t, out, `devtoolbox_tool_errors_total{code="`+sharedErrors.ErrCodeInternal+`",tool="erroringService.Panics",transport="http"} 1`)
+	assert.NotContains(t, out, `code=`+`"",`)
+}
+
+func TestServer_AccessLog(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var logs bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Token = "secret-token"
+	cfg.AccessLog = slog.New(slog.NewJSONHandler(&logs, nil))
+	server := NewServerWithConfig(cfg)
+	require.NoError(t, server.Register(&TestService{}))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/test-service/echo?access_token=secret-token", strings.NewReader(`{"message":"top secret input"}`))
+	server.Engine().ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/api/test-service/echo", strings.NewReader(`{}`))
+	server.Engine().ServeHTTP(w, req)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.NotContains(t, logs.String(), "top secret input")
+	assert.NotContains(t, logs.String(), "secret-token")
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &record))
+	assert.Equal(t, "request", record["msg"])
+	assert.Equal(t, "POST", record["method"])
+	assert.Equal(t, "/api/test-service/echo", record["route"])
+	assert.Equal(t, "INFO", record["level"])
+	assert.Equal(t, float64(http.StatusOK), record["status"])
+	assert.Contains(t, record, "duration_ms")
+	assert.NotContains(t, record, "error")
+
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &record))
+	assert.Equal(t, float64(http.StatusUnauthorized), record["status"])
+	assert.Equal(t, "WARN", record["level"], "failed requests stand out at a warn log level")
+	assert.Equal(t, ErrCodeUnauthorized, record["error"])
+}
@@ -0,0 +1,159 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	sharedErrors "devtoolbox/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"gopkg.in/yaml.v3"
+)
+
+// Negotiated content types
+const (
+	mimeJSON     = "application/json"
+	mimeText     = "text/plain"
+	mimeYAML     = "application/yaml"
+	mimeXYAML    = "application/x-yaml"
+	mimeTextYAML = "text/yaml"
+)
+
+// offeredFormats are the response formats in order of preference; JSON wins
+// for missing headers and wildcards
+var offeredFormats = []string{mimeJSON, mimeText, mimeYAML, mimeXYAML, mimeTextYAML}
+
+// responseFormat picks the response format from the Accept header:
+// mimeJSON (the envelope), mimeText (the bare result) or mimeYAML (the result
+// as YAML). Unsupported types fall back to JSON rather than 406.
+func responseFormat(c *gin.Context) string {
+	switch c.NegotiateFormat(offeredFormats...) {
+	case mimeText:
+		return mimeText
+	case mimeYAML, mimeXYAML, mimeTextYAML:
+		return mimeYAML
+	}
+	return mimeJSON
+}
+
+// textParam returns the index of the parameter that receives a text/plain
+// body, or -1: the first string or upload parameter, or a lone primitive
+func textParam(params []param) int {
+	for i, p := range params {
+		if p.argType.Kind() == reflect.String || isUploadType(p.argType) {
+			return i
+		}
+	}
+	if len(params) == 1 {
+		switch params[0].argType.Kind() {
+		case reflect.Bool, reflect.Int, reflect.Int64, reflect.Float64:
+			return 0
+		}
+	}
+	return -1
+}
+
+// isTextRequest reports whether the request carries a text/plain body
+func isTextRequest(c *gin.Context) bool {
+	return c.ContentType() == mimeText
+}
+
+// textArg converts a text/plain body for a string or primitive parameter.
+// Strings lose one trailing newline, which files and echo add; other types
+// are parsed like query values.
+func textArg(body io.Reader, p param) (reflect.Value, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if p.argType.Kind() == reflect.String {
+		text := strings.TrimSuffix(strings.TrimSuffix(string(data), "\n"), "\r")
+		return reflect.ValueOf(text).Convert(p.argType), nil
+	}
+	arg, err := convertToType(decodeFormValue(strings.TrimSpace(string(data)), p.argType), p.argType)
+	if err != nil {
+		return reflect.Value{}, conversionError(p, err)
+	}
+	return arg, nil
+}
+
+// plainText renders a result for Accept: text/plain. Strings, bytes and
+// scalars are written bare; anything else is indented JSON.
+func plainText(data interface{}) ([]byte, error) {
+	switch v := data.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	}
+	switch reflect.ValueOf(data).Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return []byte(fmt.Sprint(data)), nil
+	}
+	out, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+// marshalYAML renders v as YAML using its JSON field names and order, so the
+// YAML matches the JSON envelope's data field for field
+func marshalYAML(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	// JSON is YAML; re-encoding the node tree drops the flow style
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	blockStyle(&node)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// blockStyle clears the JSON-derived styles so the encoder picks its own
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// respondFormatted writes a result or error body in a non-JSON format. A
+// result that cannot be rendered becomes an INTERNAL error.
+func respondFormatted(c *gin.Context, status int, format string, render func() ([]byte, error)) {
+	out, err := render()
+	if err != nil {
+		c.Set(errorCodeKey, sharedErrors.ErrCodeInternal)
+		c.AbortWithStatusJSON(http.StatusInternalServerError, ResponseWrapper{
+			Error: &ErrorBody{Code: sharedErrors.ErrCodeInternal, Message: "could not render the result: " + err.Error()},
+		})
+		return
+	}
+	contentType := format + "; charset=utf-8"
+	if status >= http.StatusBadRequest {
+		c.Abort()
+	}
+	c.Data(status, contentType, out)
+}
@@ -0,0 +1,166 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	sharedErrors "devtoolbox/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type textService struct{}
+
+type textSummary struct {
+	Upper  string `json:"upper"`
+	Length int    `json:"length"`
+	Note   string `json:"note,omitempty"`
+}
+
+func (s *textService) Double(n int) int {
+	return n * 2
+}
+
+func (s *textService) Summarize(input string) textSummary {
+	return textSummary{Upper: strings.ToUpper(input), Length: len(input), Note: "123"}
+}
+
+func newTextEngine(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	server := NewServer()
+	server.SetParamNames(map[string][]string{
+		"MultiParamService.Combine": {"a", "b", "c"},
+		"uploadService.Digest":      {"input", "method", "config"},
+	})
+	for _, svc := range []interface{}{&textService{}, &MultiParamService{}, &TestService{}, &uploadService{}, &erroringService{}} {
+		require.NoError(t, server.Register(svc))
+	}
+	return server.Engine()
+}
+
+func request(engine *gin.Engine, path, contentType, accept, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", path, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+func TestRouter_TextBody(t *testing.T) {
+	engine := newTextEngine(t)
+
+	t.Run("fills the string parameter less one trailing newline", func(t *testing.T) {
+		for body, want := range map[string]string{
+			"hi there":       "HI THERE",
+			"hi there\n":     "HI THERE",
+			"hi there\r\n":   "HI THERE",
+			"hi\nthere\n\n":  "HI\nTHERE\n",
+			" hi there \t\n": " HI THERE \t",
+		} {
+			w := request(engine, "/api/text-service/summarize", "text/plain", "", body)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			var result textSummary
+			assert.Nil(t, decodeEnvelope(t, w.Body.Bytes(), &result))
+			assert.Equal(t, want, result.Upper, "%q", body)
+		}
+	})
+
+	t.Run("other parameters come from the query", func(t *testing.T) {
+		w := request(engine, "/api/multi-param-service/combine?b=y&c=z", "text/plain; charset=utf-8", "", "x")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var result string
+		assert.Nil(t, decodeEnvelope(t, w.Body.Bytes(), &result))
+		assert.Equal(t, "x-y-z", result)
+	})
+
+	t.Run("missing query parameter", func(t *testing.T) {
+		w := request(engine, "/api/multi-param-service/combine?b=y", "text/plain", "", "x")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		errBody := decodeEnvelope(t, w.Body.Bytes(), nil)
+		if assert.NotNil(t, errBody) {
+			assert.Equal(t, ErrCodeMissingParameter, errBody.Code)
+		}
+	})
+
+	t.Run("lone primitive is parsed", func(t *testing.T) {
+		w := request(engine, "/api/text-service/double", "text/plain", "", "21\n")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var result int
+		assert.Nil(t, decodeEnvelope(t, w.Body.Bytes(), &result))
+		assert.Equal(t, 42, result)
+	})
+
+	t.Run("reader parameter streams the body", func(t *testing.T) {
+		w := request(engine, "/api/upload-service/digest?method=len", "text/plain", "", "hello")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var result string
+		assert.Nil(t, decodeEnvelope(t, w.Body.Bytes(), &result))
+		assert.Equal(t, "len:hello:", result)
+	})
+
+	t.Run("struct parameter", func(t *testing.T) {
+		w := request(engine, "/api/test-service/echo", "text/plain", "", "hi")
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+		errBody := decodeEnvelope(t, w.Body.Bytes(), nil)
+		if assert.NotNil(t, errBody) {
+			assert.Equal(t, ErrCodeUnsupportedType, errBody.Code)
+		}
+	})
+}
+
+func TestRouter_NegotiatedResponse(t *testing.T) {
+	engine := newTextEngine(t)
+
+	tests := []struct {
+		name        string
+		path        string
+		accept      string
+		body        string
+		status      int
+		contentType string
+		expected    string
+	}{
+		{"bare string", "/api/multi-param-service/combine", "text/plain", `{"a":"x","b":"y","c":"z"}`,
+			http.StatusOK, "text/plain; charset=utf-8", "x-y-z"},
+		{"bare number", "/api/text-service/double", "text/plain", `{"value":4}`,
+			http.StatusOK, "text/plain; charset=utf-8", "8"},
+		{"struct as text", "/api/text-service/summarize", "text/plain", `{"value":"ab"}`,
+			http.StatusOK, "text/plain; charset=utf-8", "{\n  \"upper\": \"AB\",\n  \"length\": 2,\n  \"note\": \"123\"\n}\n"},
+		{"struct as yaml", "/api/text-service/summarize", "application/yaml", `{"value":"ab"}`,
+			http.StatusOK, "application/yaml; charset=utf-8", "upper: AB\nlength: 2\nnote: \"123\"\n"},
+		{"x-yaml alias", "/api/text-service/double", "application/x-yaml", `{"value":4}`,
+			http.StatusOK, "application/yaml; charset=utf-8", "8\n"},
+		{"error as text", "/api/erroring-service/domain", "text/plain", `{}`,
+			http.StatusBadRequest, "text/plain; charset=utf-8", sharedErrors.ErrCodeInvalidInput + ": bad input\n"},
+		{"error as yaml", "/api/erroring-service/domain", "application/yaml", `{}`,
+			http.StatusBadRequest, "application/yaml; charset=utf-8",
+			"data: null\nerror:\n  code: " + sharedErrors.ErrCodeInvalidInput + "\n  message: bad input\n  details:\n    field: input\n"},
+		{"wildcard keeps json", "/api/text-service/double", "*/*", `{"value":4}`,
+			http.StatusOK, "application/json; charset=utf-8", `{"data":8,"error":null}`},
+		{"unsupported keeps json", "/api/text-service/double", "text/html", `{"value":4}`,
+			http.StatusOK, "application/json; charset=utf-8", `{"data":8,"error":null}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := request(engine, tt.path, "application/json", tt.accept, tt.body)
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, tt.contentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expected, w.Body.String())
+		})
+	}
+
+	t.Run("text in, text out", func(t *testing.T) {
+		w := request(engine, "/api/multi-param-service/combine?b=y&c=z", "text/plain", "text/plain", "x")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "x-y-z", w.Body.String())
+	})
+}
@@ -0,0 +1,410 @@
+package router
+
+import (
+	"encoding/json"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// OpenAPI version emitted by the router
+const openAPIVersion = "3.1.0"
+
+// APIVersion is the version of the API described by the router
+const APIVersion = "1.0.0"
+
+// componentRefPrefix prefixes references to schemas in Components
+const componentRefPrefix = "#/components/schemas/"
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	errorType      = reflect.TypeOf((*error)(nil)).Elem()
+	binaryType     = reflect.TypeOf((*Binary)(nil)).Elem()
+)
+
+// OpenAPIDocument is the root of an OpenAPI 3.1 document
+type OpenAPIDocument struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       OpenAPIInfo                      `json:"info"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components Components                       `json:"components"`
+}
+
+// OpenAPIInfo describes the API
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Components holds reusable schemas referenced from operations
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Operation describes a single API operation
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter describes a query parameter
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody describes an operation's request payload
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response describes an operation's response
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the schema for a content type
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema is the subset of JSON Schema used to describe Go types
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	// Defs holds the named structs of a standalone schema, such as an MCP
+	// tool's input schema
+	Defs map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// OpenAPI builds an OpenAPI document describing every registered route
+func (r *Router) OpenAPI() *OpenAPIDocument {
+	doc := &OpenAPIDocument{
+		OpenAPI: openAPIVersion,
+		Info: OpenAPIInfo{
+			Title:       "DevToolbox API",
+			Description: "Routes generated from the registered DevToolbox services",
+			Version:     APIVersion,
+		},
+		Paths:      map[string]map[string]*Operation{},
+		Components: Components{Schemas: map[string]*Schema{}},
+	}
+
+	for _, ep := range r.endpoints {
+		doc.Paths[ep.path] = map[string]*Operation{
+			"post": buildOperation(ep, doc.Components.Schemas),
+		}
+	}
+
+	return doc
+}
+
+// buildOperation describes an endpoint using the same binding rules as createHandler
+func buildOperation(ep endpoint, schemas map[string]*Schema) *Operation {
+	methodType := ep.method.Type
+	op := &Operation{
+		OperationID: ep.name(),
+		Summary:     ep.name(),
+		Description: ep.doc,
+		Tags:        []string{ep.service},
+		Responses:   map[string]*Response{},
+	}
+
+	params := ep.params
+
+	var body *Schema
+	switch {
+	case len(params) == 1 && params[0].argType.Kind() == reflect.Struct:
+		body = schemaFor(params[0].argType, schemas)
+	case len(params) > 0:
+		body = &Schema{Type: "object", Properties: map[string]*Schema{}}
+		for _, p := range params {
+			key := p.label()
+			if len(params) == 1 && p.name == "" {
+				key = "value"
+			}
+			body.Properties[key] = schemaFor(p.argType, schemas)
+			if p.required() {
+				body.Required = append(body.Required, key)
+			}
+		}
+	}
+	if body != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{"application/json": {Schema: body}},
+		}
+	}
+	if ep.upload >= 0 {
+		addUploadContent(op, ep, schemas)
+	}
+	if ep.text >= 0 {
+		addTextContent(op, ep, schemas)
+	}
+
+	// Results: first non-error value is the payload
+	var data *Schema
+	if out := firstResult(methodType); out != nil {
+		data = schemaFor(out, schemas)
+	}
+	errorBody := schemaFor(reflect.TypeOf(ErrorBody{}), schemas)
+
+	op.Responses["200"] = envelopeResponse("Successful response", data, nil)
+	if out := firstResult(methodType); out == readerType {
+		op.Responses["200"] = &Response{
+			Description: "Successful response",
+			Content:     map[string]*MediaType{mimeOctetStream: {Schema: binarySchema()}},
+		}
+	} else if out != nil && out.Implements(binaryType) {
+		contentType := reflect.Zero(out).Interface().(Binary).ContentType()
+		op.Responses["200"].Content[contentType] = &MediaType{Schema: binarySchema()}
+	}
+	// Accept: text/plain and application/yaml return the bare result
+	op.Responses["200"].Content[mimeText] = &MediaType{Schema: &Schema{Type: "string"}}
+	if data != nil {
+		op.Responses["200"].Content[mimeYAML] = &MediaType{Schema: data}
+	}
+	op.Responses["400"] = envelopeResponse("Malformed request or invalid input", nil, errorBody)
+	op.Responses["413"] = envelopeResponse("Request body too large", nil, errorBody)
+	op.Responses["422"] = envelopeResponse("The tool could not process the input", data, errorBody)
+	op.Responses["500"] = envelopeResponse("Internal error", nil, errorBody)
+	op.Responses["504"] = envelopeResponse("The call missed its deadline", nil, errorBody)
+
+	return op
+}
+
+// addUploadContent describes the multipart and raw bodies accepted by an
+// upload endpoint. Raw bodies take the other parameters from the query.
+func addUploadContent(op *Operation, ep endpoint, schemas map[string]*Schema) {
+	form := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for i, p := range ep.params {
+		if i == ep.upload {
+			form.Properties[p.label()] = binarySchema()
+			form.Required = append(form.Required, p.label())
+			continue
+		}
+		form.Properties[p.label()] = schemaFor(p.argType, schemas)
+		if p.required() {
+			form.Required = append(form.Required, p.label())
+		}
+		op.Parameters = append(op.Parameters, &Parameter{
+			Name:        p.label(),
+			In:          "query",
+			Description: "Used with application/octet-stream bodies",
+			Schema:      schemaFor(p.argType, schemas),
+		})
+	}
+
+	if op.RequestBody == nil {
+		op.RequestBody = &RequestBody{Required: true, Content: map[string]*MediaType{}}
+	}
+	op.RequestBody.Content[mimeMultipart] = &MediaType{Schema: form}
+	op.RequestBody.Content[mimeOctetStream] = &MediaType{Schema: binarySchema()}
+}
+
+// addTextContent describes the text/plain body accepted for the text
+// parameter. The other parameters come from the query.
+func addTextContent(op *Operation, ep endpoint, schemas map[string]*Schema) {
+	listed := map[string]*Parameter{}
+	for _, qp := range op.Parameters {
+		listed[qp.Name] = qp
+	}
+	for i, p := range ep.params {
+		if i == ep.text {
+			continue
+		}
+		// Upload endpoints already list their query parameters
+		if qp, ok := listed[p.label()]; ok {
+			qp.Description = "Used with application/octet-stream and text/plain bodies"
+			continue
+		}
+		op.Parameters = append(op.Parameters, &Parameter{
+			Name:        p.label(),
+			In:          "query",
+			Description: "Used with text/plain bodies",
+			Schema:      schemaFor(p.argType, schemas),
+		})
+	}
+
+	if op.RequestBody == nil {
+		op.RequestBody = &RequestBody{Required: true, Content: map[string]*MediaType{}}
+	}
+	op.RequestBody.Content[mimeText] = &MediaType{Schema: &Schema{Type: "string"}}
+}
+
+// firstResult returns a method's first non-error result type, or nil
+func firstResult(methodType reflect.Type) reflect.Type {
+	for i := 0; i < methodType.NumOut(); i++ {
+		if out := methodType.Out(i); out != errorType {
+			return out
+		}
+	}
+	return nil
+}
+
+// binarySchema describes raw bytes
+func binarySchema() *Schema {
+	return &Schema{Type: "string", Format: "binary"}
+}
+
+// envelopeResponse describes a ResponseWrapper with the given data and error schemas
+func envelopeResponse(description string, data, errorBody *Schema) *Response {
+	if data == nil {
+		data = &Schema{Type: "null"}
+	}
+	if errorBody == nil {
+		errorBody = &Schema{Type: "null"}
+	}
+	return &Response{
+		Description: description,
+		Content: map[string]*MediaType{
+			"application/json": {Schema: &Schema{
+				Type: "object",
+				Properties: map[string]*Schema{
+					"data":  data,
+					"error": errorBody,
+				},
+				Required: []string{"data", "error"},
+			}},
+		},
+	}
+}
+
+// zero is the minimum of unsigned integer schemas
+var zero = 0.0
+
+// schemaFor returns the JSON schema for t, registering named structs as components
+func schemaFor(t reflect.Type, schemas map[string]*Schema) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawMessageType:
+		return &Schema{}
+	case readerType:
+		// JSON callers send reader inputs as text
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaFor(t.Elem(), schemas)
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64:
+		// int is 64-bit on every supported target
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer", Format: "int32", Minimum: &zero}
+	case reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int64", Minimum: &zero}
+	case reflect.Uint, reflect.Uint64:
+		// No format holds the full range
+		return &Schema{Type: "integer", Minimum: &zero}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json marshals []byte as base64
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: schemaFor(t.Elem(), schemas)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: schemaFor(t.Elem(), schemas)}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return structSchema(t, schemas)
+		}
+		name := schemaName(t)
+		if _, ok := schemas[name]; !ok {
+			// Reserve the name first so recursive types terminate
+			schemas[name] = &Schema{}
+			*schemas[name] = *structSchema(t, schemas)
+		}
+		return &Schema{Ref: componentRefPrefix + name}
+	default:
+		// interface{} and anything else accepts any JSON value
+		return &Schema{}
+	}
+}
+
+// structSchema describes a struct's fields following encoding/json rules
+func structSchema(t reflect.Type, schemas map[string]*Schema) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, omit := jsonFieldName(field)
+		if omit {
+			continue
+		}
+
+		// Embedded structs without a tag are flattened into the parent
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded := structSchema(ft, schemas)
+				for k, v := range embedded.Properties {
+					schema.Properties[k] = v
+				}
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		schema.Properties[name] = schemaFor(field.Type, schemas)
+	}
+
+	return schema
+}
+
+// jsonFieldName returns the JSON name for a struct field and whether it is skipped
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	return name, false
+}
+
+// schemaName returns the component name for a named type, e.g. "jwt.DecodeResponse"
+func schemaName(t reflect.Type) string {
+	if t.PkgPath() == "" {
+		return t.Name()
+	}
+	return path.Base(t.PkgPath()) + "." + t.Name()
+}
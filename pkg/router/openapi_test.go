@@ -0,0 +1,139 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type openAPIEmbedded struct {
+	ID string `json:"id"`
+}
+
+type openAPIRequest struct {
+	openAPIEmbedded
+	Inputs  []string          `json:"inputs"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Nested  *openAPIRequest   `json:"nested,omitempty"`
+	Ignored string            `json:"-"`
+}
+
+type openAPIService struct{}
+
+func (s *openAPIService) Run(req openAPIRequest) (EchoResponse, error) {
+	return EchoResponse{}, nil
+}
+
+func (s *openAPIService) Join(a string, b int) string {
+	return ""
+}
+
+func TestRouter_OpenAPI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := New(gin.New())
+	require.NoError(t, router.Register(&openAPIService{}))
+
+	doc := router.OpenAPI()
+	assert.Equal(t, "3.1.0", doc.OpenAPI)
+
+	run := doc.Paths["/api/open-api-service/run"]["post"]
+	require.NotNil(t, run)
+	assert.Equal(t, "openAPIService.Run", run.OperationID)
+	assert.Equal(t, "#/components/schemas/router.openAPIRequest",
+		run.RequestBody.Content["application/json"].Schema.Ref)
+	ok := run.Responses["200"].Content["application/json"].Schema
+	assert.Equal(t, "#/components/schemas/router.EchoResponse", ok.Properties["data"].Ref)
+	assert.Equal(t, "null", ok.Properties["error"].Type)
+	failed := run.Responses["400"].Content["application/json"].Schema
+	assert.Equal(t, "#/components/schemas/router.ErrorBody", failed.Properties["error"].Ref)
+
+	req := doc.Components.Schemas["router.openAPIRequest"]
+	require.NotNil(t, req)
+	assert.Contains(t, req.Properties, "id", "embedded fields are flattened")
+	assert.Equal(t, "array", req.Properties["inputs"].Type)
+	assert.Equal(t, "string", req.Properties["inputs"].Items.Type)
+	assert.Equal(t, "string", req.Properties["labels"].AdditionalProperties.Type)
+	assert.Equal(t, "#/components/schemas/router.openAPIRequest", req.Properties["nested"].Ref)
+	assert.NotContains(t, req.Properties, "Ignored")
+
+	join := doc.Paths["/api/open-api-service/join"]["post"]
+	require.NotNil(t, join)
+	body := join.RequestBody.Content["application/json"].Schema
+	assert.Equal(t, "string", body.Properties["arg0"].Type)
+	assert.Equal(t, "integer", body.Properties["arg1"].Type)
+	assert.Equal(t, []string{"arg0", "arg1"}, body.Required)
+}
+
+func TestSchemaFor_Integers(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		format   string
+		unsigned bool
+	}{
+		{int8(0), "int32", false},
+		{int32(0), "int32", false},
+		{0, "int64", false},
+		{int64(0), "int64", false},
+		{uint16(0), "int32", true},
+		{uint32(0), "int64", true},
+		{uint(0), "", true},
+		{uint64(0), "", true},
+	}
+	for _, tt := range tests {
+		typ := reflect.TypeOf(tt.value)
+		t.Run(typ.String(), func(t *testing.T) {
+			schema := schemaFor(typ, map[string]*Schema{})
+			assert.Equal(t, "integer", schema.Type)
+			assert.Equal(t, tt.format, schema.Format)
+			if tt.unsigned {
+				require.NotNil(t, schema.Minimum)
+				assert.Zero(t, *schema.Minimum)
+			} else {
+				assert.Nil(t, schema.Minimum)
+			}
+		})
+	}
+}
+
+func TestRouter_OpenAPINamedParameters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := New(gin.New())
+	router.SetParamNames(map[string][]string{"openAPIService.Join": {"prefix", "count"}})
+	require.NoError(t, router.Register(&openAPIService{}))
+
+	join := router.OpenAPI().Paths["/api/open-api-service/join"]["post"]
+	body := join.RequestBody.Content["application/json"].Schema
+	assert.Contains(t, body.Properties, "prefix")
+	assert.Contains(t, body.Properties, "count")
+	assert.Equal(t, []string{"prefix", "count"}, body.Required)
+}
+
+func TestServer_OpenAPIEndpoints(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	server := NewServer()
+	require.NoError(t, server.Register(&TestService{}))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/openapi.json", nil)
+	server.Engine().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var doc OpenAPIDocument
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Contains(t, doc.Paths, "/api/test-service/echo")
+	assert.NotContains(t, doc.Paths, "/api/test-service/service-startup")
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/docs", nil)
+	server.Engine().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, w.Body.String(), "openapi.json")
+}
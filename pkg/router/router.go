@@ -1,32 +1,119 @@
 package router
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"reflect"
 	"strings"
+	"sync"
+	"time"
 	"unicode"
 
+	sharedErrors "devtoolbox/pkg/errors"
+	"devtoolbox/pkg/tools"
+
 	"github.com/gin-gonic/gin"
 )
 
 // Router automatically discovers and registers service methods as HTTP routes
 type Router struct {
-	engine *gin.Engine
+	engine     *gin.Engine
+	endpoints  []endpoint
+	byName     map[string]int // endpoint index by "Service.Method"
+	paramNames map[string][]string
+	methodDocs map[string]string
+	limits     Limits
+	metrics    *Metrics
+	// notifications holds a slot per running notification; nil for no cap
+	notifications chan struct{}
+	// background tracks running notifications, which outlive their request
+	background sync.WaitGroup
+	tools      *tools.Registry // described at GET /api/tools; nil until RegisterTools
+}
+
+// endpoint describes a service method exposed as a route
+type endpoint struct {
+	service string
+	method  reflect.Method
+	path    string
+	doc     string // first sentence of the method's doc comment, if any
+	params  []param
+	upload  int           // index of the parameter that accepts raw uploads, or -1
+	text    int           // index of the parameter that accepts text/plain bodies, or -1
+	context bool          // the method takes a context.Context first
+	fn      reflect.Value // method bound to the service instance
+}
+
+// name returns the endpoint's "Service.Method" name
+func (ep endpoint) name() string {
+	return ep.service + "." + ep.method.Name
+}
+
+// param describes a bound method parameter
+type param struct {
+	name    string // Go parameter name, empty when not in the registry
+	key     string // positional fallback key: "arg0", "arg1", ...
+	argType reflect.Type
+}
+
+// label returns the name used to refer to the parameter in requests and errors
+func (p param) label() string {
+	if p.name != "" {
+		return p.name
+	}
+	return p.key
+}
+
+// required reports whether a request must provide the parameter.
+// Nillable types (maps, slices, pointers, interfaces) fall back to their zero
+// value, except for upload inputs.
+func (p param) required() bool {
+	if isUploadType(p.argType) {
+		return true
+	}
+	switch p.argType.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Pointer, reflect.Interface:
+		return false
+	}
+	return true
 }
 
 // New creates a new Router with the given Gin engine
 func New(engine *gin.Engine) *Router {
-	return &Router{engine: engine}
+	return &Router{engine: engine, byName: map[string]int{}, metrics: NewMetrics()}
+}
+
+// SetParamNames sets the Go parameter names for service methods, keyed by
+// "Service.Method". Reflection cannot recover them, so they are generated by
+// cmd/genservices. Must be called before Register.
+func (r *Router) SetParamNames(names map[string][]string) {
+	r.paramNames = names
+}
+
+// SetMethodDocs sets the doc summaries of service methods, keyed by
+// "Service.Method", used to describe MCP tools and OpenAPI operations. Like
+// the parameter names they are generated by cmd/genservices. Must be called
+// before Register.
+func (r *Router) SetMethodDocs(docs map[string]string) {
+	r.methodDocs = docs
 }
 
 // Register scans a service struct and auto-generates routes for all exported methods
 func (r *Router) Register(service interface{}) error {
+	r.register(reflect.TypeOf(service).Elem().Name(), service)
+	return nil
+}
+
+// register routes the exported methods of service as those of the service
+// called typeName
+func (r *Router) register(typeName string, service interface{}) {
 	serviceType := reflect.TypeOf(service)
 	serviceValue := reflect.ValueOf(service)
-
-	// Get service name and convert to kebab-case
-	serviceName := toKebabCase(serviceType.Elem().Name())
+	serviceName := toKebabCase(typeName)
 
 	// Iterate through all methods
 	for i := 0; i < serviceType.NumMethod(); i++ {
@@ -41,152 +128,354 @@ func (r *Router) Register(service interface{}) error {
 		methodName := toKebabCase(method.Name)
 		path := fmt.Sprintf("/api/%s/%s", serviceName, methodName)
 
+		ep := endpoint{
+			service: typeName,
+			method:  method,
+			path:    path,
+			doc:     r.methodDocs[typeName+"."+method.Name],
+			params:  r.methodParams(typeName, method),
+			context: takesContext(method.Type),
+			fn:      serviceValue.Method(i),
+		}
+		ep.upload = uploadParam(ep.params)
+		ep.text = textParam(ep.params)
+
 		// Create handler
-		handler := r.createHandler(serviceValue.Method(i), method)
-		r.engine.POST(path, handler)
+		r.engine.POST(path, r.createHandler(ep))
+
+		r.byName[ep.name()] = len(r.endpoints)
+		r.endpoints = append(r.endpoints, ep)
 	}
+}
 
-	return nil
+// methodParams describes the parameters of a method (excluding the receiver
+// and a leading context.Context, which the router fills in)
+func (r *Router) methodParams(service string, method reflect.Method) []param {
+	names := r.paramNames[service+"."+method.Name]
+	first := 1
+	if takesContext(method.Type) {
+		first = 2
+	}
+	numIn := method.Type.NumIn()
+
+	// A stale registry entry is worse than none
+	if len(names) != numIn-first {
+		names = nil
+	}
+
+	params := make([]param, 0, numIn-first)
+	for i := first; i < numIn; i++ {
+		p := param{
+			key:     argKey(i - first),
+			argType: method.Type.In(i),
+		}
+		if names != nil {
+			p.name = names[i-first]
+		}
+		params = append(params, p)
+	}
+	return params
+}
+
+// takesContext reports whether a method's first parameter after the receiver
+// is a context.Context
+func takesContext(methodType reflect.Type) bool {
+	return methodType.NumIn() > 1 && methodType.In(1) == contextType
 }
 
 // createHandler creates a Gin handler for a method
-func (r *Router) createHandler(methodValue reflect.Value, method reflect.Method) gin.HandlerFunc {
+func (r *Router) createHandler(ep endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Get method signature
-		methodType := method.Type
-		numIn := methodType.NumIn()
-
-		// Prepare arguments
-		args := make([]reflect.Value, numIn-1) // -1 because receiver is first
-
-		if numIn > 1 {
-			// Method has parameters - create a wrapper struct to hold all params
-			type paramInfo struct {
-				name    string
-				argType reflect.Type
-				index   int
+		// Record the call once the response, including any stream, is written
+		start := time.Now()
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				r.metrics.observe(ep.name(), transportHTTP, sharedErrors.ErrCodeInternal, time.Since(start))
+				panic(recovered)
 			}
+			r.metrics.observe(ep.name(), transportHTTP, c.GetString(errorCodeKey), time.Since(start))
+		}()
 
-			var params []paramInfo
-			for i := 1; i < numIn; i++ {
-				params = append(params, paramInfo{
-					name:    methodType.In(i).Name(),
-					argType: methodType.In(i),
-					index:   i - 1,
-				})
-			}
+		raw := ep.bodyParam(c)
+		r.limitBody(c, raw >= 0 && isUploadType(ep.params[raw].argType))
 
-			// Single parameter handling
-			if len(params) == 1 {
-				param := params[0]
-
-				if param.argType.Kind() == reflect.Struct {
-					// Single struct parameter - bind directly
-					argValue := reflect.New(param.argType).Interface()
-					if err := c.ShouldBindJSON(argValue); err != nil {
-						c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-						return
-					}
-					args[0] = reflect.ValueOf(argValue).Elem()
-				} else {
-					// Single primitive parameter - use "value" field convention
-					var wrapper struct {
-						Value interface{} `json:"value"`
-					}
-					if err := c.ShouldBindJSON(&wrapper); err != nil {
-						c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-						return
-					}
-					args[0] = convertToType(wrapper.Value, param.argType)
-				}
-			} else {
-				// Multiple parameters - bind to a map and use "arg0", "arg1", etc.
-				var requestMap map[string]interface{}
-				if err := c.ShouldBindJSON(&requestMap); err != nil {
-					c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-					return
-				}
-
-				// Convert each parameter
-				for _, param := range params {
-					// Use "arg0", "arg1", etc. as keys for multiple parameters
-					key := fmt.Sprintf("arg%d", param.index)
-					value, exists := requestMap[key]
-					if !exists {
-						// Use zero value if not provided
-						args[param.index] = reflect.Zero(param.argType)
-						continue
-					}
-
-					// Convert value to expected type
-					argValue := convertToType(value, param.argType)
-					args[param.index] = argValue
-				}
-			}
+		// The deadline also covers streaming the result
+		ctx, cancel := r.callContext(c.Request.Context(), ep)
+		defer cancel()
+
+		args, err := bindRequest(c, ep)
+		if err != nil {
+			respondError(c, err, nil)
+			return
 		}
-		// If no parameters, args is already empty
 
-		// Call the method
-		results := methodValue.Call(args)
+		// In-band failures keep the service's response alongside the error
+		result, err := ep.call(ctx, args)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			respondError(c, r.contextError(ep, ctxErr), nil)
+			return
+		}
+		if err != nil {
+			respondError(c, err, result)
+			return
+		}
 
-		// Handle return values (result, error)
-		if len(results) == 2 {
-			// Check for error
-			if !results[1].IsNil() {
-				err := results[1].Interface().(error)
-				c.JSON(http.StatusOK, gin.H{"error": err.Error()})
-				return
-			}
+		// Streams are always raw; binary-capable results only when asked for
+		if r, ok := result.(io.Reader); ok {
+			respondStream(c, r)
+			return
+		}
+		if b, ok := result.(Binary); ok && acceptsBinary(c, b.ContentType()) && len(b.Bytes()) > 0 {
+			respondBinary(c, b)
+			return
+		}
+
+		respondData(c, result)
+	}
+}
+
+// bindRequest builds a method's arguments from a REST request
+func bindRequest(c *gin.Context, ep endpoint) ([]reflect.Value, error) {
+	params := ep.params
+
+	switch {
+	case len(params) == 0:
+		return nil, nil
+
+	case isTextRequest(c):
+		// Text body - fills the text parameter, the others come from the query
+		if ep.text < 0 {
+			return nil, sharedErrors.NewDomainError(ErrCodeUnsupportedType,
+				fmt.Sprintf("%s does not accept a text/plain body", ep.name()))
+		}
+		return bindParams(ep, queryValues(c), c.Request.Body, ep.text)
+
+	case len(params) == 1 && params[0].argType.Kind() == reflect.Struct:
+		// Single struct parameter - bind directly
+		argValue := reflect.New(params[0].argType)
+		if err := c.ShouldBindJSON(argValue.Interface()); err != nil {
+			return nil, invalidRequest(err)
+		}
+		return []reflect.Value{argValue.Elem()}, nil
+
+	case ep.upload >= 0 && isUploadRequest(c):
+		// Raw or multipart upload - other parameters come from the query or form
+		values, body, err := readUpload(c, params[ep.upload])
+		if err != nil {
+			return nil, invalidRequest(err)
+		}
+		return bindParams(ep, values, body, ep.upload)
 
-			// Return result
-			c.JSON(http.StatusOK, results[0].Interface())
-		} else if len(results) == 1 {
-			c.JSON(http.StatusOK, results[0].Interface())
+	default:
+		// Primitive or multiple parameters - bind to a map keyed by parameter name,
+		// falling back to "arg0", "arg1", etc. A single primitive may also use "value".
+		var requestMap map[string]interface{}
+		if err := decodeJSON(c.Request.Body, &requestMap); err != nil {
+			return nil, invalidRequest(err)
 		}
+		return bindParams(ep, requestMap, nil, -1)
 	}
 }
 
-// convertToType converts an interface{} value to the expected reflect.Type
-func convertToType(value interface{}, targetType reflect.Type) reflect.Value {
-	switch targetType.Kind() {
-	case reflect.String:
-		if s, ok := value.(string); ok {
-			return reflect.ValueOf(s)
+// bindParams converts request values into method arguments. A non-nil body
+// fills the parameter at index raw, and the values are then query or form
+// strings.
+func bindParams(ep endpoint, values map[string]interface{}, body io.Reader, raw int) ([]reflect.Value, error) {
+	params := ep.params
+	args := make([]reflect.Value, len(params))
+
+	for i, p := range params {
+		if body != nil && i == raw {
+			arg, err := bodyArg(body, p)
+			if err != nil {
+				return nil, invalidRequest(err)
+			}
+			args[i] = arg
+			continue
 		}
-		return reflect.ValueOf("")
-	case reflect.Int:
-		if f, ok := value.(float64); ok {
-			return reflect.ValueOf(int(f))
+
+		value, exists := lookupParam(values, p, len(params) == 1)
+		if !exists {
+			if p.required() {
+				return nil, sharedErrors.NewDomainError(ErrCodeMissingParameter,
+					fmt.Sprintf("missing required parameter %q", p.label())).
+					WithDetails(map[string]interface{}{"parameter": p.label()})
+			}
+			// Use zero value if not provided
+			args[i] = reflect.Zero(p.argType)
+			continue
 		}
-		return reflect.ValueOf(0)
-	case reflect.Int64:
-		if f, ok := value.(float64); ok {
-			return reflect.ValueOf(int64(f))
+
+		// Query and form values arrive as strings
+		if body != nil {
+			value = decodeFormValue(value, p.argType)
 		}
-		return reflect.ValueOf(int64(0))
-	case reflect.Float64:
-		if f, ok := value.(float64); ok {
-			return reflect.ValueOf(f)
+
+		arg, err := convertToType(value, p.argType)
+		if err != nil {
+			return nil, conversionError(p, err)
 		}
-		return reflect.ValueOf(float64(0))
-	case reflect.Bool:
-		if b, ok := value.(bool); ok {
-			return reflect.ValueOf(b)
+		args[i] = arg
+	}
+
+	return args, nil
+}
+
+// bodyParam returns the index of the parameter that receives a raw or text
+// request body, or -1 when the body is JSON
+func (ep endpoint) bodyParam(c *gin.Context) int {
+	switch {
+	case isUploadRequest(c):
+		return ep.upload
+	case isTextRequest(c):
+		return ep.text
+	}
+	return -1
+}
+
+// bodyArg adapts a raw or text body to its parameter's type
+func bodyArg(body io.Reader, p param) (reflect.Value, error) {
+	if isUploadType(p.argType) {
+		return uploadArg(body, p.argType)
+	}
+	return textArg(body, p)
+}
+
+// call invokes the endpoint's method, passing ctx first when it takes one.
+// A returned error yields a nil result; an in-band failure keeps the result
+// so it can be reported with the error.
+func (ep endpoint) call(ctx context.Context, args []reflect.Value) (interface{}, error) {
+	if ep.context {
+		args = append([]reflect.Value{reflect.ValueOf(ctx)}, args...)
+	}
+	results := ep.fn.Call(args)
+
+	if n := len(results); n > 0 && ep.fn.Type().Out(n-1) == errorType {
+		if errValue := results[n-1]; !errValue.IsNil() {
+			return nil, errValue.Interface().(error)
 		}
-		return reflect.ValueOf(false)
-	case reflect.Map:
-		if m, ok := value.(map[string]interface{}); ok {
-			return reflect.ValueOf(m)
+		results = results[:n-1]
+	}
+
+	var result interface{}
+	if len(results) > 0 {
+		result = results[0].Interface()
+	}
+
+	// Response DTOs may carry a typed error in-band
+	if failed, ok := result.(sharedErrors.Failed); ok && failed.Err() != nil {
+		return result, failed.Err()
+	}
+	return result, nil
+}
+
+// invalidRequest reports a request body that could not be decoded. Errors
+// that already carry a code are returned as they are.
+func invalidRequest(err error) error {
+	if sharedErrors.CodeOf(err) != "" {
+		return err
+	}
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return sharedErrors.NewDomainError(ErrCodeRequestTooLarge,
+			fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit))
+	}
+	return sharedErrors.NewDomainError(ErrCodeInvalidRequest, err.Error())
+}
+
+// lookupParam finds a parameter's value by name, then by positional key.
+// Single-parameter methods also accept the "value" field convention.
+func lookupParam(requestMap map[string]interface{}, p param, single bool) (interface{}, bool) {
+	if p.name != "" {
+		if v, ok := requestMap[p.name]; ok {
+			return v, true
 		}
-		return reflect.ValueOf(map[string]interface{}{})
-	case reflect.Slice:
-		if s, ok := value.([]interface{}); ok {
-			return reflect.ValueOf(s)
+	}
+	if single {
+		if v, ok := requestMap["value"]; ok {
+			return v, true
 		}
-		return reflect.ValueOf([]interface{}{})
-	default:
-		return reflect.Zero(targetType)
 	}
+	v, ok := requestMap[p.key]
+	return v, ok
+}
+
+// decodeJSON decodes one JSON value from r into v, keeping numbers as
+// json.Number so that integers beyond float64's 53 bits reach their
+// parameters intact
+func decodeJSON(r io.Reader, v interface{}) error {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return errors.New("unexpected data after the JSON value")
+	}
+	return nil
+}
+
+// convertToType decodes a request value (as produced by encoding/json) into
+// targetType. Typed slices, nested structs, pointers, time.Time and every
+// numeric kind are supported; []byte takes base64 and io.Reader takes text.
+// A value of the wrong shape is an error rather than a zero value.
+func convertToType(value interface{}, targetType reflect.Type) (reflect.Value, error) {
+	// JSON callers send reader inputs as text
+	if targetType == readerType {
+		s, ok := value.(string)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("expected a string, got %s", jsonKind(value))
+		}
+		return reflect.ValueOf(strings.NewReader(s)), nil
+	}
+
+	// Round-trip through JSON so decoding follows the same rules (and field
+	// tags) as a struct bound from the request body
+	data, err := json.Marshal(value)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	target := reflect.New(targetType)
+	if err := json.Unmarshal(data, target.Interface()); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			if typeErr.Field != "" {
+				return reflect.Value{}, fmt.Errorf("expected %s, got %s at %q", typeErr.Type, typeErr.Value, typeErr.Field)
+			}
+			return reflect.Value{}, fmt.Errorf("expected %s, got %s", typeErr.Type, typeErr.Value)
+		}
+		return reflect.Value{}, err
+	}
+	return target.Elem(), nil
+}
+
+// jsonKind names the JSON type of a decoded value for error messages
+func jsonKind(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "bool"
+	case float64, json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	}
+	return "object"
+}
+
+// conversionError reports a parameter value that does not fit its type
+func conversionError(p param, err error) error {
+	return sharedErrors.NewDomainError(ErrCodeInvalidRequest,
+		fmt.Sprintf("invalid value for parameter %q: %v", p.label(), err)).
+		WithDetails(map[string]interface{}{"parameter": p.label()})
+}
+
+// argKey returns the positional request key for the i-th parameter
+func argKey(i int) string {
+	return fmt.Sprintf("arg%d", i)
 }
 
 // toKebabCase converts PascalCase to kebab-case
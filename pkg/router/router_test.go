@@ -3,12 +3,18 @@ package router
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // Test service
@@ -72,13 +78,30 @@ func TestRouter_Register(t *testing.T) {
 			assert.Equal(t, tt.expectedStatus, w.Code)
 
 			var resp EchoResponse
-			err = json.Unmarshal(w.Body.Bytes(), &resp)
-			assert.NoError(t, err)
+			errBody := decodeEnvelope(t, w.Body.Bytes(), &resp)
+			assert.Nil(t, errBody)
 			assert.Equal(t, tt.expectedMsg, resp.Message)
 		})
 	}
 }
 
+// decodeEnvelope unmarshals a response envelope, decoding its data into v
+func decodeEnvelope(t *testing.T, body []byte, v interface{}) *ErrorBody {
+	t.Helper()
+
+	var envelope struct {
+		Data  json.RawMessage `json:"data"`
+		Error *ErrorBody      `json:"error"`
+	}
+	if !assert.NoError(t, json.Unmarshal(body, &envelope)) {
+		return nil
+	}
+	if v != nil && len(envelope.Data) > 0 {
+		assert.NoError(t, json.Unmarshal(envelope.Data, v))
+	}
+	return envelope.Error
+}
+
 func TestToKebabCase(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -199,3 +222,221 @@ func TestRouter_MultipleParameters(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Contains(t, w.Body.String(), "first-second-third")
 }
+
+func TestRouter_NamedParameters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	router := New(r)
+	router.SetParamNames(map[string][]string{
+		"MultiParamService.Combine": {"a", "b", "c"},
+	})
+
+	err := router.Register(&MultiParamService{})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "named keys",
+			body:           `{"a": "first", "b": "second", "c": "third"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   "first-second-third",
+		},
+		{
+			name:           "positional fallback",
+			body:           `{"arg0": "first", "arg1": "second", "arg2": "third"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   "first-second-third",
+		},
+		{
+			name:           "mixed keys",
+			body:           `{"a": "first", "arg1": "second", "c": "third"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   "first-second-third",
+		},
+		{
+			name:           "missing parameter is named",
+			body:           `{"a": "first", "c": "third"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `missing required parameter \"b\"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			httpReq, _ := http.NewRequest("POST", "/api/multi-param-service/combine", bytes.NewBufferString(tt.body))
+			httpReq.Header.Set("Content-Type", "application/json")
+
+			r.ServeHTTP(w, httpReq)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestRouter_PrimitiveParameterByName(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	router := New(r)
+	router.SetParamNames(map[string][]string{"PrimitiveService.Process": {"input"}})
+
+	err := router.Register(&PrimitiveService{})
+	assert.NoError(t, err)
+
+	for _, body := range []string{`{"input": "hello"}`, `{"value": "hello"}`} {
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/api/primitive-service/process", bytes.NewBufferString(body))
+		httpReq.Header.Set("Content-Type", "application/json")
+
+		r.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "processed: hello")
+	}
+}
+
+func TestRouter_MissingPositionalParameter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	router := New(r)
+
+	err := router.Register(&MultiParamService{})
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("POST", "/api/multi-param-service/combine", bytes.NewBufferString(`{"arg0": "first"}`))
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	r.ServeHTTP(w, httpReq)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `missing required parameter \"arg1\"`)
+}
+
+// Test service with typed parameters
+type TypedService struct{}
+
+type typedOptions struct {
+	Label string   `json:"label"`
+	Tags  []string `json:"tags"`
+}
+
+func (s *TypedService) Describe(inputs []string, count uint8, offset int32, at time.Time, opts *typedOptions, data []byte) string {
+	label := "none"
+	if opts != nil {
+		label = opts.Label + "/" + strings.Join(opts.Tags, ",")
+	}
+	return fmt.Sprintf("%s|%d|%d|%s|%s|%s", strings.Join(inputs, ","), count, offset, at.UTC().Format(time.RFC3339), label, data)
+}
+
+func (s *TypedService) Span(from int64, to uint64) string {
+	return fmt.Sprintf("%d..%d", from, to)
+}
+
+func TestRouter_LargeIntegers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	server := NewServer()
+	server.SetParamNames(map[string][]string{"TypedService.Span": {"from", "to"}})
+	require.NoError(t, server.Register(&TypedService{}))
+
+	// Neither fits in a float64's 53-bit mantissa
+	const want = `"-9007199254740993..18446744073709551615"`
+
+	t.Run("json", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/api/typed-service/span",
+			strings.NewReader(`{"from": -9007199254740993, "to": 18446744073709551615}`))
+		httpReq.Header.Set("Content-Type", "application/json")
+		server.Engine().ServeHTTP(w, httpReq)
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), want)
+	})
+
+	t.Run("json-rpc", func(t *testing.T) {
+		resp := decodeRPC(t, postRPC(t, server,
+			`{"jsonrpc":"2.0","method":"TypedService.Span","params":[-9007199254740993, 18446744073709551615],"id":1}`))
+		assert.Nil(t, resp.Error)
+		assert.JSONEq(t, want, string(resp.Result))
+	})
+
+	t.Run("query value", func(t *testing.T) {
+		v, err := convertToType(decodeFormValue("9007199254740993", reflect.TypeOf(int64(0))), reflect.TypeOf(int64(0)))
+		require.NoError(t, err)
+		assert.Equal(t, int64(9007199254740993), v.Int())
+	})
+}
+
+func TestRouter_TypedParameters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	router := New(r)
+	router.SetParamNames(map[string][]string{
+		"TypedService.Describe": {"inputs", "count", "offset", "at", "opts", "data"},
+	})
+
+	err := router.Register(&TypedService{})
+	assert.NoError(t, err)
+
+	valid := `"inputs": ["a", "b"], "count": 3, "offset": -7, "at": "2024-01-02T03:04:05Z", "opts": {"label": "x", "tags": ["t1", "t2"]}, "data": "aGk="`
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"all types", `{` + valid + `}`, http.StatusOK, `a,b|3|-7|2024-01-02T03:04:05Z|x/t1,t2|hi`},
+		{"optional pointer", `{"inputs": [], "count": 0, "offset": 0, "at": "2024-01-02T03:04:05Z", "data": ""}`, http.StatusOK, `|0|0|2024-01-02T03:04:05Z|none|`},
+		{"wrong element type", `{` + valid + `, "inputs": ["a", 1]}`, http.StatusBadRequest, `invalid value for parameter \"inputs\": expected string, got number at \"1\"`},
+		{"unsigned overflow", `{` + valid + `, "count": 300}`, http.StatusBadRequest, `invalid value for parameter \"count\"`},
+		{"negative unsigned", `{` + valid + `, "count": -1}`, http.StatusBadRequest, `invalid value for parameter \"count\"`},
+		{"fractional integer", `{` + valid + `, "offset": 1.5}`, http.StatusBadRequest, `invalid value for parameter \"offset\"`},
+		{"bad time", `{` + valid + `, "at": "yesterday"}`, http.StatusBadRequest, `invalid value for parameter \"at\"`},
+		{"nested field", `{` + valid + `, "opts": {"tags": "t1"}}`, http.StatusBadRequest, `expected []string, got string at \"tags\"`},
+		{"bad base64", `{` + valid + `, "data": "not base64!"}`, http.StatusBadRequest, `invalid value for parameter \"data\"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			httpReq, _ := http.NewRequest("POST", "/api/typed-service/describe", bytes.NewBufferString(tt.body))
+			httpReq.Header.Set("Content-Type", "application/json")
+
+			r.ServeHTTP(w, httpReq)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+			if tt.expectedStatus == http.StatusBadRequest {
+				errBody := decodeEnvelope(t, w.Body.Bytes(), nil)
+				if assert.NotNil(t, errBody) {
+					assert.Equal(t, ErrCodeInvalidRequest, errBody.Code)
+					assert.Contains(t, errBody.Details, "parameter")
+				}
+			}
+		})
+	}
+}
+
+func TestConvertToType(t *testing.T) {
+	v, err := convertToType("abc", readerType)
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(v.Interface().(io.Reader))
+	assert.Equal(t, "abc", string(data))
+
+	_, err = convertToType(42.0, readerType)
+	assert.EqualError(t, err, "expected a string, got number")
+
+	v, err = convertToType(map[string]interface{}{"k": "v"}, reflect.TypeOf(map[string]string{}))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"k": "v"}, v.Interface())
+
+	_, err = convertToType("x", reflect.TypeOf(true))
+	assert.EqualError(t, err, "expected bool, got string")
+}
@@ -0,0 +1,332 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"time"
+
+	sharedErrors "devtoolbox/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JSON-RPC 2.0 error codes
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+	rpcInternalError  = -32603
+	rpcToolError      = -32000 // the service reported an error; see rpcErrorData
+)
+
+const rpcVersion = "2.0"
+
+// rpcRequest is a JSON-RPC 2.0 request. A nil ID means the "id" member was
+// absent, making the request a notification; an explicit null is kept.
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// rpcResponse is a JSON-RPC 2.0 response. Exactly one of Result and Error is set.
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// rpcError describes a failed call
+type rpcError struct {
+	Code    int           `json:"code"`
+	Message string        `json:"message"`
+	Data    *rpcErrorData `json:"data,omitempty"`
+}
+
+// rpcErrorData carries the router's error code, as used in the REST
+// envelope, and the service's response for in-band failures
+type rpcErrorData struct {
+	Code    string                 `json:"code"`
+	Details map[string]interface{} `json:"details,omitempty"`
+	Result  interface{}            `json:"result,omitempty"`
+}
+
+// handleRPC serves JSON-RPC 2.0 calls to every registered method, named
+// "Service.Method". Batches run in order; notifications run in the
+// background and get no response. A request with nothing to answer gets 204.
+// Each call has its method's deadline.
+func (r *Router) handleRPC(c *gin.Context) {
+	r.limitBody(c, false)
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		bodyErr := invalidRequest(err)
+		c.JSON(statusFor(bodyErr, errorCode(bodyErr)), rpcFailure(nil, rpcInvalidRequest, sharedErrors.MessageOf(bodyErr), rpcData(bodyErr, nil)))
+		return
+	}
+	body = bytes.TrimSpace(body)
+	if !json.Valid(body) {
+		c.JSON(http.StatusOK, rpcFailure(nil, rpcParseError, "parse error", nil))
+		return
+	}
+
+	if body[0] != '[' {
+		if resp := r.rpcCall(c.Request.Context(), body); resp != nil {
+			c.JSON(http.StatusOK, resp)
+			return
+		}
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	var batch []json.RawMessage
+	if err := json.Unmarshal(body, &batch); err != nil || len(batch) == 0 {
+		c.JSON(http.StatusOK, rpcFailure(nil, rpcInvalidRequest, "invalid request: empty batch", nil))
+		return
+	}
+
+	responses := make([]*rpcResponse, 0, len(batch))
+	for _, raw := range batch {
+		if resp := r.rpcCall(c.Request.Context(), raw); resp != nil {
+			responses = append(responses, resp)
+		}
+	}
+	if len(responses) == 0 {
+		c.Status(http.StatusNoContent)
+		return
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+// rpcCall handles one request of a call or batch. It returns nil for
+// notifications, which outlive the HTTP request.
+func (r *Router) rpcCall(ctx context.Context, raw json.RawMessage) *rpcResponse {
+	var req rpcRequest
+	if err := json.Unmarshal(raw, &req); err != nil || req.JSONRPC != rpcVersion || req.Method == "" || !validRPCID(req.ID) {
+		return rpcFailure(nil, rpcInvalidRequest, "invalid request", nil)
+	}
+
+	if req.ID == nil {
+		r.notify(ctx, req)
+		return nil
+	}
+
+	resp := r.rpcExecute(ctx, req)
+	resp.ID = req.ID
+	return resp
+}
+
+// notify runs a notification in the background once a slot is free under
+// MaxNotifications. It is dropped if ctx ends while it waits.
+func (r *Router) notify(ctx context.Context, req rpcRequest) {
+	if r.notifications != nil {
+		select {
+		case r.notifications <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+	}
+	r.background.Go(func() {
+		if r.notifications != nil {
+			defer func() { <-r.notifications }()
+		}
+		r.rpcExecute(context.WithoutCancel(ctx), req)
+	})
+}
+
+// wait waits until the notifications running in the background finish, or
+// until ctx is done
+func (r *Router) wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		r.background.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// rpcExecute resolves, binds and invokes a request's method
+func (r *Router) rpcExecute(ctx context.Context, req rpcRequest) (resp *rpcResponse) {
+	// Runs last, after a panic has become a response
+	start := time.Now()
+	found := false
+	defer func() {
+		if found {
+			r.metrics.observe(req.Method, transportRPC, resp.errorCode(), time.Since(start))
+		}
+	}()
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			resp = rpcFailure(nil, rpcInternalError, "internal error",
+				&rpcErrorData{Code: sharedErrors.ErrCodeInternal})
+		}
+	}()
+
+	i, found := r.byName[req.Method]
+	if !found {
+		return rpcFailure(nil, rpcMethodNotFound, fmt.Sprintf("method %q not found", req.Method), nil)
+	}
+	ep := r.endpoints[i]
+
+	args, err := bindRPCParams(ep, req.Params)
+	if err != nil {
+		return rpcFailure(nil, rpcInvalidParams, sharedErrors.MessageOf(err), rpcData(err, nil))
+	}
+
+	ctx, cancel := r.callContext(ctx, ep)
+	defer cancel()
+
+	result, err := ep.call(ctx, args)
+	if reader, ok := result.(io.Reader); ok && err == nil {
+		// Streams have no JSON form; they are returned as base64 bytes
+		data, readErr := r.readAllResult(reader)
+		result, err = data, readErr
+		if err != nil {
+			result = nil
+		}
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		err, result = r.contextError(ep, ctxErr), nil
+	}
+	if err != nil {
+		return rpcFailure(nil, rpcToolError, sharedErrors.MessageOf(err), rpcData(err, result))
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return rpcFailure(nil, rpcInternalError, err.Error(), &rpcErrorData{Code: sharedErrors.ErrCodeInternal})
+	}
+	return &rpcResponse{JSONRPC: rpcVersion, Result: data}
+}
+
+// bindRPCParams builds a method's arguments from JSON-RPC params. Objects
+// bind by parameter name as in the REST routes; arrays bind by position.
+// A single struct parameter takes the object itself or a one-element array.
+func bindRPCParams(ep endpoint, params json.RawMessage) ([]reflect.Value, error) {
+	params = bytes.TrimSpace(params)
+	if len(params) == 0 || string(params) == "null" {
+		params = json.RawMessage("{}")
+	}
+
+	var positional []json.RawMessage
+	switch params[0] {
+	case '{':
+	case '[':
+		if err := json.Unmarshal(params, &positional); err != nil {
+			return nil, invalidRequest(err)
+		}
+		if len(positional) > len(ep.params) {
+			return nil, invalidRequest(fmt.Errorf("%s takes %d parameters, got %d",
+				ep.name(), len(ep.params), len(positional)))
+		}
+	default:
+		return nil, invalidRequest(errors.New("params must be an object or an array"))
+	}
+
+	if len(ep.params) == 1 && ep.params[0].argType.Kind() == reflect.Struct {
+		if positional != nil {
+			if len(positional) == 0 {
+				params = json.RawMessage("{}")
+			} else {
+				params = positional[0]
+			}
+		}
+		argValue := reflect.New(ep.params[0].argType)
+		if err := json.Unmarshal(params, argValue.Interface()); err != nil {
+			return nil, invalidRequest(err)
+		}
+		return []reflect.Value{argValue.Elem()}, nil
+	}
+
+	values := map[string]interface{}{}
+	if positional == nil {
+		if err := decodeJSON(bytes.NewReader(params), &values); err != nil {
+			return nil, invalidRequest(err)
+		}
+	}
+	for i, raw := range positional {
+		var value interface{}
+		if err := decodeJSON(bytes.NewReader(raw), &value); err != nil {
+			return nil, invalidRequest(err)
+		}
+		values[argKey(i)] = value
+	}
+	return bindParams(ep, values, nil, -1)
+}
+
+// readAllResult drains a stream result so it can be returned as JSON. Like
+// request bodies, results are capped at MaxBodyBytes.
+func (r *Router) readAllResult(stream io.Reader) ([]byte, error) {
+	if closer, ok := stream.(io.Closer); ok {
+		defer closer.Close()
+	}
+	limit := r.limits.MaxBodyBytes
+	if limit <= 0 {
+		return io.ReadAll(stream)
+	}
+	data, err := io.ReadAll(io.LimitReader(stream, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, sharedErrors.NewDomainError(ErrCodeRequestTooLarge,
+			fmt.Sprintf("result exceeds %d bytes", limit))
+	}
+	return data, nil
+}
+
+// validRPCID reports whether an id is absent, null, a string or a number
+func validRPCID(id json.RawMessage) bool {
+	if id == nil {
+		return true
+	}
+	var v interface{}
+	if err := json.Unmarshal(id, &v); err != nil {
+		return false
+	}
+	switch v.(type) {
+	case nil, string, float64:
+		return true
+	}
+	return false
+}
+
+// rpcData describes err the way the REST envelope does
+func rpcData(err error, result interface{}) *rpcErrorData {
+	body := newErrorBody(err)
+	return &rpcErrorData{Code: body.Code, Details: body.Details, Result: result}
+}
+
+// errorCode returns the router error code of a failed response, or ""
+func (resp *rpcResponse) errorCode() string {
+	switch {
+	case resp.Error == nil:
+		return ""
+	case resp.Error.Data != nil:
+		return resp.Error.Data.Code
+	}
+	return ErrCodeInvalidRequest
+}
+
+// rpcFailure builds an error response
+func rpcFailure(id json.RawMessage, code int, message string, data *rpcErrorData) *rpcResponse {
+	return &rpcResponse{
+		JSONRPC: rpcVersion,
+		Error:   &rpcError{Code: code, Message: message, Data: data},
+		ID:      id,
+	}
+}
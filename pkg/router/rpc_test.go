@@ -0,0 +1,265 @@
+package router
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	sharedErrors "devtoolbox/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type notifyService struct {
+	calls chan string
+}
+
+func (s *notifyService) Ping(value string) {
+	s.calls <- value
+}
+
+// streamService returns stream results of any size
+type streamService struct{}
+
+func (s *streamService) Repeat(n int) io.Reader {
+	return strings.NewReader(strings.Repeat("x", n))
+}
+
+type rpcTestResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result"`
+	Error   *rpcError       `json:"error"`
+	ID      json.RawMessage `json:"id"`
+}
+
+func newRPCServer(t *testing.T, services ...interface{}) *Server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	server := NewServer()
+	server.SetParamNames(map[string][]string{
+		"MultiParamService.Combine": {"a", "b", "c"},
+		"uploadService.Upper":       {"input"},
+	})
+	for _, svc := range services {
+		require.NoError(t, server.Register(svc))
+	}
+	return server
+}
+
+func postRPC(t *testing.T, server *Server, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/rpc", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	server.Engine().ServeHTTP(w, req)
+	return w
+}
+
+func decodeRPC(t *testing.T, w *httptest.ResponseRecorder) rpcTestResponse {
+	t.Helper()
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp rpcTestResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "2.0", resp.JSONRPC)
+	return resp
+}
+
+func TestRPC_Call(t *testing.T) {
+	server := newRPCServer(t, &TestService{}, &MultiParamService{}, &PrimitiveService{}, &uploadService{})
+
+	tests := []struct {
+		name   string
+		body   string
+		result string
+	}{
+		{"struct param", `{"jsonrpc":"2.0","method":"TestService.Echo","params":{"message":"hi"},"id":1}`, `{"message":"hi"}`},
+		{"struct param by position", `{"jsonrpc":"2.0","method":"TestService.Echo","params":[{"message":"hi"}],"id":1}`, `{"message":"hi"}`},
+		{"named params", `{"jsonrpc":"2.0","method":"MultiParamService.Combine","params":{"a":"x","b":"y","c":"z"},"id":1}`, `"x-y-z"`},
+		{"positional params", `{"jsonrpc":"2.0","method":"MultiParamService.Combine","params":["x","y","z"],"id":1}`, `"x-y-z"`},
+		{"value convention", `{"jsonrpc":"2.0","method":"PrimitiveService.Process","params":{"value":"v"},"id":1}`, `"processed: v"`},
+		{"stream result", `{"jsonrpc":"2.0","method":"uploadService.Upper","params":{"input":"abc"},"id":1}`,
+			`"` + base64.StdEncoding.EncodeToString([]byte("ABC")) + `"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := decodeRPC(t, postRPC(t, server, tt.body))
+			assert.Nil(t, resp.Error)
+			assert.JSONEq(t, tt.result, string(resp.Result))
+			assert.Equal(t, "1", string(resp.ID))
+		})
+	}
+}
+
+func TestRPC_Errors(t *testing.T) {
+	server := newRPCServer(t, &MultiParamService{}, &erroringService{})
+
+	tests := []struct {
+		name     string
+		body     string
+		code     int
+		dataCode string
+	}{
+		{"parse error", `{"jsonrpc":`, rpcParseError, ""},
+		{"empty batch", `[]`, rpcInvalidRequest, ""},
+		{"wrong version", `{"jsonrpc":"1.0","method":"MultiParamService.Combine","id":1}`, rpcInvalidRequest, ""},
+		{"bad id", `{"jsonrpc":"2.0","method":"MultiParamService.Combine","id":{}}`, rpcInvalidRequest, ""},
+		{"unknown method", `{"jsonrpc":"2.0","method":"Nope.Nothing","id":1}`, rpcMethodNotFound, ""},
+		{"missing param", `{"jsonrpc":"2.0","method":"MultiParamService.Combine","params":{"a":"x"},"id":1}`,
+			rpcInvalidParams, ErrCodeMissingParameter},
+		{"too many params", `{"jsonrpc":"2.0","method":"MultiParamService.Combine","params":[1,2,3,4],"id":1}`,
+			rpcInvalidParams, ErrCodeInvalidRequest},
+		{"scalar params", `{"jsonrpc":"2.0","method":"MultiParamService.Combine","params":"x","id":1}`,
+			rpcInvalidParams, ErrCodeInvalidRequest},
+		{"domain error", `{"jsonrpc":"2.0","method":"erroringService.Domain","id":1}`,
+			rpcToolError, sharedErrors.ErrCodeInvalidInput},
+		{"in-band error", `{"jsonrpc":"2.0","method":"erroringService.InBand","id":1}`, rpcToolError, "INVALID_TEMPLATE"},
+		{"panic", `{"jsonrpc":"2.0","method":"erroringService.Panics","id":1}`, rpcInternalError, sharedErrors.ErrCodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := decodeRPC(t, postRPC(t, server, tt.body))
+			assert.Nil(t, resp.Result)
+			require.NotNil(t, resp.Error)
+			assert.Equal(t, tt.code, resp.Error.Code)
+			assert.NotEmpty(t, resp.Error.Message)
+			if tt.dataCode != "" && assert.NotNil(t, resp.Error.Data) {
+				assert.Equal(t, tt.dataCode, resp.Error.Data.Code)
+			}
+		})
+	}
+
+	t.Run("in-band error keeps the result", func(t *testing.T) {
+		resp := decodeRPC(t, postRPC(t, server, `{"jsonrpc":"2.0","method":"erroringService.InBand","id":"a"}`))
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, `"a"`, string(resp.ID))
+		assert.NotNil(t, resp.Error.Data.Result)
+	})
+}
+
+func TestRPC_ResultLimit(t *testing.T) {
+	server := newRPCServer(t, &streamService{})
+	limits := DefaultLimits()
+	limits.MaxBodyBytes = 80
+	server.router.SetLimits(limits)
+
+	resp := decodeRPC(t, postRPC(t, server, `{"jsonrpc":"2.0","method":"streamService.Repeat","params":[80],"id":1}`))
+	assert.Nil(t, resp.Error)
+
+	resp = decodeRPC(t, postRPC(t, server, `{"jsonrpc":"2.0","method":"streamService.Repeat","params":[81],"id":1}`))
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, rpcToolError, resp.Error.Code)
+	assert.Equal(t, ErrCodeRequestTooLarge, resp.Error.Data.Code)
+}
+
+func TestRPC_Batch(t *testing.T) {
+	calls := make(chan string, 1)
+	server := newRPCServer(t, &MultiParamService{}, &notifyService{calls: calls})
+
+	w := postRPC(t, server, `[
+		{"jsonrpc":"2.0","method":"MultiParamService.Combine","params":["a","b","c"],"id":1},
+		{"jsonrpc":"2.0","method":"notifyService.Ping","params":["note"]},
+		{"jsonrpc":"2.0","method":"Nope.Nothing","id":2},
+		42
+	]`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var responses []rpcTestResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &responses))
+	require.Len(t, responses, 3)
+
+	assert.Equal(t, "1", string(responses[0].ID))
+	assert.JSONEq(t, `"a-b-c"`, string(responses[0].Result))
+	assert.Equal(t, "2", string(responses[1].ID))
+	assert.Equal(t, rpcMethodNotFound, responses[1].Error.Code)
+	assert.Equal(t, "null", string(responses[2].ID))
+	assert.Equal(t, rpcInvalidRequest, responses[2].Error.Code)
+
+	select {
+	case value := <-calls:
+		assert.Equal(t, "note", value)
+	case <-time.After(time.Second):
+		t.Fatal("notification was not delivered")
+	}
+}
+
+func TestRPC_Notification(t *testing.T) {
+	calls := make(chan string, 2)
+	server := newRPCServer(t, &notifyService{calls: calls})
+
+	w := postRPC(t, server, `{"jsonrpc":"2.0","method":"notifyService.Ping","params":{"arg0":"one"}}`)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	// A batch of notifications has nothing to answer either
+	w = postRPC(t, server, `[{"jsonrpc":"2.0","method":"notifyService.Ping","params":["two"]},
+		{"jsonrpc":"2.0","method":"Nope.Nothing"}]`)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	received := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		select {
+		case value := <-calls:
+			received[value] = true
+		case <-time.After(time.Second):
+			t.Fatal("notification was not delivered")
+		}
+	}
+	assert.Equal(t, map[string]bool{"one": true, "two": true}, received)
+}
+
+func TestRPC_NotificationLimit(t *testing.T) {
+	calls := make(chan string)
+	server := newRPCServer(t, &notifyService{calls: calls})
+	limits := DefaultLimits()
+	limits.MaxNotifications = 1
+	server.router.SetLimits(limits)
+
+	// The first notification holds the only slot until its call is read
+	postRPC(t, server, `{"jsonrpc":"2.0","method":"notifyService.Ping","params":["one"]}`)
+	posted := make(chan int, 1)
+	go func() {
+		posted <- postRPC(t, server, `{"jsonrpc":"2.0","method":"notifyService.Ping","params":["two"]}`).Code
+	}()
+	select {
+	case <-posted:
+		t.Fatal("second notification did not wait for a slot")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	assert.Equal(t, "one", <-calls)
+	assert.Equal(t, http.StatusNoContent, <-posted)
+	assert.Equal(t, "two", <-calls)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, server.router.wait(ctx), "running notifications finish")
+}
+
+func TestRPC_RequiresToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := DefaultConfig()
+	cfg.Token = "secret-token"
+	server := NewServerWithConfig(cfg)
+	require.NoError(t, server.Register(&TestService{}))
+
+	body := `{"jsonrpc":"2.0","method":"TestService.Echo","params":{"message":"hi"},"id":1}`
+	w := postRPC(t, server, body)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/rpc", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer secret-token")
+	server.Engine().ServeHTTP(w, req)
+	resp := decodeRPC(t, w)
+	assert.JSONEq(t, `{"message":"hi"}`, string(resp.Result))
+}
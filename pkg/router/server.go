@@ -1,49 +1,161 @@
 package router
 
 import (
+	"context"
+	_ "embed"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"net"
 	"net/http"
+	"slices"
+	"strconv"
+	"sync"
 	"time"
 
+	"devtoolbox/pkg/tools"
+
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
+//go:embed assets/explorer.html
+var explorerPage []byte
+
+// DefaultAllowOrigins are the origins of the desktop webview (wails://wails
+// on macOS and Linux, http://wails.localhost on Windows) and of the Vite dev
+// server. Requests from the server's own origin are always allowed.
+var DefaultAllowOrigins = []string{
+	"wails://wails",
+	"http://wails.localhost",
+	"http://localhost:9245",
+}
+
+// Config controls who can reach the server
+type Config struct {
+	// Host is the address to bind; empty means every interface
+	Host string
+	// AllowOrigins is the CORS origin allowlist. "*" allows any origin.
+	AllowOrigins []string
+	// Token, when set, must be presented as a bearer token on /api/*, /rpc and /metrics
+	Token string
+	// Limits bounds request bodies and call durations
+	Limits
+	// AccessLog, when set, receives one record per request
+	AccessLog *slog.Logger
+	// TLSCertFile and TLSKeyFile, when both set, serve HTTPS
+	TLSCertFile string
+	TLSKeyFile  string
+	// SocketPath, when set, also serves plain HTTP on a Unix socket only the
+	// current user can connect to. Requests over it need no token.
+	SocketPath string
+	// ShutdownTimeout bounds how long Run waits for in-flight requests and
+	// notifications to finish once its context is done; zero waits
+	// indefinitely
+	ShutdownTimeout time.Duration
+	// Version is reported by /health
+	Version string
+}
+
+// DefaultConfig binds to loopback, allows the desktop origins, requires no
+// token, applies DefaultLimits and drains requests for 30 seconds on shutdown
+func DefaultConfig() Config {
+	return Config{
+		Host:            "127.0.0.1",
+		AllowOrigins:    DefaultAllowOrigins,
+		Limits:          DefaultLimits(),
+		ShutdownTimeout: 30 * time.Second,
+	}
+}
+
 // Server represents the HTTP server with auto-discovery router
 type Server struct {
 	router *Router
 	engine *gin.Engine
+	config Config
+	// closing is closed when the current Run starts shutting down, ending
+	// event streams. Each Run makes a new one, so a server can run again.
+	mu      sync.Mutex
+	closing chan struct{}
 }
 
-// NewServer creates a new HTTP server
+// NewServer creates a new HTTP server with DefaultConfig
 func NewServer() *Server {
+	return NewServerWithConfig(DefaultConfig())
+}
+
+// NewServerWithConfig creates a new HTTP server
+func NewServerWithConfig(cfg Config) *Server {
 	gin.SetMode(gin.ReleaseMode)
 	engine := gin.New()
-	engine.Use(gin.Recovery())
+	if cfg.AccessLog != nil {
+		// Outermost, so rejected and panicking requests are logged too
+		engine.Use(accessLog(cfg.AccessLog))
+	}
+	engine.Use(gin.CustomRecovery(recoverPanic))
 
 	// CORS configuration
-	config := cors.Config{
-		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
-		MaxAge:           12 * time.Hour,
+	corsConfig := cors.Config{
+		AllowMethods:  []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:  []string{"Origin", "Content-Type", "Accept", "Authorization"},
+		ExposeHeaders: []string{"Content-Length"},
+		CustomSchemas: []string{"wails://"},
+		MaxAge:        12 * time.Hour,
+	}
+	if slices.Contains(cfg.AllowOrigins, "*") {
+		corsConfig.AllowAllOrigins = true
+	} else {
+		corsConfig.AllowOrigins = cfg.AllowOrigins
 	}
-	engine.Use(cors.New(config))
+	engine.Use(cors.New(corsConfig))
+
+	// Bearer token on /api/*, /rpc and /metrics, checked after CORS so preflights still succeed
+	if cfg.Token != "" {
+		engine.Use(requireToken(cfg.Token))
+	}
+
+	s := &Server{
+		router:  New(engine),
+		engine:  engine,
+		config:  cfg,
+		closing: make(chan struct{}),
+	}
+	s.router.SetLimits(cfg.Limits)
 
 	// Health check
 	engine.GET("/health", func(c *gin.Context) {
+		tools := []string{}
+		if s.router.tools != nil {
+			tools = s.router.tools.IDs()
+		}
 		c.JSON(http.StatusOK, gin.H{
-			"status": "ok",
-			"mode":   "web",
-			"time":   time.Now().Format(time.RFC3339),
+			"status":  "ok",
+			"mode":    "web",
+			"version": cfg.Version,
+			"tools":   tools,
+			"time":    time.Now().Format(time.RFC3339),
 		})
 	})
 
-	return &Server{
-		router: New(engine),
-		engine: engine,
-	}
+	// API description and explorer
+	engine.GET("/api/openapi.json", func(c *gin.Context) {
+		c.JSON(http.StatusOK, s.router.OpenAPI())
+	})
+	engine.GET("/api/docs", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", explorerPage)
+	})
+
+	// Tool catalog for the sidebar, spotlight and other clients
+	engine.GET("/api/tools", s.router.serveCatalog)
+
+	// JSON-RPC 2.0 access to the same methods
+	engine.POST("/rpc", s.router.handleRPC)
+
+	// Per-tool call metrics for Prometheus
+	engine.GET("/metrics", serveMetrics(s.router.Metrics()))
+
+	return s
 }
 
 // Register adds a service to the router
@@ -51,10 +163,119 @@ func (s *Server) Register(service interface{}) error {
 	return s.router.Register(service)
 }
 
+// RegisterTools registers the services of every tool in the registry
+func (s *Server) RegisterTools(registry *tools.Registry) error {
+	return s.router.RegisterTools(registry)
+}
+
+// RegisterManifest serves a pinned API version under /api/<version>/; call
+// it after registering the services
+func (s *Server) RegisterManifest(m Manifest) error {
+	return s.router.RegisterManifest(m)
+}
+
+// SetParamNames sets the parameter-name registry used to bind named request fields
+func (s *Server) SetParamNames(names map[string][]string) {
+	s.router.SetParamNames(names)
+}
+
+// SetMethodDocs sets the method doc summaries used to describe MCP tools and
+// OpenAPI operations
+func (s *Server) SetMethodDocs(docs map[string]string) {
+	s.router.SetMethodDocs(docs)
+}
+
+// ServeMCP serves the registered services as MCP tools over in and out
+func (s *Server) ServeMCP(ctx context.Context, in io.Reader, out io.Writer) error {
+	return s.router.ServeMCP(ctx, in, out)
+}
+
+// Addr returns the listen address for port on the configured host
+func (s *Server) Addr(port int) string {
+	return net.JoinHostPort(s.config.Host, strconv.Itoa(port))
+}
+
 // Start starts the HTTP server on the specified port
 func (s *Server) Start(port int) error {
-	addr := fmt.Sprintf(":%d", port)
-	return s.engine.Run(addr)
+	return s.Run(context.Background(), port)
+}
+
+// Run serves on port, over HTTPS when a certificate is configured, and on
+// SocketPath when set, until ctx is done. It then stops accepting
+// connections, ends event streams and waits up to ShutdownTimeout for
+// in-flight requests and JSON-RPC notifications.
+func (s *Server) Run(ctx context.Context, port int) error {
+	ln, err := net.Listen("tcp", s.Addr(port))
+	if err != nil {
+		return err
+	}
+	var socket net.Listener
+	if s.config.SocketPath != "" {
+		if socket, err = listenSocket(s.config.SocketPath); err != nil {
+			ln.Close()
+			return err
+		}
+	}
+	return s.serve(ctx, ln, socket)
+}
+
+// serve is Run on open listeners; socket may be nil
+func (s *Server) serve(ctx context.Context, ln, socket net.Listener) error {
+	closing := make(chan struct{})
+	s.mu.Lock()
+	s.closing = closing
+	s.mu.Unlock()
+
+	srv := &http.Server{Handler: s.engine, ConnContext: socketConnContext}
+	errc := make(chan error, 2)
+	go func() {
+		if s.config.TLSCertFile != "" && s.config.TLSKeyFile != "" {
+			errc <- srv.ServeTLS(ln, s.config.TLSCertFile, s.config.TLSKeyFile)
+		} else {
+			errc <- srv.Serve(ln)
+		}
+	}()
+	listeners := 1
+	if socket != nil {
+		listeners++
+		go func() { errc <- srv.Serve(socket) }()
+	}
+
+	select {
+	case err := <-errc:
+		// One listener failing stops the other
+		srv.Close()
+		return err
+	case <-ctx.Done():
+	}
+
+	close(closing)
+	shutdownCtx := context.Background()
+	if s.config.ShutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		shutdownCtx, cancel = context.WithTimeout(shutdownCtx, s.config.ShutdownTimeout)
+		defer cancel()
+	}
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutdown: %w", err)
+	}
+	// JSON-RPC notifications outlive the requests Shutdown waited for
+	if err := s.router.wait(shutdownCtx); err != nil {
+		return fmt.Errorf("shutdown: %w", err)
+	}
+	for range listeners {
+		if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+	}
+	return nil
+}
+
+// closingChan returns the channel closed when the current Run shuts down
+func (s *Server) closingChan() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closing
 }
 
 // Engine returns the Gin engine for testing
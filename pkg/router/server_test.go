@@ -1,13 +1,22 @@
 package router
 
 import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
+
+	"devtoolbox/pkg/events"
+	"devtoolbox/pkg/tools"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestServer_Start(t *testing.T) {
@@ -29,6 +38,114 @@ func TestServer_Start(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "web")
 }
 
+func TestServer_HealthReportsVersionAndTools(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := DefaultConfig()
+	cfg.Version = "1.2.3"
+	server := NewServerWithConfig(cfg)
+	require.NoError(t, server.RegisterTools(tools.NewRegistry(
+		tools.Tool{ID: "echo", Name: "Echo", Service: &TestService{}},
+		tools.Tool{ID: "diff", Name: "Text Diff"},
+	)))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/health", nil)
+	server.Engine().ServeHTTP(w, req)
+
+	var health struct {
+		Status  string   `json:"status"`
+		Version string   `json:"version"`
+		Tools   []string `json:"tools"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &health))
+	assert.Equal(t, "ok", health.Status)
+	assert.Equal(t, "1.2.3", health.Version)
+	assert.Equal(t, []string{"echo", "diff"}, health.Tools)
+}
+
+func TestServer_GracefulShutdown(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	bus := events.NewBus()
+	server := NewServer()
+	server.SetEventBus(bus)
+	require.NoError(t, server.Register(&slowService{}))
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	url := "http://" + ln.Addr().String()
+
+	ctx, stop := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.serve(ctx, ln, nil) }()
+
+	// An open event stream must not hold up shutdown
+	stream, err := http.Get(url + "/api/events")
+	require.NoError(t, err)
+	defer stream.Body.Close()
+
+	// A call in flight when shutdown starts still gets its response
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+	inflight := make(chan result, 1)
+	go func() {
+		resp, err := http.Post(url+"/api/slow-service/wait", "application/json", strings.NewReader(`{"value":300}`))
+		if err != nil {
+			inflight <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		inflight <- result{status: resp.StatusCode, body: string(body)}
+	}()
+	time.Sleep(100 * time.Millisecond)
+	stop()
+
+	r := <-inflight
+	require.NoError(t, r.err)
+	assert.Equal(t, http.StatusOK, r.status)
+	assert.Contains(t, r.body, "done")
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not shut down")
+	}
+
+	_, err = http.Get(url + "/health")
+	assert.Error(t, err, "no new connections after shutdown")
+}
+
+func TestServer_RunTwice(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	server := NewServer()
+	server.SetEventBus(events.NewBus())
+
+	for i := 0; i < 2; i++ {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		ctx, stop := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() { done <- server.serve(ctx, ln, nil) }()
+
+		// Each run's event streams end with that run
+		stream, err := http.Get("http://" + ln.Addr().String() + "/api/events")
+		require.NoError(t, err)
+		stop()
+
+		select {
+		case err := <-done:
+			assert.NoError(t, err, "run %d", i+1)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("run %d did not shut down", i+1)
+		}
+		stream.Body.Close()
+	}
+}
+
 type testServiceForServer struct{}
 
 type testRequest struct {
@@ -0,0 +1,103 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// socketConnKey marks the context of connections accepted on the Unix socket
+type socketConnKey struct{}
+
+// listenSocket listens on a Unix socket at path that only the current user
+// can connect to. A socket left behind by a server that is no longer running
+// is replaced; one that still accepts connections is an error.
+//
+// The socket is created and restricted inside a fresh directory only the
+// current user can enter, then linked into place, so it is never reachable
+// with the default permissions net.Listen gives it.
+func listenSocket(path string) (net.Listener, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("socket %s is in use by another server", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("remove stale socket: %w", err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	private, err := os.MkdirTemp(dir, ".sock")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(private)
+
+	tmp := filepath.Join(private, "s")
+	ln, err := net.ListenUnix("unix", &net.UnixAddr{Name: tmp, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	// The socket outlives tmp, so Close removes path instead
+	ln.SetUnlinkOnClose(false)
+	if err := os.Chmod(tmp, 0600); err != nil {
+		ln.Close()
+		return nil, err
+	}
+	// Unlike a rename, a link fails rather than replacing a socket another
+	// server created since the check above
+	if err := os.Link(tmp, path); err != nil {
+		ln.Close()
+		if errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("socket %s is in use by another server", path)
+		}
+		return nil, err
+	}
+	return &socketListener{UnixListener: ln, path: path}, nil
+}
+
+// socketListener is a Unix socket listener served at path
+type socketListener struct {
+	*net.UnixListener
+	path string
+}
+
+// Addr returns the path the socket is served at
+func (l *socketListener) Addr() net.Addr {
+	return &net.UnixAddr{Name: l.path, Net: "unix"}
+}
+
+// Close stops listening and removes the socket
+func (l *socketListener) Close() error {
+	err := l.UnixListener.Close()
+	if rmErr := os.Remove(l.path); err == nil && !errors.Is(rmErr, os.ErrNotExist) {
+		err = rmErr
+	}
+	return err
+}
+
+// socketConnContext tags connections accepted on a Unix socket so handlers
+// can tell them apart from TCP ones
+func socketConnContext(ctx context.Context, c net.Conn) context.Context {
+	if c.LocalAddr().Network() == "unix" {
+		return context.WithValue(ctx, socketConnKey{}, true)
+	}
+	return ctx
+}
+
+// fromSocket reports whether r arrived over the Unix socket
+func fromSocket(r *http.Request) bool {
+	viaSocket, _ := r.Context().Value(socketConnKey{}).(bool)
+	return viaSocket
+}
@@ -0,0 +1,77 @@
+package router
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_Socket(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := DefaultConfig()
+	cfg.Token = "secret-token"
+	server := NewServerWithConfig(cfg)
+	require.NoError(t, server.Register(&TestService{}))
+
+	path := filepath.Join(t.TempDir(), "devtoolbox.sock")
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	socket, err := listenSocket(path)
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	assert.Equal(t, path, socket.Addr().String())
+	// The private directory the socket was created in is gone
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "devtoolbox.sock", entries[0].Name())
+
+	ctx, stop := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.serve(ctx, ln, socket) }()
+
+	socketClient := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", path)
+		},
+	}}
+	call := func(client *http.Client, base string) int {
+		resp, err := client.Post(base+"/api/test-service/echo", "application/json", strings.NewReader(`{"message":"hi"}`))
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	// Both listeners serve at once; only TCP needs the token
+	assert.Equal(t, http.StatusOK, call(socketClient, "http://devtoolbox"))
+	assert.Equal(t, http.StatusUnauthorized, call(http.DefaultClient, "http://"+ln.Addr().String()))
+
+	_, err = listenSocket(path)
+	assert.ErrorContains(t, err, "in use", "a live socket is not replaced")
+
+	stop()
+	require.NoError(t, <-done)
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "the socket is removed on shutdown")
+}
+
+func TestListenSocket_ReplacesStaleSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "devtoolbox.sock")
+	require.NoError(t, os.WriteFile(path, nil, 0600))
+
+	ln, err := listenSocket(path)
+	require.NoError(t, err)
+	defer ln.Close()
+	assert.Equal(t, "unix", ln.Addr().Network())
+}
@@ -0,0 +1,184 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	readerType = reflect.TypeOf((*io.Reader)(nil)).Elem()
+	bytesType  = reflect.TypeOf([]byte(nil))
+)
+
+// maxFieldSize bounds multipart form fields other than the uploaded file
+const maxFieldSize = 1 << 20
+
+// Upload content types
+const (
+	mimeOctetStream = "application/octet-stream"
+	mimeMultipart   = "multipart/form-data"
+)
+
+// Binary is implemented by results that also have a raw form, such as a
+// generated image. Clients that list its content type (or
+// application/octet-stream) in Accept receive the raw bytes instead of the
+// JSON envelope. ContentType must not depend on the receiver's value.
+type Binary interface {
+	ContentType() string
+	Bytes() []byte
+}
+
+// isUploadType reports whether a parameter can receive a raw request body
+func isUploadType(t reflect.Type) bool {
+	return t == readerType || t == bytesType
+}
+
+// uploadParam returns the index of the first parameter that can receive a raw
+// request body, or -1
+func uploadParam(params []param) int {
+	for i, p := range params {
+		if isUploadType(p.argType) {
+			return i
+		}
+	}
+	return -1
+}
+
+// isUploadRequest reports whether the request carries a raw or multipart body
+func isUploadRequest(c *gin.Context) bool {
+	switch c.ContentType() {
+	case mimeOctetStream, mimeMultipart:
+		return true
+	}
+	return false
+}
+
+// readUpload reads a multipart/form-data or application/octet-stream request.
+// It returns the body, or the file part, for the upload parameter p and the
+// other parameters' values from the query string and, for multipart requests,
+// from the form fields sent before the file. The body is not buffered.
+func readUpload(c *gin.Context, p param) (map[string]interface{}, io.Reader, error) {
+	values := queryValues(c)
+	if c.ContentType() == mimeOctetStream {
+		return values, c.Request.Body, nil
+	}
+
+	mr, err := c.Request.MultipartReader()
+	if err != nil {
+		return nil, nil, err
+	}
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, nil, fmt.Errorf("missing file part %q", p.label())
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		name := part.FormName()
+		if part.FileName() != "" || name == p.name || name == p.key {
+			return values, part, nil
+		}
+
+		data, err := io.ReadAll(io.LimitReader(part, maxFieldSize+1))
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(data) > maxFieldSize {
+			return nil, nil, fmt.Errorf("form field %q is too large", name)
+		}
+		values[name] = string(data)
+	}
+}
+
+// queryValues returns the first value of each query parameter
+func queryValues(c *gin.Context) map[string]interface{} {
+	values := map[string]interface{}{}
+	for key, vs := range c.Request.URL.Query() {
+		values[key] = vs[0]
+	}
+	return values
+}
+
+// uploadArg adapts an upload body to an io.Reader or []byte parameter
+func uploadArg(body io.Reader, t reflect.Type) (reflect.Value, error) {
+	if t == bytesType {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(data), nil
+	}
+	return reflect.ValueOf(body), nil
+}
+
+// decodeFormValue interprets a query or form value for a non-string parameter,
+// e.g. "42", "true" or a JSON object. Values that are not JSON stay strings.
+func decodeFormValue(value interface{}, t reflect.Type) interface{} {
+	s, ok := value.(string)
+	if !ok || t.Kind() == reflect.String {
+		return value
+	}
+	var decoded interface{}
+	if err := decodeJSON(strings.NewReader(s), &decoded); err != nil {
+		return value
+	}
+	return decoded
+}
+
+// respondStream writes a reader result as a raw response. Errors surfacing
+// before the first byte still produce an error envelope; later ones can only
+// cut the response short.
+func respondStream(c *gin.Context, r io.Reader) {
+	if closer, ok := r.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	// The result may still be reading the request body
+	http.NewResponseController(c.Writer).EnableFullDuplex()
+
+	first := make([]byte, 32*1024)
+	n, err := io.ReadFull(r, first)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		respondError(c, err, nil)
+		return
+	}
+
+	contentType := mimeOctetStream
+	if b, ok := r.(interface{ ContentType() string }); ok {
+		contentType = b.ContentType()
+	}
+	c.Header("Content-Type", contentType)
+	c.Status(http.StatusOK)
+	c.Writer.Write(first[:n])
+	if err == nil {
+		io.Copy(c.Writer, r)
+	}
+}
+
+// respondBinary writes a Binary result's raw bytes
+func respondBinary(c *gin.Context, b Binary) {
+	c.Data(http.StatusOK, b.ContentType(), b.Bytes())
+}
+
+// acceptsBinary reports whether the Accept header explicitly asks for
+// contentType or raw bytes. Wildcards do not count, so browsers and fetch
+// keep getting JSON.
+func acceptsBinary(c *gin.Context, contentType string) bool {
+	major, _, _ := strings.Cut(contentType, "/")
+	for _, accepted := range strings.Split(c.GetHeader("Accept"), ",") {
+		accepted, _, _ = strings.Cut(accepted, ";")
+		switch strings.TrimSpace(accepted) {
+		case contentType, mimeOctetStream, major + "/*":
+			return true
+		}
+	}
+	return false
+}
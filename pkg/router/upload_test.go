@@ -0,0 +1,272 @@
+package router
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type uploadService struct{}
+
+func (s *uploadService) Digest(input io.Reader, method string, config map[string]interface{}) (string, error) {
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return "", err
+	}
+	return method + ":" + string(data) + ":" + toString(config["key"]), nil
+}
+
+func (s *uploadService) Size(data []byte) int {
+	return len(data)
+}
+
+func (s *uploadService) Upper(input io.Reader) (io.Reader, error) {
+	pr, pw := io.Pipe()
+	go func() {
+		data, err := io.ReadAll(input)
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		if string(data) == "fail" {
+			pw.CloseWithError(errors.New("cannot process input"))
+			return
+		}
+		pw.Write(bytes.ToUpper(data))
+		pw.Close()
+	}()
+	return pr, nil
+}
+
+func (s *uploadService) Image() imageResult {
+	return imageResult{Data: []byte("\x89PNG")}
+}
+
+type imageResult struct {
+	Data []byte `json:"data"`
+}
+
+func (r imageResult) ContentType() string { return "image/png" }
+func (r imageResult) Bytes() []byte       { return r.Data }
+
+func toString(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
+
+func newUploadEngine(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	router := New(engine)
+	router.SetParamNames(map[string][]string{
+		"uploadService.Digest": {"input", "method", "config"},
+		"uploadService.Size":   {"data"},
+		"uploadService.Upper":  {"input"},
+	})
+	require.NoError(t, router.Register(&uploadService{}))
+	return engine
+}
+
+// multipartBody builds a form with the given fields followed by a file part
+func multipartBody(t *testing.T, fields [][2]string, fileField, content string) (*bytes.Buffer, string) {
+	t.Helper()
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for _, f := range fields {
+		require.NoError(t, mw.WriteField(f[0], f[1]))
+	}
+	if fileField != "" {
+		fw, err := mw.CreateFormFile(fileField, "artifact.bin")
+		require.NoError(t, err)
+		fw.Write([]byte(content))
+	}
+	require.NoError(t, mw.Close())
+	return &buf, mw.FormDataContentType()
+}
+
+func TestRouter_OctetStreamUpload(t *testing.T) {
+	engine := newUploadEngine(t)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", `/api/upload-service/digest?method=SHA-256&config={"key":"k"}`,
+		strings.NewReader("raw bytes"))
+	req.Header.Set("Content-Type", "application/octet-stream")
+	engine.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var result string
+	assert.Nil(t, decodeEnvelope(t, w.Body.Bytes(), &result))
+	assert.Equal(t, "SHA-256:raw bytes:k", result)
+}
+
+func TestRouter_MultipartUpload(t *testing.T) {
+	engine := newUploadEngine(t)
+
+	t.Run("fields before file", func(t *testing.T) {
+		body, contentType := multipartBody(t, [][2]string{{"method", "MD5"}}, "input", "file content")
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/upload-service/digest", body)
+		req.Header.Set("Content-Type", contentType)
+		engine.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var result string
+		assert.Nil(t, decodeEnvelope(t, w.Body.Bytes(), &result))
+		assert.Equal(t, "MD5:file content:", result)
+	})
+
+	t.Run("bytes parameter", func(t *testing.T) {
+		body, contentType := multipartBody(t, nil, "file", "12345")
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/upload-service/size", body)
+		req.Header.Set("Content-Type", contentType)
+		engine.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var size int
+		assert.Nil(t, decodeEnvelope(t, w.Body.Bytes(), &size))
+		assert.Equal(t, 5, size)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		body, contentType := multipartBody(t, [][2]string{{"method", "MD5"}}, "", "")
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/upload-service/digest", body)
+		req.Header.Set("Content-Type", contentType)
+		engine.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		errBody := decodeEnvelope(t, w.Body.Bytes(), nil)
+		if assert.NotNil(t, errBody) {
+			assert.Equal(t, ErrCodeInvalidRequest, errBody.Code)
+			assert.Contains(t, errBody.Message, `"input"`)
+		}
+	})
+}
+
+func TestRouter_UploadParamsAsJSON(t *testing.T) {
+	engine := newUploadEngine(t)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/upload-service/digest",
+		strings.NewReader(`{"input": "text", "method": "MD5"}`))
+	req.Header.Set("Content-Type", "application/json")
+	engine.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var result string
+	assert.Nil(t, decodeEnvelope(t, w.Body.Bytes(), &result))
+	assert.Equal(t, "MD5:text:", result)
+
+	w = httptest.NewRecorder()
+	encoded := base64.StdEncoding.EncodeToString([]byte("abc"))
+	req, _ = http.NewRequest("POST", "/api/upload-service/size",
+		strings.NewReader(`{"data": "`+encoded+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	engine.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var size int
+	assert.Nil(t, decodeEnvelope(t, w.Body.Bytes(), &size))
+	assert.Equal(t, 3, size)
+}
+
+func TestRouter_StreamResponse(t *testing.T) {
+	engine := newUploadEngine(t)
+
+	t.Run("streams raw output", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/upload-service/upper", strings.NewReader("hello"))
+		req.Header.Set("Content-Type", "application/octet-stream")
+		engine.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/octet-stream", w.Header().Get("Content-Type"))
+		assert.Equal(t, "HELLO", w.Body.String())
+	})
+
+	t.Run("early error returns envelope", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/upload-service/upper", strings.NewReader("fail"))
+		req.Header.Set("Content-Type", "application/octet-stream")
+		engine.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		errBody := decodeEnvelope(t, w.Body.Bytes(), nil)
+		if assert.NotNil(t, errBody) {
+			assert.Equal(t, "cannot process input", errBody.Message)
+		}
+	})
+}
+
+func TestRouter_BinaryResponse(t *testing.T) {
+	engine := newUploadEngine(t)
+
+	tests := []struct {
+		name       string
+		accept     string
+		expectType string
+	}{
+		{"explicit type", "image/png", "image/png"},
+		{"type wildcard", "image/*;q=0.9", "image/png"},
+		{"octet stream", "application/octet-stream", "image/png"},
+		{"any", "*/*", "application/json"},
+		{"none", "", "application/json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/upload-service/image", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			engine.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Contains(t, w.Header().Get("Content-Type"), tt.expectType)
+			if tt.expectType == "image/png" {
+				assert.Equal(t, "\x89PNG", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRouter_OpenAPIUpload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := New(gin.New())
+	router.SetParamNames(map[string][]string{"uploadService.Digest": {"input", "method", "config"}})
+	require.NoError(t, router.Register(&uploadService{}))
+	doc := router.OpenAPI()
+
+	digest := doc.Paths["/api/upload-service/digest"]["post"]
+	require.NotNil(t, digest.RequestBody)
+	assert.Contains(t, digest.RequestBody.Content, "application/json")
+	assert.Equal(t, "binary", digest.RequestBody.Content["application/octet-stream"].Schema.Format)
+	form := digest.RequestBody.Content["multipart/form-data"].Schema
+	assert.Equal(t, "binary", form.Properties["input"].Format)
+	assert.Equal(t, []string{"input", "method"}, form.Required)
+	if assert.Len(t, digest.Parameters, 2) {
+		assert.Equal(t, "method", digest.Parameters[0].Name)
+		assert.Equal(t, "query", digest.Parameters[0].In)
+	}
+
+	upper := doc.Paths["/api/upload-service/upper"]["post"]
+	assert.Contains(t, upper.Responses["200"].Content, "application/octet-stream")
+	assert.NotContains(t, upper.Responses["200"].Content, "application/json")
+
+	image := doc.Paths["/api/upload-service/image"]["post"]
+	assert.Contains(t, image.Responses["200"].Content, "application/json")
+	assert.Contains(t, image.Responses["200"].Content, "image/png")
+}
@@ -0,0 +1,189 @@
+package tools
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Tool describes one tool: how the app, spotlight and CLI present it, and
+// the service whose methods implement it
+type Tool struct {
+	// ID is the stable identifier, also the frontend route segment ("/tool/<id>")
+	ID string `json:"id"`
+	// Name is the display name
+	Name string `json:"name"`
+	// Keywords are extra search terms, such as algorithm or format names
+	Keywords []string `json:"keywords,omitempty"`
+	// Categories group the tool in the sidebar; the first is the primary one
+	Categories []string `json:"categories"`
+	// Service implements the tool's operations. It is nil for tools that run
+	// entirely in the frontend.
+	Service interface{} `json:"-"`
+	// Streams implements the operations on streams, such as file uploads,
+	// that only the HTTP server routes, as the service's own: the desktop
+	// bindings cannot carry an io.Reader. It is nil for most tools.
+	Streams interface{} `json:"-"`
+}
+
+// Path returns the tool's frontend route
+func (t Tool) Path() string {
+	return "/tool/" + t.ID
+}
+
+// ServiceName returns the name of the service type, e.g. "JWTService", or ""
+func (t Tool) ServiceName() string {
+	if t.Service == nil {
+		return ""
+	}
+	typ := reflect.TypeOf(t.Service)
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ.Name()
+}
+
+// Registry is the ordered list of tools. The Wails app, the HTTP router,
+// spotlight and the CLI are all built from it.
+type Registry struct {
+	tools []Tool
+	byID  map[string]int
+}
+
+// NewRegistry creates a registry of tools in display order. It panics on an
+// empty or duplicate ID, like registering a route twice.
+func NewRegistry(tools ...Tool) *Registry {
+	r := &Registry{byID: map[string]int{}}
+	for _, tool := range tools {
+		if tool.ID == "" {
+			panic(fmt.Sprintf("tools: %q has no ID", tool.Name))
+		}
+		if _, exists := r.byID[tool.ID]; exists {
+			panic(fmt.Sprintf("tools: duplicate ID %q", tool.ID))
+		}
+		r.byID[tool.ID] = len(r.tools)
+		r.tools = append(r.tools, tool)
+	}
+	return r
+}
+
+// All returns every tool in display order
+func (r *Registry) All() []Tool {
+	return append([]Tool(nil), r.tools...)
+}
+
+// Get returns the tool with the given ID
+func (r *Registry) Get(id string) (Tool, bool) {
+	i, ok := r.byID[id]
+	if !ok {
+		return Tool{}, false
+	}
+	return r.tools[i], true
+}
+
+// Services returns the services behind the tools, in display order
+func (r *Registry) Services() []interface{} {
+	var services []interface{}
+	for _, tool := range r.tools {
+		if tool.Service != nil {
+			services = append(services, tool.Service)
+		}
+	}
+	return services
+}
+
+// Filter returns a registry of the tools listed in enabled, or of every tool
+// when enabled is empty, minus those listed in disabled. Unknown IDs are an
+// error, so a typo in a config file does not silently expose a tool.
+func (r *Registry) Filter(enabled, disabled []string) (*Registry, error) {
+	for _, id := range append(append([]string(nil), enabled...), disabled...) {
+		if _, ok := r.byID[id]; !ok {
+			return nil, fmt.Errorf("tools: unknown tool %q", id)
+		}
+	}
+
+	keep := map[string]bool{}
+	for _, id := range enabled {
+		keep[id] = true
+	}
+	for _, id := range disabled {
+		keep[id] = false
+	}
+
+	var selected []Tool
+	for _, tool := range r.tools {
+		if on, listed := keep[tool.ID]; on || !listed && len(enabled) == 0 {
+			selected = append(selected, tool)
+		}
+	}
+	return NewRegistry(selected...), nil
+}
+
+// IDs returns the tool IDs in display order
+func (r *Registry) IDs() []string {
+	ids := make([]string, len(r.tools))
+	for i, tool := range r.tools {
+		ids[i] = tool.ID
+	}
+	return ids
+}
+
+// Search returns the tools matching every word of query in their ID, name,
+// keywords or categories, best matches first: a name starting with the
+// query, then a name word starting with it, then any other match.
+func (r *Registry) Search(query string) []Tool {
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return r.All()
+	}
+
+	type match struct {
+		tool  Tool
+		score int
+	}
+	var matches []match
+	for _, tool := range r.tools {
+		if !tool.matches(words) {
+			continue
+		}
+		matches = append(matches, match{tool, tool.rank(strings.ToLower(query))})
+	}
+
+	// Stable, so equal ranks keep display order
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score < matches[j].score })
+
+	result := make([]Tool, len(matches))
+	for i, m := range matches {
+		result[i] = m.tool
+	}
+	return result
+}
+
+// matches reports whether every word occurs in the tool's searchable text
+func (t Tool) matches(words []string) bool {
+	text := strings.ToLower(strings.Join(append([]string{t.ID, t.Name}, append(t.Keywords, t.Categories...)...), " "))
+	for _, word := range words {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// rank orders search results; lower is better
+func (t Tool) rank(query string) int {
+	name := strings.ToLower(t.Name)
+	switch {
+	case name == query || t.ID == query:
+		return 0
+	case strings.HasPrefix(name, query):
+		return 1
+	}
+	for _, word := range strings.Fields(name) {
+		if strings.HasPrefix(word, query) {
+			return 2
+		}
+	}
+	return 3
+}
@@ -0,0 +1,91 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type hashService struct{}
+
+func newTestRegistry() *Registry {
+	return NewRegistry(
+		Tool{ID: "hash-generator", Name: "Hash Generator", Keywords: []string{"md5", "sha256"}, Categories: []string{"Security"}, Service: &hashService{}},
+		Tool{ID: "jwt", Name: "JWT Debugger", Keywords: []string{"token"}, Categories: []string{"Security"}},
+		Tool{ID: "text-utilities", Name: "Text Utilities", Keywords: []string{"hash tags"}, Categories: []string{"Text"}},
+	)
+}
+
+func TestRegistry_Lookup(t *testing.T) {
+	r := newTestRegistry()
+
+	tool, ok := r.Get("hash-generator")
+	assert.True(t, ok)
+	assert.Equal(t, "Hash Generator", tool.Name)
+	assert.Equal(t, "/tool/hash-generator", tool.Path())
+	assert.Equal(t, "hashService", tool.ServiceName())
+
+	_, ok = r.Get("nope")
+	assert.False(t, ok)
+
+	assert.Len(t, r.All(), 3)
+	assert.Equal(t, []interface{}{tool.Service}, r.Services())
+
+	jwt, _ := r.Get("jwt")
+	assert.Empty(t, jwt.ServiceName())
+}
+
+func TestRegistry_Search(t *testing.T) {
+	r := newTestRegistry()
+
+	ids := func(tools []Tool) []string {
+		var result []string
+		for _, tool := range tools {
+			result = append(result, tool.ID)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"hash-generator", "jwt", "text-utilities"}, ids(r.Search("")))
+	assert.Equal(t, []string{"hash-generator"}, ids(r.Search("MD5")))
+	assert.Equal(t, []string{"jwt"}, ids(r.Search("token")))
+	assert.Equal(t, []string{"hash-generator", "jwt"}, ids(r.Search("security")))
+	// Name prefixes rank above keyword matches
+	assert.Equal(t, []string{"hash-generator", "text-utilities"}, ids(r.Search("hash")))
+	assert.Empty(t, r.Search("hash token"))
+}
+
+func TestNewRegistry_RejectsDuplicates(t *testing.T) {
+	assert.Panics(t, func() {
+		NewRegistry(Tool{ID: "jwt", Name: "A"}, Tool{ID: "jwt", Name: "B"})
+	})
+	assert.Panics(t, func() {
+		NewRegistry(Tool{Name: "No ID"})
+	})
+}
+
+func TestRegistry_Filter(t *testing.T) {
+	r := newTestRegistry()
+
+	all, err := r.Filter(nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"hash-generator", "jwt", "text-utilities"}, all.IDs())
+
+	enabled, err := r.Filter([]string{"text-utilities", "jwt"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"jwt", "text-utilities"}, enabled.IDs(), "display order is kept")
+
+	disabled, err := r.Filter(nil, []string{"jwt"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"hash-generator", "text-utilities"}, disabled.IDs())
+
+	// Disabled wins over enabled
+	both, err := r.Filter([]string{"jwt", "hash-generator"}, []string{"jwt"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"hash-generator"}, both.IDs())
+
+	_, err = r.Filter([]string{"nope"}, nil)
+	assert.ErrorContains(t, err, `"nope"`)
+	_, err = r.Filter(nil, []string{"nope"})
+	assert.Error(t, err)
+}
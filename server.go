@@ -1,54 +1,400 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"log/slog"
+	"net"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strconv"
+	"time"
 
+	"devtoolbox/internal/settings"
+	"devtoolbox/pkg/events"
 	"devtoolbox/pkg/router"
+	"devtoolbox/pkg/tools"
 	"devtoolbox/service"
+
+	"github.com/wailsapp/wails/v3/pkg/application"
 )
 
-func themesDir() string {
+func configDir() string {
 	if runtime.GOOS == "darwin" {
-		return filepath.Join(os.Getenv("HOME"), "Library", "Application Support", "DevToolbox", "themes")
+		return filepath.Join(os.Getenv("HOME"), "Library", "Application Support", "DevToolbox")
 	} else if runtime.GOOS == "windows" {
-		return filepath.Join(os.Getenv("APPDATA"), "DevToolbox", "themes")
-	}
-	return filepath.Join(os.Getenv("HOME"), ".config", "devtoolbox", "themes")
-}
-
-// StartHTTPServer starts the HTTP server with all services registered
-func StartHTTPServer(port int) {
-	// Create services
-	jwtSvc := service.NewJWTService(nil)
-	encrypterSvc := service.NewEncrypterService(nil)
-	encoderSvc := service.NewEncoderService(nil)
-	hashGenSvc := service.NewHashGeneratorService(nil)
-	codeConvSvc := service.NewCodeConverterService(nil)
-	textUtilsSvc := service.NewTextUtilitiesService(nil)
-	barcodeSvc := service.NewBarcodeService(nil)
-	dataGenSvc := service.NewDataGeneratorService(nil)
-	codeFmtSvc := service.NewCodeFormatterService(nil)
-	dateTimeSvc := service.NewDateTimeService(nil)
-	numberConvSvc := service.NewNumberConverterService(nil)
-	themesSvc := service.NewThemesService(nil, themesDir())
-
-	// Create server and register services
-	server := router.NewServer()
-	server.Register(jwtSvc)
-	server.Register(encrypterSvc)
-	server.Register(encoderSvc)
-	server.Register(hashGenSvc)
-	server.Register(codeConvSvc)
-	server.Register(textUtilsSvc)
-	server.Register(barcodeSvc)
-	server.Register(dataGenSvc)
-	server.Register(codeFmtSvc)
-	server.Register(dateTimeSvc)
-	server.Register(numberConvSvc)
-	server.Register(themesSvc)
+		return filepath.Join(os.Getenv("APPDATA"), "DevToolbox")
+	}
+	return filepath.Join(os.Getenv("HOME"), ".config", "devtoolbox")
+}
+
+func themesDir() string {
+	return filepath.Join(configDir(), "themes")
+}
+
+func recipesDir() string {
+	return filepath.Join(configDir(), "recipes")
+}
+
+// defaultSocket is the --socket value that picks defaultSocketPath
+const defaultSocket = "default"
+
+// runtimeDir is the per-user runtime directory for sockets where there is
+// one, else the config directory
+func runtimeDir() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" && runtime.GOOS == "linux" {
+		return dir
+	}
+	return configDir()
+}
+
+// defaultSocketPath is devtoolbox.sock in the runtime directory
+func defaultSocketPath() string {
+	return filepath.Join(runtimeDir(), "devtoolbox.sock")
+}
+
+// version is the application version reported by /health, set at build
+// time with -ldflags "-X main.version=1.2.3"
+var version = "dev"
+
+// defaultPort is the HTTP server port when none is configured
+const defaultPort = 8081
+
+// fileRouteTimeout is the deadline for the upload routes, which may process
+// files far larger than a JSON request
+const fileRouteTimeout = 10 * time.Minute
+
+// serverFlags are the command-line options for the HTTP server. Flags given
+// on the command line override the config file and environment.
+type serverFlags struct {
+	config         string
+	host           string
+	port           int
+	allowOrigins   string
+	tlsCert        string
+	tlsKey         string
+	tls            bool
+	socket         string
+	requireToken   bool
+	enableTools    string
+	disableTools   string
+	maxBodyBytes   int64
+	maxUploadBytes int64
+	timeout        time.Duration
+	routeTimeouts  string
+	logLevel       string
+}
+
+// registerServerFlags defines the HTTP server flags on the default flag set
+func registerServerFlags() *serverFlags {
+	defaults := router.DefaultLimits()
+	f := &serverFlags{}
+	flag.StringVar(&f.config, "config", "", "Server config file (.yaml, .yml or .toml); defaults to server.yaml or server.toml in the config directory")
+	flag.StringVar(&f.host, "host", "127.0.0.1", "HTTP server bind address (empty for all interfaces)")
+	flag.IntVar(&f.port, "port", defaultPort, "HTTP server port")
+	flag.StringVar(&f.allowOrigins, "allow-origins", "", "Comma-separated CORS origins allowed to call the HTTP server (\"*\" for any)")
+	flag.StringVar(&f.tlsCert, "tls-cert", "", "PEM certificate file; serves HTTPS together with --tls-key")
+	flag.StringVar(&f.tlsKey, "tls-key", "", "PEM private key file for --tls-cert")
+	flag.BoolVar(&f.tls, "tls", false, "Serve HTTPS with a certificate from a local CA kept in the config directory")
+	flag.StringVar(&f.socket, "socket", "", "Also serve on a Unix socket at this path (\"default\" for devtoolbox.sock in the runtime or config directory); no token is needed over it")
+	flag.BoolVar(&f.requireToken, "require-token", false, "Require the per-install bearer token on /api/*, /rpc and /metrics")
+	flag.StringVar(&f.enableTools, "enable-tools", "", "Comma-separated tool IDs to serve (default all)")
+	flag.StringVar(&f.disableTools, "disable-tools", "", "Comma-separated tool IDs not to serve")
+	flag.Int64Var(&f.maxBodyBytes, "max-body-bytes", defaults.MaxBodyBytes, "Largest JSON request body in bytes (0 for no limit)")
+	flag.Int64Var(&f.maxUploadBytes, "max-upload-bytes", defaults.MaxUploadBytes, "Largest file upload in bytes (0 for no limit)")
+	flag.DurationVar(&f.timeout, "timeout", defaults.Timeout, "Deadline for each API call (0 for none)")
+	flag.StringVar(&f.routeTimeouts, "route-timeouts", "", "Comma-separated per-method deadlines, e.g. \"DataGeneratorService.Generate=2m\"")
+	flag.StringVar(&f.logLevel, "log-level", "info", "Lowest server log level: debug, info, warn or error")
+	return f
+}
+
+// loadServerConfig merges, from lowest to highest precedence, the defaults,
+// the config file, DEVTOOLBOX_* environment variables and the flags given on
+// the command line
+func loadServerConfig(f *serverFlags) (settings.ServerConfig, error) {
+	defaults := router.DefaultConfig()
+	cfg := settings.ServerConfig{
+		Host: defaults.Host,
+		Port: defaultPort,
+		Limits: settings.ServerLimits{
+			MaxBodyBytes:   defaults.MaxBodyBytes,
+			MaxUploadBytes: defaults.MaxUploadBytes,
+			Timeout:        settings.Duration(defaults.Timeout),
+			RouteTimeouts: map[string]settings.Duration{
+				"HashGeneratorService.HashFile": settings.Duration(fileRouteTimeout),
+				"EncoderService.EncodeFile":     settings.Duration(fileRouteTimeout),
+				"EncoderService.DecodeFile":     settings.Duration(fileRouteTimeout),
+			},
+		},
+		LogLevel: "info",
+	}
+
+	path := f.config
+	if path == "" {
+		path = os.Getenv(settings.EnvPrefix + "CONFIG")
+	}
+	if path == "" {
+		path = settings.FindServerConfig(configDir())
+	}
+	if path != "" {
+		if err := settings.LoadServerConfig(path, &cfg); err != nil {
+			return cfg, err
+		}
+		log.Printf("Loaded server config from %s", path)
+	}
+
+	if err := cfg.ApplyEnv(os.LookupEnv); err != nil {
+		return cfg, err
+	}
+
+	var err error
+	flag.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "host":
+			cfg.Host = f.host
+		case "port":
+			cfg.Port = f.port
+		case "allow-origins":
+			cfg.AllowOrigins = settings.SplitList(f.allowOrigins)
+		case "tls-cert":
+			cfg.TLS.Cert = f.tlsCert
+		case "tls-key":
+			cfg.TLS.Key = f.tlsKey
+		case "tls":
+			cfg.TLS.Auto = f.tls
+		case "socket":
+			cfg.Socket = f.socket
+		case "require-token":
+			cfg.RequireToken = f.requireToken
+		case "enable-tools":
+			cfg.Tools.Enabled = settings.SplitList(f.enableTools)
+		case "disable-tools":
+			cfg.Tools.Disabled = settings.SplitList(f.disableTools)
+		case "max-body-bytes":
+			cfg.Limits.MaxBodyBytes = f.maxBodyBytes
+		case "max-upload-bytes":
+			cfg.Limits.MaxUploadBytes = f.maxUploadBytes
+		case "timeout":
+			cfg.Limits.Timeout = settings.Duration(f.timeout)
+		case "route-timeouts":
+			if cfg.Limits.RouteTimeouts == nil {
+				cfg.Limits.RouteTimeouts = map[string]settings.Duration{}
+			}
+			err = settings.ParseRouteTimeouts(f.routeTimeouts, cfg.Limits.RouteTimeouts)
+		case "log-level":
+			cfg.LogLevel = f.logLevel
+		}
+	})
+	return cfg, err
+}
+
+// httpServerConfig builds the router config from the server config, loading
+// the per-install API token when one is required without being configured
+// and the local certificate when HTTPS is on without certificate files
+func httpServerConfig(sc settings.ServerConfig) (router.Config, error) {
+	cfg := router.DefaultConfig()
+	cfg.Host = sc.Host
+	if len(sc.AllowOrigins) > 0 {
+		cfg.AllowOrigins = sc.AllowOrigins
+	}
+	cfg.TLSCertFile = sc.TLS.Cert
+	cfg.TLSKeyFile = sc.TLS.Key
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		return cfg, fmt.Errorf("TLS needs both a certificate and a key")
+	}
+	if sc.TLS.Auto && cfg.TLSCertFile == "" {
+		local, err := settings.LoadOrCreateLocalCertificate(configDir(), localTLSHosts(sc.Host))
+		if err != nil {
+			return cfg, fmt.Errorf("failed to load local certificate: %w", err)
+		}
+		cfg.TLSCertFile = local.CertFile
+		cfg.TLSKeyFile = local.KeyFile
+		log.Printf("Serving HTTPS with a local certificate; trust its CA once: %s", local.CAFile)
+	}
+	cfg.SocketPath = sc.Socket
+	if cfg.SocketPath == defaultSocket {
+		cfg.SocketPath = defaultSocketPath()
+	}
+	cfg.Version = version
+
+	cfg.MaxBodyBytes = sc.Limits.MaxBodyBytes
+	cfg.MaxUploadBytes = sc.Limits.MaxUploadBytes
+	cfg.Timeout = time.Duration(sc.Limits.Timeout)
+	cfg.RouteTimeouts = map[string]time.Duration{}
+	for name, d := range sc.Limits.RouteTimeouts {
+		cfg.RouteTimeouts[name] = time.Duration(d)
+	}
+
+	switch {
+	case sc.Token != "":
+		cfg.Token = sc.Token
+	case sc.RequireToken:
+		token, err := settings.LoadOrCreateAPIToken(configDir())
+		if err != nil {
+			return cfg, fmt.Errorf("failed to load API token: %w", err)
+		}
+		cfg.Token = token
+		log.Printf("API token required; it is stored in %s", filepath.Join(configDir(), settings.APITokenFile))
+	}
+
+	return cfg, nil
+}
+
+// localTLSHosts are the names the local certificate covers: loopback, the
+// machine's hostname and the bind address, or every interface address when
+// the server listens on all of them
+func localTLSHosts(bindHost string) []string {
+	hosts := []string{"localhost", "127.0.0.1", "::1"}
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		hosts = append(hosts, hostname)
+	}
+
+	if ip := net.ParseIP(bindHost); bindHost != "" && (ip == nil || !ip.IsUnspecified()) {
+		if !slices.Contains(hosts, bindHost) {
+			hosts = append(hosts, bindHost)
+		}
+		return hosts
+	}
+	addrs, _ := net.InterfaceAddrs()
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && !ipNet.IP.IsLoopback() && !ipNet.IP.IsLinkLocalUnicast() {
+			if ip := ipNet.IP.String(); !slices.Contains(hosts, ip) {
+				hosts = append(hosts, ip)
+			}
+		}
+	}
+	return hosts
+}
+
+// accessLogger writes JSON access logs to stdout at level and above
+func accessLogger(level string) (*slog.Logger, error) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q: want debug, info, warn or error", level)
+	}
+	return slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: l})), nil
+}
+
+// enabledTools returns the tools the server config selects
+func enabledTools(registry *tools.Registry, sc settings.ServerConfig) (*tools.Registry, error) {
+	return registry.Filter(sc.Tools.Enabled, sc.Tools.Disabled)
+}
+
+// newToolServer creates the server with the service of every tool in the
+// registry registered, plus the themes API the browser frontend loads, the
+// recipes API, and the pinned API versions served under /api/<version>/
+func newToolServer(cfg router.Config, registry *tools.Registry) (*router.Server, error) {
+	server := router.NewServerWithConfig(cfg)
+	server.SetParamNames(service.MethodParams)
+	server.SetMethodDocs(service.MethodDocs)
+	if err := server.RegisterTools(registry); err != nil {
+		return nil, err
+	}
+	if err := server.Register(service.NewThemesService(nil, themesDir())); err != nil {
+		return nil, err
+	}
+	if err := server.Register(service.NewRecipeService(nil, recipesDir())); err != nil {
+		return nil, err
+	}
+
+	manifests, err := fs.Glob(service.APIManifests, "api/*.json")
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range manifests {
+		data, err := service.APIManifests.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		m, err := router.ParseManifest(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		if err := server.RegisterManifest(m); err != nil {
+			return nil, err
+		}
+	}
+	return server, nil
+}
+
+// StartHTTPServer starts the HTTP server with the registry's tools
+// registered and bus events streamed at /api/events. A non-nil frontend, the
+// built app, is served on every other route. When ctx is done the server
+// drains in-flight requests and returns.
+func StartHTTPServer(ctx context.Context, cfg router.Config, port int, registry *tools.Registry, bus *events.Bus, frontend fs.FS) {
+	server, err := newToolServer(cfg, registry)
+	if err != nil {
+		log.Printf("HTTP server not started: %v", err)
+		return
+	}
+	server.SetEventBus(bus)
+	if frontend != nil {
+		if err := server.ServeFrontend(frontend); err != nil {
+			log.Printf("Serving the API only: %v", err)
+		}
+	}
 
 	// Start server
-	server.Start(port)
+	scheme := "http"
+	if cfg.TLSCertFile != "" {
+		scheme = "https"
+	}
+	log.Printf("HTTP server listening on %s://%s", scheme, server.Addr(port))
+	if cfg.SocketPath != "" {
+		log.Printf("HTTP server listening on unix socket %s", cfg.SocketPath)
+	}
+	if err := server.Run(ctx, port); err != nil {
+		log.Printf("HTTP server stopped: %v", err)
+		return
+	}
+	log.Printf("HTTP server shut down")
+}
+
+// ServeMCP serves every tool over the Model Context Protocol on stdin and
+// stdout until stdin closes. Logs go to stderr, keeping stdout for the protocol.
+func ServeMCP(cfg router.Config, registry *tools.Registry) error {
+	log.SetOutput(os.Stderr)
+	server, err := newToolServer(cfg, registry)
+	if err != nil {
+		return err
+	}
+	log.Printf("Serving MCP on stdio")
+	return server.ServeMCP(context.Background(), os.Stdin, os.Stdout)
+}
+
+// webviewAPIConfig is what the desktop webview needs to call the HTTP server
+type webviewAPIConfig struct {
+	BaseURL string `json:"baseURL"`
+	Token   string `json:"token,omitempty"`
+}
+
+// newWebviewAPIConfig points the webview at the server, using loopback when
+// the server listens on every interface
+func newWebviewAPIConfig(cfg router.Config, port int) webviewAPIConfig {
+	host := cfg.Host
+	if host == "" || net.ParseIP(host).IsUnspecified() {
+		host = "127.0.0.1"
+	}
+	scheme := "http"
+	if cfg.TLSCertFile != "" {
+		scheme = "https"
+	}
+	return webviewAPIConfig{
+		BaseURL: scheme + "://" + net.JoinHostPort(host, strconv.Itoa(port)),
+		Token:   cfg.Token,
+	}
+}
+
+// WebviewAPIMiddleware hands the HTTP server's address and token to the
+// desktop webview by injecting window.__DEVTOOLBOX_API__ into every page
+// before the frontend's own scripts run
+func WebviewAPIMiddleware(apiConfig webviewAPIConfig) application.Middleware {
+	payload, _ := json.Marshal(apiConfig)
+	return router.InjectScript([]byte("<script>window.__DEVTOOLBOX_API__ = " + string(payload) + ";</script>"))
 }
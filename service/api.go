@@ -0,0 +1,10 @@
+package service
+
+import "embed"
+
+// APIManifests holds the pinned API versions, api/v1.json and so on, served
+// under /api/<version>/. A route's contract may not change within a version;
+// TestAPIManifests_Compatible enforces it.
+//
+//go:embed api/*.json
+var APIManifests embed.FS
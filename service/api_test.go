@@ -0,0 +1,108 @@
+package service
+
+import (
+	"encoding/json"
+	"flag"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"devtoolbox/pkg/router"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateManifest = flag.Bool("update-manifest", false, "pin newly added methods in the latest API manifest")
+
+// newAPIRouter registers the services the HTTP server serves
+func newAPIRouter(t *testing.T) *router.Router {
+	t.Helper()
+	r := router.New(gin.New())
+	r.SetParamNames(MethodParams)
+	r.SetMethodDocs(MethodDocs)
+	require.NoError(t, r.RegisterTools(NewToolRegistry(nil)))
+	require.NoError(t, r.Register(NewThemesService(nil, t.TempDir())))
+	require.NoError(t, r.Register(NewRecipeService(nil, t.TempDir())))
+	return r
+}
+
+// TestAPIManifests_Compatible fails when a pinned method is removed or its
+// request or response changes. Keep the old method for the old version and
+// pin the new one in a new manifest instead. New methods are pinned in the
+// latest manifest with: go test ./service -run TestAPIManifests -update-manifest
+func TestAPIManifests_Compatible(t *testing.T) {
+	r := newAPIRouter(t)
+
+	files, err := fs.Glob(APIManifests, "api/*.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	var latest router.Manifest
+	var latestNumber int
+	for _, file := range files {
+		data, err := APIManifests.ReadFile(file)
+		require.NoError(t, err)
+		m, err := router.ParseManifest(data)
+		require.NoError(t, err, file)
+		assert.Equal(t, m.Version+".json", path.Base(file))
+
+		for _, route := range m.Routes {
+			contract, ok := r.Contract(route.Method)
+			if !ok {
+				t.Errorf("%s %s: %s no longer exists; keep it until the version is retired", m.Version, route.Path, route.Method)
+				continue
+			}
+			if contract != route.Contract {
+				t.Errorf("%s %s: the contract of %s changed without a version bump", m.Version, route.Path, route.Method)
+			}
+		}
+		assert.NoError(t, router.New(gin.New()).RegisterManifest(m), file)
+
+		if n, _ := strconv.Atoi(strings.TrimPrefix(m.Version, "v")); n > latestNumber {
+			latest, latestNumber = m, n
+		}
+	}
+
+	if *updateManifest {
+		pinNewMethods(t, r, latest)
+	}
+}
+
+// pinNewMethods adds the registered methods m lacks at their unversioned
+// path and rewrites its file
+func pinNewMethods(t *testing.T, r *router.Router, m router.Manifest) {
+	pinned := map[string]bool{}
+	for _, route := range m.Routes {
+		pinned[route.Method] = true
+	}
+
+	doc := r.OpenAPI()
+	paths := make([]string, 0, len(doc.Paths))
+	for p := range doc.Paths {
+		paths = append(paths, p)
+	}
+	slices.Sort(paths)
+	for _, p := range paths {
+		name := doc.Paths[p]["post"].OperationID
+		if pinned[name] {
+			continue
+		}
+		contract, _ := r.Contract(name)
+		m.Routes = append(m.Routes, router.ManifestRoute{
+			Path:     strings.TrimPrefix(p, "/api/"),
+			Method:   name,
+			Contract: contract,
+		})
+		t.Logf("pinned %s at /api/%s/%s", name, m.Version, strings.TrimPrefix(p, "/api/"))
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile("api/"+m.Version+".json", append(data, '\n'), 0644))
+}
@@ -2,21 +2,19 @@ package service
 
 import (
 	"context"
+
 	"devtoolbox/internal/barcode"
+	"devtoolbox/pkg/devtoolbox"
 
 	"github.com/wailsapp/wails/v3/pkg/application"
 )
 
 type BarcodeService struct {
 	app *application.App
-	svc barcode.BarcodeService
 }
 
 func NewBarcodeService(app *application.App) *BarcodeService {
-	return &BarcodeService{
-		app: app,
-		svc: barcode.NewBarcodeService(),
-	}
+	return &BarcodeService{app: app}
 }
 
 func (s *BarcodeService) ServiceStartup(ctx context.Context, options application.ServiceOptions) error {
@@ -25,25 +23,38 @@ func (s *BarcodeService) ServiceStartup(ctx context.Context, options application
 
 // GenerateBarcode generates a barcode based on the selected standard
 func (s *BarcodeService) GenerateBarcode(req barcode.GenerateBarcodeRequest) barcode.GenerateBarcodeResponse {
-	return s.svc.GenerateBarcode(req)
+	resp, err := devtoolbox.GenerateBarcode(context.Background(), req.Content, devtoolbox.BarcodeOptions{
+		Standard: devtoolbox.BarcodeStandard(req.Standard),
+		Size:     req.Size,
+		Level:    req.Level,
+	})
+	if err != nil {
+		return barcode.GenerateBarcodeResponse{Error: err.Error()}
+	}
+	return barcode.GenerateBarcodeResponse{DataURL: resp.DataURL}
 }
 
 // GetBarcodeStandards returns available barcode standards
 func (s *BarcodeService) GetBarcodeStandards() []map[string]string {
-	return s.svc.GetBarcodeStandards()
+	return devtoolbox.BarcodeStandards()
 }
 
 // GetQRErrorLevels returns available error correction levels for QR codes
 func (s *BarcodeService) GetQRErrorLevels() []map[string]string {
-	return s.svc.GetQRErrorLevels()
+	return devtoolbox.QRErrorLevels()
 }
 
 // GetBarcodeSizes returns available barcode sizes
 func (s *BarcodeService) GetBarcodeSizes() []map[string]interface{} {
-	return s.svc.GetBarcodeSizes()
+	return devtoolbox.BarcodeSizes()
 }
 
 // ValidateContent validates content for specific barcode standards
 func (s *BarcodeService) ValidateContent(content string, standard string) map[string]interface{} {
-	return s.svc.ValidateContent(content, standard)
+	result := map[string]interface{}{"valid": true, "message": ""}
+	if err := devtoolbox.ValidateBarcode(content, devtoolbox.BarcodeStandard(standard)); err != nil {
+		result["valid"] = false
+		result["message"] = err.Error()
+	}
+	return result
 }
@@ -2,29 +2,31 @@ package service
 
 import (
 	"context"
-	"devtoolbox/internal/converter"
+
+	"devtoolbox/pkg/devtoolbox"
 
 	"github.com/wailsapp/wails/v3/pkg/application"
 )
 
 type CodeConverterService struct {
 	app *application.App
-	svc converter.ConverterService
 }
 
 func NewCodeConverterService(app *application.App) *CodeConverterService {
-	return &CodeConverterService{app: app, svc: converter.NewFormattingConverter()}
+	return &CodeConverterService{app: app}
 }
 
 func (s *CodeConverterService) ServiceStartup(ctx context.Context, options application.ServiceOptions) error {
 	return nil
 }
 
+// Convert runs one conversion, named by method, over input. ListMethods
+// lists the names it accepts
 func (s *CodeConverterService) Convert(input, method string) (string, error) {
-	return s.svc.Convert(converter.ConversionRequest{
-		Input:    input,
-		Category: "Convert",
-		Method:   method,
-		Config:   map[string]interface{}{},
-	})
+	return devtoolbox.Convert(context.Background(), input, devtoolbox.Conversion(method))
+}
+
+// ListMethods describes the conversions the service accepts
+func (s *CodeConverterService) ListMethods() ([]devtoolbox.Method, error) {
+	return devtoolbox.ConversionMethods(), nil
 }
@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+
 	"devtoolbox/internal/codeformatter"
+	"devtoolbox/pkg/devtoolbox"
 
 	"github.com/wailsapp/wails/v3/pkg/application"
 )
@@ -10,15 +12,11 @@ import (
 // CodeFormatterService is the Wails binding struct for code formatting operations
 type CodeFormatterService struct {
 	app *application.App
-	svc codeformatter.CodeFormatterService
 }
 
 // NewCodeFormatterService creates a new CodeFormatterService instance
 func NewCodeFormatterService(app *application.App) *CodeFormatterService {
-	return &CodeFormatterService{
-		svc: codeformatter.NewCodeFormatterService(),
-		app: app,
-	}
+	return &CodeFormatterService{app: app}
 }
 
 // Startup is called when the app starts (Wails lifecycle)
@@ -28,6 +26,13 @@ func (c *CodeFormatterService) ServiceStartup(ctx context.Context, options appli
 
 // Format formats code based on the request
 // This method is exposed to the frontend via Wails
-func (c *CodeFormatterService) Format(req codeformatter.FormatRequest) codeformatter.FormatResponse {
-	return c.svc.Format(req)
+func (c *CodeFormatterService) Format(ctx context.Context, req codeformatter.FormatRequest) codeformatter.FormatResponse {
+	output, err := devtoolbox.Format(ctx, req.Input, devtoolbox.Language(req.FormatType), devtoolbox.FormatOptions{
+		Filter: req.Filter,
+		Minify: req.Minify,
+	})
+	if err != nil {
+		return codeformatter.FormatResponse{Error: err.Error()}
+	}
+	return codeformatter.FormatResponse{Output: output}
 }
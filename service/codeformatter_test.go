@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"testing"
 
 	"devtoolbox/internal/codeformatter"
@@ -72,7 +73,7 @@ func TestCodeFormatterService_Format(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			resp := svc.Format(tt.req)
+			resp := svc.Format(context.Background(), tt.req)
 			if tt.wantError {
 				assert.NotEmpty(t, resp.Error)
 				assert.Contains(t, resp.Error, tt.wantSubstr)
@@ -90,7 +91,7 @@ func TestCodeFormatterService_Format(t *testing.T) {
 func TestCodeFormatterService_FormatEmptyInput(t *testing.T) {
 	svc := NewCodeFormatterService(nil)
 
-	resp := svc.Format(codeformatter.FormatRequest{
+	resp := svc.Format(context.Background(), codeformatter.FormatRequest{
 		Input:      "   ",
 		FormatType: "json",
 	})
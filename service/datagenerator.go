@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"fmt"
+
 	"devtoolbox/internal/datagenerator"
+	"devtoolbox/pkg/devtoolbox"
 
 	"github.com/wailsapp/wails/v3/pkg/application"
 )
@@ -10,15 +13,11 @@ import (
 // DataGeneratorService provides data generation functionality via Wails
 type DataGeneratorService struct {
 	app *application.App
-	svc datagenerator.DataGeneratorService
 }
 
 // NewDataGeneratorService creates a new DataGeneratorService
 func NewDataGeneratorService(app *application.App) *DataGeneratorService {
-	return &DataGeneratorService{
-		svc: datagenerator.NewDataGeneratorService(),
-		app: app,
-	}
+	return &DataGeneratorService{app: app}
 }
 
 // Startup is called when the service starts
@@ -27,35 +26,54 @@ func (d *DataGeneratorService) ServiceStartup(ctx context.Context, options appli
 }
 
 // Generate generates data based on the provided request
-func (d *DataGeneratorService) Generate(req datagenerator.GenerateRequest) datagenerator.GenerateResponse {
-	resp, err := d.svc.Generate(req)
+func (d *DataGeneratorService) Generate(ctx context.Context, req datagenerator.GenerateRequest) datagenerator.GenerateResponse {
+	data, err := devtoolbox.GenerateData(ctx, req.Template, devtoolbox.GenerateOptions{
+		Variables: req.Variables,
+		Count:     req.BatchCount,
+		Format:    req.OutputFormat,
+		Separator: req.Separator,
+	})
 	if err != nil {
-		return datagenerator.GenerateResponse{
-			Error: err.Error(),
-		}
+		return datagenerator.ErrorResponse(err)
+	}
+	return datagenerator.GenerateResponse{
+		Output:   data.Output,
+		Count:    data.Count,
+		Duration: data.Duration.Milliseconds(),
 	}
-	return *resp
 }
 
 // GetPresets returns all available template presets
 func (d *DataGeneratorService) GetPresets() datagenerator.PresetsResponse {
-	resp, err := d.svc.GetPresets()
-	if err != nil {
-		return datagenerator.PresetsResponse{
-			Error: err.Error(),
+	presets := devtoolbox.DataPresets()
+	resp := datagenerator.PresetsResponse{Presets: make([]datagenerator.TemplatePreset, len(presets))}
+	for i, p := range presets {
+		vars := make([]datagenerator.Variable, len(p.Variables))
+		for j, v := range p.Variables {
+			vars[j] = datagenerator.Variable(v)
+		}
+		resp.Presets[i] = datagenerator.TemplatePreset{
+			ID:          p.ID,
+			Name:        p.Name,
+			Description: p.Description,
+			Template:    p.Template,
+			Variables:   vars,
 		}
 	}
-	return *resp
+	return resp
 }
 
 // ValidateTemplate validates a template string
 func (d *DataGeneratorService) ValidateTemplate(template string) datagenerator.ValidationResult {
-	resp, err := d.svc.ValidateTemplate(template)
-	if err != nil {
+	if err := devtoolbox.ValidateTemplate(template); err != nil {
 		return datagenerator.ValidationResult{
-			Valid: false,
-			Error: err.Error(),
+			Valid:   false,
+			Error:   err.Error(),
+			Message: fmt.Sprintf("Template validation failed: %v", err),
 		}
 	}
-	return *resp
+	return datagenerator.ValidationResult{
+		Valid:   true,
+		Message: "Template is valid",
+	}
 }
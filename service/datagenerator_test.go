@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -51,7 +52,7 @@ func TestDataGeneratorService_Generate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			resp := svc.Generate(tt.req)
+			resp := svc.Generate(context.Background(), tt.req)
 			if tt.wantError {
 				assert.NotEmpty(t, resp.Error)
 				return
@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+
 	"devtoolbox/internal/datetimeconverter"
+	"devtoolbox/pkg/devtoolbox"
 
 	"github.com/wailsapp/wails/v3/pkg/application"
 )
@@ -10,15 +12,11 @@ import (
 // DateTimeService is the Wails binding struct for Unix Time operations
 type DateTimeService struct {
 	app *application.App
-	svc datetimeconverter.Service
 }
 
 // NewDateTimeService creates a new UnixTimeService instance
 func NewDateTimeService(app *application.App) *DateTimeService {
-	return &DateTimeService{
-		svc: datetimeconverter.NewService(),
-		app: app,
-	}
+	return &DateTimeService{app: app}
 }
 
 // ServiceStartup is called when the app starts (Wails lifecycle)
@@ -29,21 +27,61 @@ func (u *DateTimeService) ServiceStartup(ctx context.Context, options applicatio
 // Convert converts a timestamp or date string to all formats
 // This method is exposed to the frontend via Wails
 func (u *DateTimeService) Convert(req datetimeconverter.ConvertRequest) (datetimeconverter.ConvertResponse, error) {
-	return u.svc.Convert(req), nil
+	conv, err := devtoolbox.ConvertTime(context.Background(), req.Input, devtoolbox.TimeOptions{
+		Timezone:     req.Timezone,
+		Precision:    req.Precision,
+		OutputFormat: req.OutputFormat,
+		CustomFormat: req.CustomFormat,
+	})
+	if err != nil {
+		return datetimeconverter.ErrorResponse(err), nil
+	}
+	return datetimeconverter.FromTimeResult(timeResult(conv.Time), conv.DetectedType, conv.DetectedPrecision), nil
 }
 
 // GetPresets returns all available quick presets
 // This method is exposed to the frontend via Wails
 func (u *DateTimeService) GetPresets() (datetimeconverter.PresetsResponse, error) {
-	return u.svc.GetPresets(), nil
+	presets := devtoolbox.TimePresets()
+	resp := datetimeconverter.PresetsResponse{Presets: make([]datetimeconverter.Preset, len(presets))}
+	for i, p := range presets {
+		resp.Presets[i] = datetimeconverter.Preset(p)
+	}
+	return resp, nil
 }
 
 // CalculateDelta calculates the difference between two dates
 // This method is exposed to the frontend via Wails
 func (u *DateTimeService) CalculateDelta(req datetimeconverter.DeltaRequest) (datetimeconverter.DeltaResponse, error) {
-	return u.svc.CalculateDelta(req), nil
+	delta, err := devtoolbox.TimeBetween(context.Background(), req.DateA, req.DateB)
+	if err != nil {
+		return datetimeconverter.DeltaErrorResponse(err), nil
+	}
+	return datetimeconverter.DeltaResponse{Delta: (*datetimeconverter.TimeDelta)(delta)}, nil
 }
 
 func (u *DateTimeService) GetAvailableTimezones() (datetimeconverter.AvailableTimezonesResponse, error) {
-	return u.svc.GetAvailableTimezones(), nil
+	zones := devtoolbox.Timezones()
+	resp := datetimeconverter.AvailableTimezonesResponse{Timezones: make([]datetimeconverter.TimezoneInfo, len(zones))}
+	for i, z := range zones {
+		resp.Timezones[i] = datetimeconverter.TimezoneInfo(z)
+	}
+	return resp, nil
+}
+
+// timeResult converts the SDK's result, which may be nil, for the response
+func timeResult(r *devtoolbox.TimeResult) *datetimeconverter.TimeResult {
+	if r == nil {
+		return nil
+	}
+	return &datetimeconverter.TimeResult{
+		UnixSeconds:     r.UnixSeconds,
+		UnixMillis:      r.UnixMillis,
+		UnixMicros:      r.UnixMicros,
+		UnixNanos:       r.UnixNanos,
+		UTC:             r.UTC,
+		Local:           r.Local,
+		Relative:        r.Relative,
+		RelativeDetails: datetimeconverter.RelativeBreakdown(r.RelativeDetails),
+	}
 }
@@ -2,61 +2,62 @@ package service
 
 import (
 	"context"
-	"devtoolbox/internal/converter"
+	"io"
+
+	"devtoolbox/pkg/devtoolbox"
 
 	"github.com/wailsapp/wails/v3/pkg/application"
 )
 
 type EncoderService struct {
-	app             *application.App
-	encodingService converter.ConverterService
-	escapeService   converter.ConverterService
+	app *application.App
 }
 
 func NewEncoderService(app *application.App) *EncoderService {
-	return &EncoderService{
-		app:             app,
-		encodingService: converter.NewEncodingConverter(),
-		escapeService:   converter.NewEscapeConverter(),
-	}
+	return &EncoderService{app: app}
 }
 
 func (s *EncoderService) ServiceStartup(ctx context.Context, options application.ServiceOptions) error {
 	return nil
 }
 
+// Encode encodes input with the encoding named by method, e.g. base64 or hex
 func (s *EncoderService) Encode(input, method string) (string, error) {
-	return s.encodingService.Convert(converter.ConversionRequest{
-		Input:    input,
-		Category: "Encode - Decode",
-		Method:   method,
-		Config:   map[string]interface{}{"subMode": "Encode"},
-	})
+	return devtoolbox.Encode(context.Background(), input, devtoolbox.Encoding(method))
 }
 
+// Decode reverses Encode for the encoding named by method
 func (s *EncoderService) Decode(input, method string) (string, error) {
-	return s.encodingService.Convert(converter.ConversionRequest{
-		Input:    input,
-		Category: "Encode - Decode",
-		Method:   method,
-		Config:   map[string]interface{}{"subMode": "Decode"},
-	})
+	return devtoolbox.Decode(context.Background(), input, devtoolbox.Encoding(method))
 }
 
+// Escape escapes input for the format named by method, e.g. json or html
 func (s *EncoderService) Escape(input, method string) (string, error) {
-	return s.escapeService.Convert(converter.ConversionRequest{
-		Input:    input,
-		Category: "Escape",
-		Method:   method,
-		Config:   map[string]interface{}{"subMode": "Escape"},
-	})
+	return devtoolbox.Escape(context.Background(), input, devtoolbox.Escaping(method))
 }
 
+// Unescape reverses Escape for the format named by method
 func (s *EncoderService) Unescape(input, method string) (string, error) {
-	return s.escapeService.Convert(converter.ConversionRequest{
-		Input:    input,
-		Category: "Escape",
-		Method:   method,
-		Config:   map[string]interface{}{"subMode": "Unescape"},
-	})
+	return devtoolbox.Unescape(context.Background(), input, devtoolbox.Escaping(method))
+}
+
+// ListMethods describes the encodings and escapings the service accepts,
+// with the directions and config each supports
+func (s *EncoderService) ListMethods() ([]devtoolbox.Method, error) {
+	return append(devtoolbox.EncodingMethods(), devtoolbox.EscapeMethods()...), nil
+}
+
+// encoderStreams holds the EncoderService operations on streams. Only the
+// HTTP server routes them, under EncoderService: the desktop bindings
+// cannot carry an io.Reader.
+type encoderStreams struct{}
+
+// EncodeFile encodes a stream, such as an uploaded file, returning the encoded stream
+func (encoderStreams) EncodeFile(ctx context.Context, input io.Reader, method string) (io.Reader, error) {
+	return devtoolbox.EncodeReader(ctx, input, devtoolbox.Encoding(method)), nil
+}
+
+// DecodeFile decodes a stream, returning the decoded bytes as a stream
+func (encoderStreams) DecodeFile(ctx context.Context, input io.Reader, method string) (io.Reader, error) {
+	return devtoolbox.DecodeReader(ctx, input, devtoolbox.Encoding(method)), nil
 }
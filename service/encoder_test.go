@@ -33,3 +33,18 @@ func TestEncoderService_EscapeUnescape(t *testing.T) {
 		t.Fatalf("expected '<div>', got '%s'", unescaped)
 	}
 }
+
+func TestEncoderService_ListMethods(t *testing.T) {
+	svc := NewEncoderService(nil)
+	methods, err := svc.ListMethods()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	categories := map[string]int{}
+	for _, m := range methods {
+		categories[m.Category]++
+	}
+	if categories["Encode - Decode"] == 0 || categories["Escape"] == 0 {
+		t.Fatalf("expected encodings and escapings, got %v", categories)
+	}
+}
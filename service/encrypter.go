@@ -2,38 +2,37 @@ package service
 
 import (
 	"context"
-	"devtoolbox/internal/converter"
+
+	"devtoolbox/pkg/devtoolbox"
 
 	"github.com/wailsapp/wails/v3/pkg/application"
 )
 
 type EncrypterService struct {
 	app *application.App
-	svc converter.ConverterService
 }
 
 func NewEncrypterService(app *application.App) *EncrypterService {
-	return &EncrypterService{app: app, svc: converter.NewEncryptionConverter()}
+	return &EncrypterService{app: app}
 }
 
 func (s *EncrypterService) ServiceStartup(ctx context.Context, options application.ServiceOptions) error {
 	return nil
 }
 
+// Encrypt encrypts input with the cipher named by method, using key and iv
+// where the cipher needs them
 func (s *EncrypterService) Encrypt(input, method, key, iv string) (string, error) {
-	return s.svc.Convert(converter.ConversionRequest{
-		Input:    input,
-		Category: "Encrypt - Decrypt",
-		Method:   method,
-		Config:   map[string]interface{}{"subMode": "Encrypt", "key": key, "iv": iv},
-	})
+	return devtoolbox.Encrypt(context.Background(), input, devtoolbox.Cipher(method), devtoolbox.CipherOptions{Key: key, IV: iv})
 }
 
+// Decrypt reverses Encrypt for the cipher named by method
 func (s *EncrypterService) Decrypt(input, method, key, iv string) (string, error) {
-	return s.svc.Convert(converter.ConversionRequest{
-		Input:    input,
-		Category: "Encrypt - Decrypt",
-		Method:   method,
-		Config:   map[string]interface{}{"subMode": "Decrypt", "key": key, "iv": iv},
-	})
+	return devtoolbox.Decrypt(context.Background(), input, devtoolbox.Cipher(method), devtoolbox.CipherOptions{Key: key, IV: iv})
+}
+
+// ListMethods describes the ciphers the service accepts, with the key and
+// IV each requires
+func (s *EncrypterService) ListMethods() ([]devtoolbox.Method, error) {
+	return devtoolbox.CipherMethods(), nil
 }
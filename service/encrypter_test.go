@@ -26,3 +26,20 @@ func TestEncrypterService_Decrypt(t *testing.T) {
 		t.Fatalf("expected 'hello', got '%s'", decrypted)
 	}
 }
+
+func TestEncrypterService_ListMethods(t *testing.T) {
+	svc := NewEncrypterService(nil)
+	methods, err := svc.ListMethods()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, m := range methods {
+		if m.Name == "XOR" {
+			if len(m.Config) != 1 || m.Config[0].Key != "key" || !m.Config[0].Required {
+				t.Fatalf("expected XOR to require a key, got %+v", m.Config)
+			}
+			return
+		}
+	}
+	t.Fatal("expected XOR to be listed")
+}
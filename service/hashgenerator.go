@@ -2,55 +2,57 @@ package service
 
 import (
 	"context"
-	"devtoolbox/internal/converter"
-	"encoding/json"
+	"io"
+
+	"devtoolbox/pkg/devtoolbox"
 
 	"github.com/wailsapp/wails/v3/pkg/application"
 )
 
 type HashGeneratorService struct {
-	app  *application.App
-	svc  converter.ConverterService
-	hash converter.ConverterService
+	app *application.App
 }
 
 func NewHashGeneratorService(app *application.App) *HashGeneratorService {
-	return &HashGeneratorService{
-		app:  app,
-		svc:  converter.NewConverterService(),
-		hash: converter.NewHashingConverter(),
-	}
+	return &HashGeneratorService{app: app}
 }
 
 func (s *HashGeneratorService) ServiceStartup(ctx context.Context, options application.ServiceOptions) error {
 	return nil
 }
 
-func (s *HashGeneratorService) Hash(input, method string, config map[string]interface{}) (string, error) {
-	if config == nil {
-		config = map[string]interface{}{}
-	}
-	return s.hash.Convert(converter.ConversionRequest{
-		Input:    input,
-		Category: "Hash",
-		Method:   method,
-		Config:   config,
-	})
-}
-
-func (s *HashGeneratorService) HashAll(input string) (map[string]string, error) {
-	result, err := s.svc.Convert(converter.ConversionRequest{
-		Input:    input,
-		Category: "Hash",
-		Method:   "All",
-		Config:   map[string]interface{}{},
-	})
-	if err != nil {
-		return nil, err
-	}
-	var results map[string]string
-	if err := json.Unmarshal([]byte(result), &results); err != nil {
-		return nil, err
+// Hash hashes input with the algorithm named by method. config carries
+// algorithm options such as an HMAC key
+func (s *HashGeneratorService) Hash(ctx context.Context, input, method string, config map[string]interface{}) (string, error) {
+	return devtoolbox.Hash(ctx, input, devtoolbox.HashAlgorithm(method), hashOptions(config))
+}
+
+// HashAll hashes input with every fast algorithm, keyed by algorithm name
+func (s *HashGeneratorService) HashAll(ctx context.Context, input string) (map[string]string, error) {
+	return devtoolbox.HashAll(ctx, input)
+}
+
+// hashOptions reads the options the frontend sends as a config object
+func hashOptions(config map[string]interface{}) devtoolbox.HashOptions {
+	var opts devtoolbox.HashOptions
+	if key, ok := config["key"].(string); ok {
+		opts.Key = key
 	}
-	return results, nil
+	return opts
+}
+
+// ListMethods describes the hash algorithms the service accepts, with the
+// config each reads
+func (s *HashGeneratorService) ListMethods() ([]devtoolbox.Method, error) {
+	return devtoolbox.HashMethods(), nil
+}
+
+// hashGeneratorStreams holds the HashGeneratorService operations on
+// streams. Only the HTTP server routes them, under HashGeneratorService:
+// the desktop bindings cannot carry an io.Reader.
+type hashGeneratorStreams struct{}
+
+// HashFile hashes a stream, such as an uploaded file, without holding it in memory
+func (hashGeneratorStreams) HashFile(ctx context.Context, input io.Reader, method string, config map[string]interface{}) (string, error) {
+	return devtoolbox.HashReader(ctx, input, devtoolbox.HashAlgorithm(method), hashOptions(config))
 }
@@ -1,12 +1,13 @@
 package service
 
 import (
+	"context"
 	"testing"
 )
 
 func TestHashGeneratorService_Hash(t *testing.T) {
 	svc := NewHashGeneratorService(nil)
-	result, err := svc.Hash("hello", "MD5", nil)
+	result, err := svc.Hash(context.Background(), "hello", "MD5", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -17,7 +18,7 @@ func TestHashGeneratorService_Hash(t *testing.T) {
 
 func TestHashGeneratorService_HashAll(t *testing.T) {
 	svc := NewHashGeneratorService(nil)
-	results, err := svc.HashAll("hello")
+	results, err := svc.HashAll(context.Background(), "hello")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
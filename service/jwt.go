@@ -2,24 +2,22 @@ package service
 
 import (
 	"context"
-	"devtoolbox/internal/jwt"
 	"encoding/json"
 
+	"devtoolbox/internal/jwt"
+	"devtoolbox/pkg/devtoolbox"
+
 	"github.com/wailsapp/wails/v3/pkg/application"
 )
 
 // JWTService is the Wails binding struct for JWT operations
 type JWTService struct {
-	svc jwt.JWTService
 	app *application.App
 }
 
 // NewJWTService creates a new JWTService instance
 func NewJWTService(app *application.App) *JWTService {
-	return &JWTService{
-		svc: jwt.NewJWTService(jwt.NewParser()),
-		app: app,
-	}
+	return &JWTService{app: app}
 }
 
 // ServiceStartup is called when the app starts (Wails lifecycle)
@@ -30,33 +28,30 @@ func (j *JWTService) ServiceStartup(ctx context.Context, options application.Ser
 // Decode decodes a JWT token
 // This method is exposed to the frontend via Wails
 func (j *JWTService) Decode(token string) (jwt.DecodeResponse, error) {
-	result, err := j.svc.Decode(token)
+	result, err := devtoolbox.DecodeJWT(context.Background(), token)
 	if err != nil {
-		return jwt.DecodeResponse{
-			Header:    nil,
-			Payload:   nil,
-			Signature: "",
-			Valid:     false,
-			Error:     err.Error(),
-		}, nil
+		return jwt.FromToken(nil, err), nil
 	}
-
-	return jwt.FromToken(result), nil
+	return jwt.FromToken(&jwt.Token{
+		Raw:       result.Raw,
+		Header:    result.Header,
+		Payload:   result.Payload,
+		Signature: result.Signature,
+		Valid:     result.Valid,
+	}, nil), nil
 }
 
 // Verify verifies a JWT signature with secret
 // This method is exposed to the frontend via Wails
 func (j *JWTService) Verify(token, secret, encoding string) (jwt.VerifyResponse, error) {
-	result, err := j.svc.Verify(token, secret, encoding)
+	result, err := devtoolbox.VerifyJWT(context.Background(), token, devtoolbox.VerifyOptions{
+		Secret:       secret,
+		Base64Secret: encoding == "base64",
+	})
 	if err != nil {
-		return jwt.VerifyResponse{
-			Valid:   false,
-			Message: "",
-			Error:   err.Error(),
-		}, nil
+		return jwt.FromValidation(nil, err), nil
 	}
-
-	return jwt.FromValidation(result), nil
+	return jwt.FromValidation(&jwt.ValidationResult{Valid: result.Valid, Message: result.Message}, nil), nil
 }
 
 // Encode creates a signed JWT token from header JSON, payload JSON, algorithm, and secret
@@ -66,10 +61,7 @@ func (j *JWTService) Encode(headerJSON, payloadJSON, algorithm, secret string) (
 	var header map[string]interface{}
 	if headerJSON != "" {
 		if err := json.Unmarshal([]byte(headerJSON), &header); err != nil {
-			return jwt.EncodeResponse{
-				Token: "",
-				Error: "Invalid header JSON: " + err.Error(),
-			}, nil
+			return jwt.FromEncoded("", jwt.NewJWTError("INVALID_INPUT", "Invalid header JSON", err)), nil
 		}
 	}
 
@@ -77,24 +69,13 @@ func (j *JWTService) Encode(headerJSON, payloadJSON, algorithm, secret string) (
 	var payload map[string]interface{}
 	if payloadJSON != "" {
 		if err := json.Unmarshal([]byte(payloadJSON), &payload); err != nil {
-			return jwt.EncodeResponse{
-				Token: "",
-				Error: "Invalid payload JSON: " + err.Error(),
-			}, nil
+			return jwt.FromEncoded("", jwt.NewJWTError("INVALID_INPUT", "Invalid payload JSON", err)), nil
 		}
 	}
 
-	// Call service to encode
-	token, err := j.svc.Encode(header, payload, algorithm, secret)
-	if err != nil {
-		return jwt.EncodeResponse{
-			Token: "",
-			Error: err.Error(),
-		}, nil
-	}
-
-	return jwt.EncodeResponse{
-		Token: token,
-		Error: "",
-	}, nil
+	return jwt.FromEncoded(devtoolbox.EncodeJWT(context.Background(), payload, devtoolbox.SignOptions{
+		Algorithm: algorithm,
+		Secret:    secret,
+		Header:    header,
+	})), nil
 }
@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+
 	"devtoolbox/internal/numberconverter"
+	"devtoolbox/pkg/devtoolbox"
 
 	"github.com/wailsapp/wails/v3/pkg/application"
 )
@@ -10,15 +12,11 @@ import (
 // NumberConverterService is the Wails binding for number conversion
 type NumberConverterService struct {
 	app *application.App
-	svc *numberconverter.NumberConverterService
 }
 
 // NewNumberConverterService creates a new service instance
 func NewNumberConverterService(app *application.App) *NumberConverterService {
-	return &NumberConverterService{
-		app: app,
-		svc: numberconverter.NewNumberConverterService(),
-	}
+	return &NumberConverterService{app: app}
 }
 
 // ServiceStartup is called when the app starts
@@ -28,5 +26,23 @@ func (n *NumberConverterService) ServiceStartup(ctx context.Context, options app
 
 // Convert converts a number and returns all interpretations
 func (n *NumberConverterService) Convert(req numberconverter.ConvertRequest) numberconverter.ConvertResponse {
-	return n.svc.Convert(req)
+	resp, err := devtoolbox.ConvertNumber(context.Background(), req.Value, devtoolbox.NumberBase(req.Base))
+	if err != nil {
+		return numberconverter.ConvertResponse{Error: err.Error()}
+	}
+	return numberconverter.ConvertResponse{
+		Binary:     resp.Binary,
+		Decimal:    resp.Decimal,
+		Hex:        resp.Hex,
+		Octal:      resp.Octal,
+		Bits:       resp.Bits,
+		BitValues:  resp.BitValues,
+		Bytes:      numberconverter.ByteView(resp.Bytes),
+		ASCII:      numberconverter.ASCIIView(resp.ASCII),
+		Color:      numberconverter.ColorView(resp.Color),
+		IPv4:       numberconverter.IPv4View(resp.IPv4),
+		FileSize:   numberconverter.FileSizeView(resp.FileSize),
+		Timestamp:  numberconverter.TimestampView(resp.Timestamp),
+		Percentage: resp.Percentage,
+	}
 }
@@ -0,0 +1,115 @@
+// Code generated by genservices. DO NOT EDIT.
+
+package service
+
+// MethodParams maps "Service.Method" to the Go parameter names of each
+// exposed method. The HTTP router uses it to bind named request fields.
+var MethodParams = map[string][]string{
+	"BarcodeService.GenerateBarcode":          {"req"},
+	"BarcodeService.ValidateContent":          {"content", "standard"},
+	"CodeConverterService.Convert":            {"input", "method"},
+	"CodeFormatterService.Format":             {"req"},
+	"DataGeneratorService.Generate":           {"req"},
+	"DataGeneratorService.ValidateTemplate":   {"template"},
+	"DateTimeService.Convert":                 {"req"},
+	"DateTimeService.CalculateDelta":          {"req"},
+	"EncoderService.Encode":                   {"input", "method"},
+	"EncoderService.Decode":                   {"input", "method"},
+	"EncoderService.Escape":                   {"input", "method"},
+	"EncoderService.Unescape":                 {"input", "method"},
+	"EncoderService.EncodeFile":               {"input", "method"},
+	"EncoderService.DecodeFile":               {"input", "method"},
+	"EncrypterService.Encrypt":                {"input", "method", "key", "iv"},
+	"EncrypterService.Decrypt":                {"input", "method", "key", "iv"},
+	"HashGeneratorService.Hash":               {"input", "method", "config"},
+	"HashGeneratorService.HashAll":            {"input"},
+	"HashGeneratorService.HashFile":           {"input", "method", "config"},
+	"JWTService.Decode":                       {"token"},
+	"JWTService.Verify":                       {"token", "secret", "encoding"},
+	"JWTService.Encode":                       {"headerJSON", "payloadJSON", "algorithm", "secret"},
+	"NumberConverterService.Convert":          {"req"},
+	"RecipeService.Run":                       {"req"},
+	"RecipeService.RunSaved":                  {"name", "input"},
+	"RecipeService.Get":                       {"name"},
+	"RecipeService.Save":                      {"r"},
+	"RecipeService.Delete":                    {"name"},
+	"RecipeService.Import":                    {"file"},
+	"RecipeService.Export":                    {"name"},
+	"SettingsService.SetApp":                  {"app"},
+	"SettingsService.SetCloseMinimizesToTray": {"value"},
+	"SpotlightService.SetWindow":              {"window"},
+	"TextUtilitiesService.Escape":             {"input", "method"},
+	"TextUtilitiesService.Unescape":           {"input", "method"},
+	"TextUtilitiesService.SortLines":          {"input", "reverse"},
+	"TextUtilitiesService.RemoveDuplicates":   {"input"},
+	"TextUtilitiesService.TrimLines":          {"input"},
+	"TextUtilitiesService.RemoveEmptyLines":   {"input"},
+	"TextUtilitiesService.ConvertCase":        {"input", "targetCase"},
+	"TextUtilitiesService.GetStats":           {"input"},
+}
+
+// MethodDocs maps "Service.Method" to the first sentence of each exposed
+// method's doc comment. The HTTP router uses it to describe MCP tools and
+// API operations.
+var MethodDocs = map[string]string{
+	"BarcodeService.GenerateBarcode":             "Generates a barcode based on the selected standard",
+	"BarcodeService.GetBarcodeStandards":         "Returns available barcode standards",
+	"BarcodeService.GetQRErrorLevels":            "Returns available error correction levels for QR codes",
+	"BarcodeService.GetBarcodeSizes":             "Returns available barcode sizes",
+	"BarcodeService.ValidateContent":             "Validates content for specific barcode standards",
+	"CodeConverterService.Convert":               "Runs one conversion, named by method, over input",
+	"CodeConverterService.ListMethods":           "Describes the conversions the service accepts",
+	"CodeFormatterService.Format":                "Formats code based on the request",
+	"DataGeneratorService.Generate":              "Generates data based on the provided request",
+	"DataGeneratorService.GetPresets":            "Returns all available template presets",
+	"DataGeneratorService.ValidateTemplate":      "Validates a template string",
+	"DateTimeService.Convert":                    "Converts a timestamp or date string to all formats",
+	"DateTimeService.GetPresets":                 "Returns all available quick presets",
+	"DateTimeService.CalculateDelta":             "Calculates the difference between two dates",
+	"EncoderService.Encode":                      "Encodes input with the encoding named by method, e.g",
+	"EncoderService.Decode":                      "Reverses Encode for the encoding named by method",
+	"EncoderService.Escape":                      "Escapes input for the format named by method, e.g",
+	"EncoderService.Unescape":                    "Reverses Escape for the format named by method",
+	"EncoderService.ListMethods":                 "Describes the encodings and escapings the service accepts, with the directions and config each supports",
+	"EncoderService.EncodeFile":                  "Encodes a stream, such as an uploaded file, returning the encoded stream",
+	"EncoderService.DecodeFile":                  "Decodes a stream, returning the decoded bytes as a stream",
+	"EncrypterService.Encrypt":                   "Encrypts input with the cipher named by method, using key and iv where the cipher needs them",
+	"EncrypterService.Decrypt":                   "Reverses Encrypt for the cipher named by method",
+	"EncrypterService.ListMethods":               "Describes the ciphers the service accepts, with the key and IV each requires",
+	"HashGeneratorService.Hash":                  "Hashes input with the algorithm named by method",
+	"HashGeneratorService.HashAll":               "Hashes input with every fast algorithm, keyed by algorithm name",
+	"HashGeneratorService.ListMethods":           "Describes the hash algorithms the service accepts, with the config each reads",
+	"HashGeneratorService.HashFile":              "Hashes a stream, such as an uploaded file, without holding it in memory",
+	"JWTService.Decode":                          "Decodes a JWT token",
+	"JWTService.Verify":                          "Verifies a JWT signature with secret",
+	"JWTService.Encode":                          "Creates a signed JWT token from header JSON, payload JSON, algorithm, and secret",
+	"NumberConverterService.Convert":             "Converts a number and returns all interpretations",
+	"RecipeService.ListSteps":                    "Describes the methods a recipe step may run, with the config each reads",
+	"RecipeService.Run":                          "Runs a recipe on the input and returns the output of every step",
+	"RecipeService.RunSaved":                     "Runs the recipe saved under name on the input",
+	"RecipeService.List":                         "Returns the saved recipes sorted by name",
+	"RecipeService.Get":                          "Returns the recipe saved under name",
+	"RecipeService.Save":                         "Validates and saves a recipe, replacing one of the same name or one whose name differs only in case",
+	"RecipeService.Delete":                       "Removes the recipe saved under name",
+	"RecipeService.Import":                       "Saves the recipe in an uploaded recipe file, as Save does",
+	"RecipeService.Export":                       "Returns the recipe saved under name as a recipe file",
+	"SettingsService.SetApp":                     "Connects the service to the Wails app after application creation",
+	"SettingsService.GetCloseMinimizesToTray":    "Returns the current setting",
+	"SettingsService.SetCloseMinimizesToTray":    "Updates the setting",
+	"SettingsService.ToggleCloseMinimizesToTray": "Toggles the setting",
+	"SpotlightService.SetWindow":                 "Sets the spotlight window (called after window creation)",
+	"SpotlightService.Show":                      "Shows the spotlight window and focuses it",
+	"SpotlightService.Hide":                      "Hides the spotlight window",
+	"SpotlightService.Toggle":                    "Shows or hides the spotlight window",
+	"SpotlightService.IsVisible":                 "Returns whether the spotlight window is visible",
+	"SpotlightService.Close":                     "Closes the spotlight window",
+	"TextUtilitiesService.Escape":                "Escapes input for the format named by method, e.g",
+	"TextUtilitiesService.Unescape":              "Reverses Escape for the format named by method",
+	"TextUtilitiesService.ListMethods":           "Describes the escapings Escape and Unescape accept",
+	"TextUtilitiesService.SortLines":             "Sorts the lines of input, in reverse when reverse is set",
+	"TextUtilitiesService.RemoveDuplicates":      "Drops repeated lines from input, keeping the first of each",
+	"TextUtilitiesService.TrimLines":             "Trims leading and trailing whitespace from every line of input",
+	"TextUtilitiesService.RemoveEmptyLines":      "Drops blank lines from input",
+	"TextUtilitiesService.ConvertCase":           "Converts input to targetCase, e.g",
+	"TextUtilitiesService.GetStats":              "Counts the characters, words, lines, bytes and sentences in input",
+}
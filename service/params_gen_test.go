@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// TestMethodParams_MatchSignatures guards against a stale params_gen.go;
+// regenerate it with cmd/genservices when this fails.
+func TestMethodParams_MatchSignatures(t *testing.T) {
+	// A service's stream operations are routed as its own
+	services := map[string][]interface{}{
+		"BarcodeService":         {NewBarcodeService(nil)},
+		"CodeConverterService":   {NewCodeConverterService(nil)},
+		"CodeFormatterService":   {NewCodeFormatterService(nil)},
+		"DataGeneratorService":   {NewDataGeneratorService(nil)},
+		"DateTimeService":        {NewDateTimeService(nil)},
+		"EncoderService":         {NewEncoderService(nil), &encoderStreams{}},
+		"EncrypterService":       {NewEncrypterService(nil)},
+		"HashGeneratorService":   {NewHashGeneratorService(nil), &hashGeneratorStreams{}},
+		"JWTService":             {NewJWTService(nil)},
+		"NumberConverterService": {NewNumberConverterService(nil)},
+		"RecipeService":          {NewRecipeService(nil, "")},
+		"SettingsService":        {NewSettingsService(nil, nil, nil)},
+		"SpotlightService":       {NewSpotlightService(nil, nil)},
+		"TextUtilitiesService":   {NewTextUtilitiesService(nil)},
+		"ThemesService":          {NewThemesService(nil, "")},
+	}
+
+	for key, names := range MethodParams {
+		serviceName, methodName, _ := strings.Cut(key, ".")
+		svcs, ok := services[serviceName]
+		if !ok {
+			continue
+		}
+
+		var method reflect.Method
+		for _, svc := range svcs {
+			if method, ok = reflect.TypeOf(svc).MethodByName(methodName); ok {
+				break
+			}
+		}
+		if assert.True(t, ok, "%s no longer exists", key) {
+			// The receiver and a leading context.Context have no name
+			params := method.Type.NumIn() - 1
+			if params > 0 && method.Type.In(1) == contextType {
+				params--
+			}
+			assert.Len(t, names, params, "%s parameter count changed", key)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"devtoolbox/internal/recipe"
+	"devtoolbox/pkg/devtoolbox"
+
+	"github.com/wailsapp/wails/v3/pkg/application"
+)
+
+// RecipeService runs recipes, chains of conversions, and keeps the saved
+// ones as JSON files in a directory
+type RecipeService struct {
+	app   *application.App
+	store *recipe.Store
+}
+
+func NewRecipeService(app *application.App, recipesDir string) *RecipeService {
+	return &RecipeService{
+		app:   app,
+		store: recipe.NewStore(recipesDir),
+	}
+}
+
+func (s *RecipeService) ServiceStartup(ctx context.Context, options application.ServiceOptions) error {
+	return nil
+}
+
+// ListSteps describes the methods a recipe step may run, with the config
+// each reads
+func (s *RecipeService) ListSteps() ([]devtoolbox.Method, error) {
+	return devtoolbox.RecipeSteps(), nil
+}
+
+// Run runs a recipe on the input and returns the output of every step. A
+// failed step stops the run and is reported with the steps before it.
+func (s *RecipeService) Run(ctx context.Context, req recipe.RunRequest) recipe.RunResponse {
+	return recipe.NewRunResponse(devtoolbox.RunRecipe(ctx, req.Recipe, req.Input))
+}
+
+// RunSaved runs the recipe saved under name on the input
+func (s *RecipeService) RunSaved(ctx context.Context, name, input string) recipe.RunResponse {
+	r, err := s.store.Get(name)
+	if err != nil {
+		return recipe.NewRunResponse(devtoolbox.RecipeRun{}, err)
+	}
+	return recipe.NewRunResponse(devtoolbox.RunRecipe(ctx, r, input))
+}
+
+// List returns the saved recipes sorted by name
+func (s *RecipeService) List() ([]devtoolbox.Recipe, error) {
+	return s.store.List()
+}
+
+// Get returns the recipe saved under name
+func (s *RecipeService) Get(name string) (devtoolbox.Recipe, error) {
+	return s.store.Get(name)
+}
+
+// Save validates and saves a recipe, replacing one of the same name or one
+// whose name differs only in case. A recipe whose name differs only in
+// punctuation shares its file and fails with RECIPE_EXISTS.
+func (s *RecipeService) Save(r devtoolbox.Recipe) (devtoolbox.Recipe, error) {
+	if err := devtoolbox.ValidateRecipe(r); err != nil {
+		return devtoolbox.Recipe{}, err
+	}
+	if err := s.store.Save(r); err != nil {
+		return devtoolbox.Recipe{}, err
+	}
+	return r, nil
+}
+
+// Delete removes the recipe saved under name
+func (s *RecipeService) Delete(name string) error {
+	return s.store.Delete(name)
+}
+
+// Import saves the recipe in an uploaded recipe file, as Save does
+func (s *RecipeService) Import(file io.Reader) (devtoolbox.Recipe, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return devtoolbox.Recipe{}, err
+	}
+	r, err := devtoolbox.ParseRecipe(data)
+	if err != nil {
+		return devtoolbox.Recipe{}, err
+	}
+	return s.Save(r)
+}
+
+// Export returns the recipe saved under name as a recipe file
+func (s *RecipeService) Export(name string) (io.Reader, error) {
+	r, err := s.store.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := devtoolbox.MarshalRecipe(r)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(data), nil
+}
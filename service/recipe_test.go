@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"devtoolbox/internal/recipe"
+	"devtoolbox/pkg/devtoolbox"
+	sharedErrors "devtoolbox/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecipeService(t *testing.T) {
+	ctx := context.Background()
+	svc := NewRecipeService(nil, t.TempDir())
+
+	steps, err := svc.ListSteps()
+	require.NoError(t, err)
+	assert.NotEmpty(t, steps)
+
+	r := devtoolbox.Recipe{Name: "Shout Base64", Steps: []devtoolbox.RecipeStep{
+		{Category: "Encode - Decode", Method: "Base64", Config: map[string]interface{}{"subMode": "Decode"}},
+		{Category: "Convert", Method: "Case Swapping"},
+	}}
+	saved, err := svc.Save(r)
+	require.NoError(t, err)
+	assert.Equal(t, r, saved)
+
+	resp := svc.RunSaved(ctx, "shout base64", "aGVsbG8=")
+	require.NoError(t, resp.Err())
+	require.Len(t, resp.Steps, 2)
+	assert.Equal(t, "hello", resp.Steps[0].Output)
+	assert.Equal(t, "HELLO", resp.Output)
+
+	exported, err := svc.Export(r.Name)
+	require.NoError(t, err)
+	data, err := io.ReadAll(exported)
+	require.NoError(t, err)
+
+	require.NoError(t, svc.Delete(r.Name))
+	imported, err := svc.Import(strings.NewReader(string(data)))
+	require.NoError(t, err)
+	assert.Equal(t, r, imported)
+
+	recipes, err := svc.List()
+	require.NoError(t, err)
+	assert.Equal(t, []devtoolbox.Recipe{r}, recipes)
+}
+
+func TestRecipeService_Failures(t *testing.T) {
+	ctx := context.Background()
+	svc := NewRecipeService(nil, t.TempDir())
+
+	resp := svc.Run(ctx, recipe.RunRequest{
+		Recipe: devtoolbox.Recipe{Steps: []devtoolbox.RecipeStep{
+			{Category: "Hash", Method: "MD5"},
+			{Category: "Format", Method: "JSON"},
+		}},
+		Input: "hello",
+	})
+	require.Error(t, resp.Err())
+	assert.NotEmpty(t, resp.Error)
+	assert.Len(t, resp.Steps, 2, "the failed step is reported with the ones before it")
+
+	resp = svc.RunSaved(ctx, "missing", "hello")
+	assert.ErrorIs(t, resp.Err(), recipe.ErrRecipeNotFound)
+	assert.Empty(t, resp.Steps)
+
+	_, err := svc.Save(devtoolbox.Recipe{Name: "empty"})
+	assert.Equal(t, devtoolbox.ErrCodeInvalidRecipe, sharedErrors.CodeOf(err))
+
+	_, err = svc.Import(strings.NewReader("not json"))
+	assert.Error(t, err)
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"devtoolbox/internal/settings"
+	"devtoolbox/pkg/events"
 	"log"
 	"sync"
 
@@ -9,17 +10,22 @@ import (
 )
 
 // SettingsService provides settings management via Wails bindings
+//
+//genservices:desktop
 type SettingsService struct {
 	app     *application.App
 	manager *settings.Manager
+	events  *events.Bus
 	mu      sync.RWMutex
 }
 
-// NewSettingsService creates a new settings service
-func NewSettingsService(app *application.App, manager *settings.Manager) *SettingsService {
+// NewSettingsService creates a new settings service that publishes its
+// change events on bus
+func NewSettingsService(app *application.App, manager *settings.Manager, bus *events.Bus) *SettingsService {
 	return &SettingsService{
 		app:     app,
 		manager: manager,
+		events:  bus,
 	}
 }
 
@@ -60,11 +66,7 @@ func (s *SettingsService) ToggleCloseMinimizesToTray() (bool, error) {
 }
 
 func (s *SettingsService) emitSettingsChanged(setting string, value bool) {
-	if s.app == nil {
-		return
-	}
-
-	s.app.Event.Emit("settings:changed", map[string]interface{}{
+	s.events.Publish(events.SettingsChanged, map[string]interface{}{
 		"setting": setting,
 		"value":   value,
 	})
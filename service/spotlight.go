@@ -1,19 +1,26 @@
 package service
 
 import (
+	"devtoolbox/pkg/events"
+
 	"github.com/wailsapp/wails/v3/pkg/application"
 )
 
 // SpotlightService manages the spotlight command palette window
+//
+//genservices:desktop
 type SpotlightService struct {
 	window *application.WebviewWindow
 	app    *application.App
+	events *events.Bus
 }
 
-// NewSpotlightService creates a new spotlight service
-func NewSpotlightService(app *application.App) *SpotlightService {
+// NewSpotlightService creates a new spotlight service that publishes its
+// events on bus
+func NewSpotlightService(app *application.App, bus *events.Bus) *SpotlightService {
 	return &SpotlightService{
-		app: app,
+		app:    app,
+		events: bus,
 	}
 }
 
@@ -30,7 +37,7 @@ func (s *SpotlightService) Show() {
 
 	s.window.Show()
 	s.window.Focus()
-	s.window.EmitEvent("spotlight:opened", "")
+	s.events.Publish(events.SpotlightOpened, "")
 }
 
 // Hide hides the spotlight window
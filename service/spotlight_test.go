@@ -8,7 +8,7 @@ import (
 
 func TestNewSpotlightService(t *testing.T) {
 	t.Run("creates new service", func(t *testing.T) {
-		service := NewSpotlightService(nil)
+		service := NewSpotlightService(nil, nil)
 		assert.NotNil(t, service)
 	})
 }
@@ -56,7 +56,7 @@ func TestSpotlightService_Operations(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			service := NewSpotlightService(nil)
+			service := NewSpotlightService(nil, nil)
 			tt.test(t, service)
 		})
 	}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"devtoolbox/pkg/tools"
+	"fmt"
+
+	"github.com/wailsapp/wails/v3/pkg/application"
+)
+
+// NewToolRegistry creates the registry of every tool in sidebar order, with a
+// fresh instance of each tool's service. The Wails app, the HTTP server and
+// spotlight are all built from it, so a new tool only needs adding here.
+func NewToolRegistry(app *application.App) *tools.Registry {
+	return tools.NewRegistry(
+		tools.Tool{
+			ID:         "code-encoder",
+			Name:       "Code Encoder",
+			Keywords:   []string{"base64", "base32", "base58", "url", "hex", "html entities", "decode"},
+			Categories: []string{"Text"},
+			Service:    NewEncoderService(app),
+			Streams:    &encoderStreams{},
+		},
+		tools.Tool{
+			ID:         "code-encrypter",
+			Name:       "Code Encrypter",
+			Keywords:   []string{"aes", "des", "triple des", "rc4", "chacha20", "encrypt", "decrypt", "cipher"},
+			Categories: []string{"Security"},
+			Service:    NewEncrypterService(app),
+		},
+		tools.Tool{
+			ID:         "hash-generator",
+			Name:       "Hash Generator",
+			Keywords:   []string{"md5", "sha1", "sha256", "sha512", "bcrypt", "checksum", "hmac"},
+			Categories: []string{"Security"},
+			Service:    NewHashGeneratorService(app),
+			Streams:    &hashGeneratorStreams{},
+		},
+		tools.Tool{
+			ID:         "code-converter",
+			Name:       "Code Converter",
+			Keywords:   []string{"json", "yaml", "xml", "csv", "tsv", "markdown", "html", "case"},
+			Categories: []string{"Developer"},
+			Service:    NewCodeConverterService(app),
+		},
+		tools.Tool{
+			ID:         "text-utilities",
+			Name:       "Text Utilities",
+			Keywords:   []string{"escape", "case", "sort", "lines", "duplicates", "trim", "stats"},
+			Categories: []string{"Text"},
+			Service:    NewTextUtilitiesService(app),
+		},
+		tools.Tool{
+			ID:         "number-converter",
+			Name:       "Number Converter",
+			Keywords:   []string{"binary", "octal", "decimal", "hex", "base"},
+			Categories: []string{"Data"},
+			Service:    NewNumberConverterService(app),
+		},
+		tools.Tool{
+			ID:         "datetime-converter",
+			Name:       "DateTime Converter",
+			Keywords:   []string{"timestamp", "unix", "epoch", "timezone", "iso 8601", "date", "time"},
+			Categories: []string{"Data"},
+			Service:    NewDateTimeService(app),
+		},
+		tools.Tool{
+			ID:         "jwt",
+			Name:       "JWT Debugger",
+			Keywords:   []string{"token", "json web token", "decode", "verify", "claims"},
+			Categories: []string{"Security"},
+			Service:    NewJWTService(app),
+		},
+		tools.Tool{
+			ID:         "barcode",
+			Name:       "Barcode / QR Code",
+			Keywords:   []string{"qr", "ean", "code128", "image"},
+			Categories: []string{"Generator"},
+			Service:    NewBarcodeService(app),
+		},
+		tools.Tool{
+			ID:         "data-generator",
+			Name:       "Data Generator",
+			Keywords:   []string{"fake", "mock", "random", "uuid", "user", "address"},
+			Categories: []string{"Generator"},
+			Service:    NewDataGeneratorService(app),
+		},
+		tools.Tool{
+			ID:         "code-formatter",
+			Name:       "Code Formatter",
+			Keywords:   []string{"json", "xml", "html", "css", "sql", "beautify", "minify", "jq", "xpath"},
+			Categories: []string{"Developer"},
+			Service:    NewCodeFormatterService(app),
+		},
+		tools.Tool{
+			ID:         "color-converter",
+			Name:       "Color Converter",
+			Keywords:   []string{"rgb", "hsl", "hex", "palette", "harmony"},
+			Categories: []string{"Developer"},
+		},
+		tools.Tool{
+			ID:         "url-inspector",
+			Name:       "URL Inspector",
+			Keywords:   []string{"query", "parse", "link"},
+			Categories: []string{"Developer"},
+		},
+		tools.Tool{
+			ID:         "cron",
+			Name:       "Cron Job Parser",
+			Keywords:   []string{"crontab", "schedule"},
+			Categories: []string{"Developer"},
+		},
+		tools.Tool{
+			ID:         "regexp",
+			Name:       "RegExp Tester",
+			Keywords:   []string{"regex", "pattern", "match"},
+			Categories: []string{"Developer"},
+		},
+		tools.Tool{
+			ID:         "diff",
+			Name:       "Text Diff",
+			Keywords:   []string{"compare", "difference"},
+			Categories: []string{"Text"},
+		},
+	)
+}
+
+// ToolCatalog lists the registry's tools to the desktop sidebar and
+// spotlight through Wails bindings. Browsers read GET /api/tools instead.
+type ToolCatalog struct {
+	registry *tools.Registry
+}
+
+// ToolEntry describes a tool and its frontend route
+type ToolEntry struct {
+	tools.Tool
+	Path string `json:"path"`
+}
+
+// NewToolCatalog creates the catalog of the registry's tools
+func NewToolCatalog(registry *tools.Registry) *ToolCatalog {
+	return &ToolCatalog{registry: registry}
+}
+
+// List returns the tools in display order
+func (c *ToolCatalog) List() []ToolEntry {
+	all := c.registry.All()
+	entries := make([]ToolEntry, len(all))
+	for i, tool := range all {
+		entries[i] = ToolEntry{Tool: tool, Path: tool.Path()}
+	}
+	return entries
+}
+
+// BindTools returns the Wails bindings of the registry's services. The
+// bindings generator finds service types through their application.NewService
+// calls, so every tool service type is listed; an unlisted one panics at
+// startup instead of silently missing from the frontend.
+func BindTools(registry *tools.Registry) []application.Service {
+	var bindings []application.Service
+	for _, svc := range registry.Services() {
+		switch s := svc.(type) {
+		case *EncoderService:
+			bindings = append(bindings, application.NewService(s))
+		case *EncrypterService:
+			bindings = append(bindings, application.NewService(s))
+		case *HashGeneratorService:
+			bindings = append(bindings, application.NewService(s))
+		case *CodeConverterService:
+			bindings = append(bindings, application.NewService(s))
+		case *TextUtilitiesService:
+			bindings = append(bindings, application.NewService(s))
+		case *NumberConverterService:
+			bindings = append(bindings, application.NewService(s))
+		case *DateTimeService:
+			bindings = append(bindings, application.NewService(s))
+		case *JWTService:
+			bindings = append(bindings, application.NewService(s))
+		case *BarcodeService:
+			bindings = append(bindings, application.NewService(s))
+		case *DataGeneratorService:
+			bindings = append(bindings, application.NewService(s))
+		case *CodeFormatterService:
+			bindings = append(bindings, application.NewService(s))
+		default:
+			panic(fmt.Sprintf("service: no Wails binding for %T", svc))
+		}
+	}
+	return bindings
+}
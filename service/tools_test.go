@@ -0,0 +1,45 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewToolRegistry(t *testing.T) {
+	registry := NewToolRegistry(nil)
+
+	for _, tool := range registry.All() {
+		assert.NotEmpty(t, tool.Name, tool.ID)
+		assert.NotEmpty(t, tool.Categories, tool.ID)
+	}
+
+	// Every tool service has a Wails binding and generated parameter names
+	require.NotPanics(t, func() {
+		assert.Len(t, BindTools(registry), len(registry.Services()))
+	})
+	for _, tool := range registry.All() {
+		if tool.Service == nil {
+			continue
+		}
+		found := false
+		for key := range MethodParams {
+			if strings.HasPrefix(key, tool.ServiceName()+".") {
+				found = true
+				break
+			}
+		}
+		assert.True(t, found, "%s has no entry in MethodParams; run cmd/genservices", tool.ServiceName())
+	}
+}
+
+func TestToolCatalog_List(t *testing.T) {
+	registry := NewToolRegistry(nil)
+	entries := NewToolCatalog(registry).List()
+
+	require.Len(t, entries, len(registry.All()))
+	assert.Equal(t, "code-encoder", entries[0].ID)
+	assert.Equal(t, "/tool/code-encoder", entries[0].Path)
+}